@@ -0,0 +1,41 @@
+
+	terraform {
+		required_providers {
+			aws = {
+				source  = "hashicorp/aws"
+			}
+			google = {
+				source  = "hashicorp/google"
+			}
+			azurerm = {
+				source  = "hashicorp/azurerm"
+			}
+		}
+	}
+
+	provider "aws" {
+		region                      = "us-east-1"
+		s3_force_path_style         = true
+		skip_credentials_validation = true
+		skip_metadata_api_check     = true
+		skip_requesting_account_id  = true
+		skip_get_ec2_platforms      = true
+		skip_region_validation      = true
+		access_key                  = "mock_access_key"
+		secret_key                  = "mock_secret_key"
+	}
+
+	provider "google" {
+		credentials = "{\"type\":\"service_account\"}"
+		region = "us-central1"
+	}
+
+	provider "google-beta" {
+		credentials = "{\"type\":\"service_account\"}"
+		region = "us-central1"
+	}
+
+	provider "azurerm" {
+		skip_provider_registration = true
+		features {}
+	}
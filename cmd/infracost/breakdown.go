@@ -22,15 +22,18 @@ func breakdownCmd(ctx *config.RunContext) *cobra.Command {
       infracost breakdown --path plan.json`,
 		ValidArgs: []string{"--", "-"},
 		RunE: func(cmd *cobra.Command, args []string) error {
-			if err := checkAPIKey(ctx.Config.APIKey, ctx.Config.PricingAPIEndpoint, ctx.Config.DefaultPricingAPIEndpoint); err != nil {
-				return err
-			}
-
 			err := loadRunFlags(ctx.Config, cmd)
 			if err != nil {
 				return err
 			}
 
+			// The API key isn't needed when prices are looked up from a local snapshot
+			if ctx.Config.PricingSnapshot == "" {
+				if err := checkAPIKey(ctx.Config.APIKey, ctx.Config.PricingAPIEndpoint, ctx.Config.DefaultPricingAPIEndpoint); err != nil {
+					return err
+				}
+			}
+
 			ctx.SetContextValue("outputFormat", ctx.Config.Format)
 
 			err = checkRunConfig(cmd.ErrOrStderr(), ctx.Config)
@@ -46,11 +49,12 @@ func breakdownCmd(ctx *config.RunContext) *cobra.Command {
 	addRunFlags(cmd)
 
 	cmd.Flags().Bool("terraform-use-state", false, "Use Terraform state instead of generating a plan. Applicable when path is a Terraform directory")
-	cmd.Flags().String("format", "table", "Output format: json, table, html")
-	cmd.Flags().StringSlice("fields", []string{"monthlyQuantity", "unit", "monthlyCost"}, "Comma separated list of output fields: all,price,monthlyQuantity,unit,hourlyCost,monthlyCost.\nSupported by table and html output formats")
+	cmd.Flags().String("format", "table", "Output format: json, table, html, markdown, csv")
+	cmd.Flags().String("group-by", "", "Group costs by tag:<key>, resource_type, provider or module.\nSupported by table and json output formats")
+	cmd.Flags().StringSlice("fields", []string{"monthlyQuantity", "unit", "monthlyCost"}, "Comma separated list of output fields: all,price,monthlyQuantity,unit,hourlyCost,monthlyCost.\nSupported by table, html, markdown and csv output formats")
 
 	_ = cmd.RegisterFlagCompletionFunc("format", func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
-		return []string{"table", "json", "html"}, cobra.ShellCompDirectiveDefault
+		return []string{"table", "json", "html", "markdown", "csv"}, cobra.ShellCompDirectiveDefault
 	})
 
 	return cmd
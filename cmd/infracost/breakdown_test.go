@@ -1,6 +1,7 @@
 package main_test
 
 import (
+	"path/filepath"
 	"testing"
 
 	"github.com/infracost/infracost/internal/testutil"
@@ -91,3 +92,9 @@ func TestBreakdownTerraform_v0_14(t *testing.T) {
 func TestBreakdownTerraformUseState_v0_14(t *testing.T) {
 	GoldenFileCommandTest(t, testutil.CalcGoldenFileTestdataDirName(), []string{"breakdown", "--path", "./testdata/terraform_v0.14_state.json", "--terraform-use-state"}, nil)
 }
+
+func TestBreakdownPricingSnapshot(t *testing.T) {
+	testName := testutil.CalcGoldenFileTestdataDirName()
+	dir := filepath.Join("./testdata", testName)
+	GoldenFileCommandTest(t, testName, []string{"breakdown", "--path", filepath.Join(dir, "nat_gateway_plan.json"), "--pricing-snapshot", filepath.Join(dir, "pricing_snapshot.json")}, nil)
+}
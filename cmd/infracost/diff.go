@@ -23,15 +23,18 @@ func diffCmd(ctx *config.RunContext) *cobra.Command {
       infracost diff --path plan.json`,
 		ValidArgs: []string{"--", "-"},
 		RunE: func(cmd *cobra.Command, args []string) error {
-			if err := checkAPIKey(ctx.Config.APIKey, ctx.Config.PricingAPIEndpoint, ctx.Config.DefaultPricingAPIEndpoint); err != nil {
-				return err
-			}
-
 			err := loadRunFlags(ctx.Config, cmd)
 			if err != nil {
 				return err
 			}
 
+			// The API key isn't needed when prices are looked up from a local snapshot
+			if ctx.Config.PricingSnapshot == "" {
+				if err := checkAPIKey(ctx.Config.APIKey, ctx.Config.PricingAPIEndpoint, ctx.Config.DefaultPricingAPIEndpoint); err != nil {
+					return err
+				}
+			}
+
 			err = checkRunConfig(cmd.ErrOrStderr(), ctx.Config)
 			if err != nil {
 				ui.PrintUsage(cmd)
@@ -44,7 +47,9 @@ func diffCmd(ctx *config.RunContext) *cobra.Command {
 				return err
 			}
 
-			ctx.Config.Format = "diff"
+			if ctx.Config.Format != "markdown" {
+				ctx.Config.Format = "diff"
+			}
 
 			return runMain(cmd, ctx)
 		},
@@ -52,6 +57,12 @@ func diffCmd(ctx *config.RunContext) *cobra.Command {
 
 	addRunFlags(cmd)
 
+	cmd.Flags().String("format", "diff", "Output format: diff, markdown")
+
+	_ = cmd.RegisterFlagCompletionFunc("format", func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		return []string{"diff", "markdown"}, cobra.ShellCompDirectiveDefault
+	})
+
 	return cmd
 }
 
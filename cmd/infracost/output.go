@@ -31,11 +31,45 @@ func outputCmd(ctx *config.RunContext) *cobra.Command {
 
       infracost output --format html --path "out*.json" > output.html
 
+  Create a markdown pull request comment from multiple Infracost JSON files:
+
+      infracost output --format markdown --path "out*.json" > comment.md
+
+  Export the cost components from multiple Infracost JSON files as a spreadsheet:
+
+      infracost output --format csv --fields all --path "out*.json" > costs.csv
+
+  Show the cost of each team from multiple Infracost JSON files:
+
+      infracost output --path "out*.json" --group-by tag:team
+
   Merge multiple Infracost JSON files:
 
-      infracost output --format json --path "out*.json"`,
+      infracost output --format json --path "out*.json"
+
+  Check the cost and tag policies in a config file against an Infracost JSON file:
+
+      infracost output --path out.json --config-file infracost.yml
+
+  Fail if any project in an Infracost JSON file is over its monthly budget:
+
+      infracost output --path out.json --fail-on-budget-breach`,
 		ValidArgs: []string{"--", "-"},
 		RunE: func(cmd *cobra.Command, args []string) error {
+			if cmd.Flags().Changed("config-file") {
+				cfgFilePath, _ := cmd.Flags().GetString("config-file")
+				err := ctx.Config.LoadPoliciesFromConfigFile(cfgFilePath)
+				if err != nil {
+					return err
+				}
+			}
+
+			groupBy, _ := cmd.Flags().GetString("group-by")
+			if err := checkGroupBy(groupBy); err != nil {
+				ui.PrintUsage(cmd)
+				return err
+			}
+
 			inputFiles := []string{}
 
 			paths, _ := cmd.Flags().GetStringArray("path")
@@ -119,15 +153,24 @@ func outputCmd(ctx *config.RunContext) *cobra.Command {
 
 			combined := output.Combine(currency, inputs, opts)
 
+			if groupBy != "" {
+				combined.GroupBy = groupBy
+				combined.Groups = output.GroupCosts(combined, groupBy)
+			}
+
+			if len(ctx.Config.TagPolicies) > 0 {
+				combined.TagPolicyViolations = output.CheckTagPolicies(combined, ctx.Config.TagPolicies)
+			}
+
 			var (
 				b   []byte
 				err error
 			)
 
-			validFieldsFormats := []string{"table", "html"}
+			validFieldsFormats := []string{"table", "html", "markdown", "csv"}
 
 			if cmd.Flags().Changed("fields") && !contains(validFieldsFormats, format) {
-				ui.PrintWarning(cmd.ErrOrStderr(), "fields is only supported for table and html output formats")
+				ui.PrintWarning(cmd.ErrOrStderr(), "fields is only supported for table, html, markdown and csv output formats")
 			}
 			switch strings.ToLower(format) {
 			case "json":
@@ -136,6 +179,10 @@ func outputCmd(ctx *config.RunContext) *cobra.Command {
 				b, err = output.ToHTML(combined, opts)
 			case "diff":
 				b, err = output.ToDiff(combined, opts)
+			case "markdown":
+				b, err = output.ToMarkdown(combined, opts)
+			case "csv":
+				b, err = output.ToCSV(combined, opts)
 			default:
 				b, err = output.ToTable(combined, opts)
 			}
@@ -145,7 +192,21 @@ func outputCmd(ctx *config.RunContext) *cobra.Command {
 
 			cmd.Println(string(b))
 
-			return nil
+			if err := policyError(output.CheckPolicies(combined, ctx.Config.Policies)); err != nil {
+				return err
+			}
+
+			if cmd.Flags().Changed("fail-on-budget-breach") {
+				ctx.Config.FailOnBudgetBreach, _ = cmd.Flags().GetBool("fail-on-budget-breach")
+			}
+
+			if ctx.Config.FailOnBudgetBreach {
+				if err := budgetError(combined); err != nil {
+					return err
+				}
+			}
+
+			return tagPolicyError(combined)
 		},
 	}
 
@@ -153,12 +214,17 @@ func outputCmd(ctx *config.RunContext) *cobra.Command {
 	_ = cmd.MarkFlagRequired("path")
 	_ = cmd.MarkFlagFilename("path", "json")
 
-	cmd.Flags().String("format", "table", "Output format: json, diff, table, html")
+	cmd.Flags().String("config-file", "", "Path to Infracost config file with cost and tag policies to check")
+	_ = cmd.MarkFlagFilename("config-file", "yml")
+
+	cmd.Flags().String("format", "table", "Output format: json, diff, table, html, markdown, csv")
+	cmd.Flags().String("group-by", "", "Group costs by tag:<key>, resource_type, provider or module.\nSupported by table and json output formats")
+	cmd.Flags().Bool("fail-on-budget-breach", false, "Exit with an error if any project is over its monthly budget")
 	cmd.Flags().Bool("show-skipped", false, "Show unsupported resources, some of which might be free")
-	cmd.Flags().StringSlice("fields", []string{"monthlyQuantity", "unit", "monthlyCost"}, "Comma separated list of output fields: all,price,monthlyQuantity,unit,hourlyCost,monthlyCost.\nSupported by table and html output formats")
+	cmd.Flags().StringSlice("fields", []string{"monthlyQuantity", "unit", "monthlyCost"}, "Comma separated list of output fields: all,price,monthlyQuantity,unit,hourlyCost,monthlyCost.\nSupported by table, html, markdown and csv output formats")
 
 	_ = cmd.RegisterFlagCompletionFunc("format", func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
-		return []string{"table", "json", "html"}, cobra.ShellCompDirectiveDefault
+		return []string{"table", "json", "html", "diff", "markdown", "csv"}, cobra.ShellCompDirectiveDefault
 	})
 
 	return cmd
@@ -26,3 +26,27 @@ func TestOutputFormatTable(t *testing.T) {
 func TestOutputTerraformFieldsAll(t *testing.T) {
 	GoldenFileCommandTest(t, testutil.CalcGoldenFileTestdataDirName(), []string{"output", "--path", "./testdata/example_out.json", "--path", "./testdata/azure_firewall_out.json", "--fields", "all"}, nil)
 }
+
+func TestOutputPolicies(t *testing.T) {
+	GoldenFileCommandTest(t, testutil.CalcGoldenFileTestdataDirName(), []string{"output", "--path", "./testdata/example_out.json", "--path", "./testdata/azure_firewall_out.json", "--config-file", "./testdata/infracost-config-policies.yml"}, nil)
+}
+
+func TestOutputBudget(t *testing.T) {
+	GoldenFileCommandTest(t, testutil.CalcGoldenFileTestdataDirName(), []string{"output", "--path", "./testdata/example_budget_out.json", "--path", "./testdata/azure_firewall_out.json", "--fail-on-budget-breach"}, nil)
+}
+
+func TestOutputFormatMarkdown(t *testing.T) {
+	GoldenFileCommandTest(t, testutil.CalcGoldenFileTestdataDirName(), []string{"output", "--format", "markdown", "--path", "./testdata/example_out.json", "--path", "./testdata/azure_firewall_out.json"}, nil)
+}
+
+func TestOutputFormatCSV(t *testing.T) {
+	GoldenFileCommandTest(t, testutil.CalcGoldenFileTestdataDirName(), []string{"output", "--format", "csv", "--path", "./testdata/example_out.json", "--path", "./testdata/azure_firewall_out.json", "--fields", "all"}, nil)
+}
+
+func TestOutputGroupByResourceType(t *testing.T) {
+	GoldenFileCommandTest(t, testutil.CalcGoldenFileTestdataDirName(), []string{"output", "--path", "./testdata/example_out.json", "--path", "./testdata/azure_firewall_out.json", "--group-by", "resource_type"}, nil)
+}
+
+func TestOutputTagPolicies(t *testing.T) {
+	GoldenFileCommandTest(t, testutil.CalcGoldenFileTestdataDirName(), []string{"output", "--path", "./testdata/example_tags_out.json", "--path", "./testdata/azure_firewall_out.json", "--config-file", "./testdata/infracost-config-tag-policies.yml"}, nil)
+}
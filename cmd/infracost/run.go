@@ -5,6 +5,8 @@ import (
 	"io"
 	"os"
 	"strings"
+	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/Rhymond/go-money"
@@ -18,7 +20,9 @@ import (
 	"github.com/infracost/infracost/internal/schema"
 	"github.com/infracost/infracost/internal/ui"
 	"github.com/infracost/infracost/internal/usage"
+	"github.com/infracost/infracost/internal/usage/billing"
 	"github.com/pkg/errors"
+	"github.com/shopspring/decimal"
 
 	log "github.com/sirupsen/logrus"
 	"github.com/spf13/cobra"
@@ -27,24 +31,43 @@ import (
 func addRunFlags(cmd *cobra.Command) {
 	cmd.Flags().StringP("path", "p", "", "Path to the Terraform directory or JSON/plan file")
 
-	cmd.Flags().String("config-file", "", "Path to Infracost config file. Cannot be used with path, terraform* or usage-file flags")
+	cmd.Flags().String("config-file", "", "Path to Infracost config file. Cannot be used with path, terraform*, cloudformation* or usage-file flags")
 	cmd.Flags().String("usage-file", "", "Path to Infracost usage file that specifies values for usage-based resources")
+	cmd.Flags().String("usage-profile", "", "Name of the profile in usage-file to use, or 'all' to compare the costs of all the profiles")
 
 	cmd.Flags().String("terraform-plan-flags", "", "Flags to pass to 'terraform plan'. Applicable when path is a Terraform directory")
 	cmd.Flags().String("terraform-workspace", "", "Terraform workspace to use. Applicable when path is a Terraform directory")
+	cmd.Flags().Bool("terraform-parse-hcl", false, "Parse the Terraform HCL code instead of running terraform plan, no credentials needed (experimental).\nApplicable when path is a Terraform directory")
+
+	cmd.Flags().String("cloudformation-parameters-file", "", "Path to a JSON file with the template parameter values. Applicable when path is a CloudFormation template")
+	cmd.Flags().String("cloudformation-region", "", "AWS region that the stack is deployed to, defaults to AWS_REGION or us-east-1. Applicable when path is a CloudFormation template")
+	cmd.Flags().String("cloudformation-compare-to", "", "Path to the deployed template or a change set JSON file from 'aws cloudformation describe-change-set'\nto compare the template to. Applicable when path is a CloudFormation template")
+
+	cmd.Flags().Int("parallelism", 0, "Number of projects to load at the same time. Defaults to the number of CPUs, up to 16")
 
 	cmd.Flags().Bool("no-cache", false, "Don't attempt to cache Terraform plans")
+	cmd.Flags().Bool("no-price-cache", false, "Don't use or update the local cache of Cloud Pricing API results")
 
 	cmd.Flags().Bool("show-skipped", false, "Show unsupported resources, some of which might be free")
 
 	cmd.Flags().Bool("sync-usage-file", false, "Sync usage-file with missing resources, needs usage-file too (experimental)")
+	cmd.Flags().String("billing-export", "", "Path to a billing export CSV file (AWS CUR, GCP billing export or Azure cost details) to sync\nusage-file from the actual usage of Terraform and Pulumi resources. Applicable with sync-usage-file")
+
+	cmd.Flags().String("pricing-snapshot", "", "Path to a local pricing snapshot file to use instead of the Cloud Pricing API")
+
+	cmd.Flags().Bool("fail-on-budget-breach", false, "Exit with an error if any project is over the monthly budget set in the config file")
 
 	_ = cmd.MarkFlagFilename("path", "json", "tf")
 	_ = cmd.MarkFlagFilename("config-file", "yml")
 	_ = cmd.MarkFlagFilename("usage-file", "yml")
+	_ = cmd.MarkFlagFilename("pricing-snapshot", "json")
+	_ = cmd.MarkFlagFilename("cloudformation-parameters-file", "json")
+	_ = cmd.MarkFlagFilename("cloudformation-compare-to", "json", "yml", "yaml")
+	_ = cmd.MarkFlagFilename("billing-export", "csv", "gz")
 }
 
-func generateUsageFile(cmd *cobra.Command, runCtx *config.RunContext, projectCfg *config.Project, provider schema.Provider) error {
+func generateUsageFile(cmd *cobra.Command, ctx *config.ProjectContext, provider schema.Provider) error {
+	projectCfg := ctx.ProjectConfig
 	if projectCfg.UsageFile == "" {
 		// This should not happen as we check earlier in the code that usage-file is not empty when sync-usage-file flag is on.
 		return fmt.Errorf("Error generating usage: no usage file given")
@@ -70,19 +93,28 @@ func generateUsageFile(cmd *cobra.Command, runCtx *config.RunContext, projectCfg
 	}
 
 	spinnerOpts := ui.SpinnerOptions{
-		EnableLogging: runCtx.Config.IsLogging(),
-		NoColor:       runCtx.Config.NoColor,
+		EnableLogging: ctx.RunContext.Config.IsLogging(),
+		NoColor:       ctx.RunContext.Config.NoColor,
 		Indent:        "  ",
+		Prefix:        ctx.LogPrefix,
+	}
+
+	syncOpts := usage.SyncUsageDataOpts{}
+	if projectCfg.BillingExport != "" {
+		syncOpts.BillingExport, err = billing.LoadExport(projectCfg.BillingExport)
+		if err != nil {
+			return errors.Wrap(err, "Error loading billing export")
+		}
 	}
 
-	spinner = ui.NewSpinner("Syncing usage data from cloud", spinnerOpts)
-	syncResult, err := usage.SyncUsageData(usageFile, providerProjects)
+	spinner := ui.NewSpinner("Syncing usage data from cloud", spinnerOpts)
+	syncResult, err := usage.SyncUsageData(usageFile, providerProjects, syncOpts)
 	if err != nil {
 		spinner.Fail()
 		return errors.Wrap(err, "Error synchronizing usage data")
 	}
 
-	runCtx.SetProjectContextFrom(syncResult)
+	ctx.SetContextValuesFrom(syncResult)
 	if err != nil {
 		spinner.Fail()
 		return errors.Wrap(err, "Error summarizing usage")
@@ -108,120 +140,46 @@ func generateUsageFile(cmd *cobra.Command, runCtx *config.RunContext, projectCfg
 		}
 
 		spinner.Success()
-		cmd.PrintErrln(fmt.Sprintf("    %s Synced %d of %d resource%s",
+		cmd.PrintErrln(fmt.Sprintf("    %s %sSynced %d of %d resource%s",
 			ui.FaintString("└─"),
+			ctx.LogPrefix,
 			successes,
 			resources,
 			pluralized))
+
+		if syncOpts.BillingExport != nil {
+			cmd.PrintErrln(fmt.Sprintf("    %s %sMatched %d of %d resource%s to the billing export",
+				ui.FaintString("└─"),
+				ctx.LogPrefix,
+				syncResult.BillingExportCount,
+				resources,
+				pluralized))
+		}
 	}
 	return nil
 }
 
 func runMain(cmd *cobra.Command, runCtx *config.RunContext) error {
-	projects := make([]*schema.Project, 0)
-	projectContexts := make([]*config.ProjectContext, 0)
-
-	for _, projectCfg := range runCtx.Config.Projects {
-		ctx := config.NewProjectContext(runCtx, projectCfg)
-		runCtx.SetCurrentProjectContext(ctx)
-
-		provider, err := providers.Detect(ctx)
-		if err != nil {
-			m := fmt.Sprintf("%s\n\n", err)
-			m += fmt.Sprintf("Use the %s flag to specify the path to one of the following:\n", ui.PrimaryString("--path"))
-			m += " - Terraform plan JSON file\n - Terraform/Terragrunt directory\n - Terraform plan file"
-
-			if cmd.Name() != "diff" {
-				m += "\n - Terraform state JSON file"
-			}
-
-			return clierror.NewSanitizedError(errors.New(m), "Could not detect path type")
-		}
-		ctx.SetContextValue("projectType", provider.Type())
-		projectContexts = append(projectContexts, ctx)
-
-		if cmd.Name() == "diff" && provider.Type() == "terraform_state_json" {
-			m := "Cannot use Terraform state JSON with the infracost diff command.\n\n"
-			m += fmt.Sprintf("Use the %s flag to specify the path to one of the following:\n", ui.PrimaryString("--path"))
-			m += " - Terraform plan JSON file\n - Terraform/Terragrunt directory\n - Terraform plan file"
-			return clierror.NewSanitizedError(errors.New(m), "Cannot use Terraform state JSON with the infracost diff command")
-		}
-
-		m := fmt.Sprintf("Detected %s at %s", provider.DisplayType(), ui.DisplayPath(projectCfg.Path))
-		if runCtx.Config.IsLogging() {
-			log.Info(m)
-		} else {
-			fmt.Fprintln(os.Stderr, m)
-		}
-
-		// Generate usage file
-		if runCtx.Config.SyncUsageFile {
-			err := generateUsageFile(cmd, runCtx, projectCfg, provider)
-			if err != nil {
-				return errors.Wrap(err, "Error generating usage file")
-			}
-		}
-
-		// Load usage data
-		usageData := make(map[string]*schema.UsageData)
-		var usageFile *usage.UsageFile
-
-		if projectCfg.UsageFile != "" {
-			var err error
-			usageFile, err = usage.LoadUsageFile(projectCfg.UsageFile)
-			if err != nil {
-				return err
-			}
-
-			invalidKeys, err := usageFile.InvalidKeys()
-			if err != nil {
-				log.Errorf("Error checking usage file keys: %v", err)
-			} else if len(invalidKeys) > 0 {
-				ui.PrintWarningf(cmd.ErrOrStderr(),
-					"The following usage file parameters are invalid and will be ignored: %s\n",
-					strings.Join(invalidKeys, ", "),
-				)
-			}
-		} else {
-			usageFile = usage.NewBlankUsageFile()
-		}
-
-		if len(usageData) > 0 {
-			ctx.SetContextValue("hasUsageFile", true)
-		}
-
-		// Merge wildcard usages into individual usage
-		wildCardUsage := make(map[string]*usage.ResourceUsage)
-		for _, us := range usageFile.ResourceUsages {
-			if strings.HasSuffix(us.Name, "[*]") {
-				lastIndexOfOpenBracket := strings.LastIndex(us.Name, "[")
-				prefixName := us.Name[:lastIndexOfOpenBracket]
-				wildCardUsage[prefixName] = us
-			}
-		}
-
-		for _, us := range usageFile.ResourceUsages {
-			if strings.HasSuffix(us.Name, "[*]") {
-				continue
-			}
-
-			if !strings.HasSuffix(us.Name, "]") {
-				continue
-			}
-			lastIndexOfOpenBracket := strings.LastIndex(us.Name, "[")
-			prefixName := us.Name[:lastIndexOfOpenBracket]
-
-			us.MergeResourceUsage(wildCardUsage[prefixName])
-		}
-
-		usageData = usageFile.ToUsageDataMap()
+	// When comparing the usage profiles the projects are also loaded with the
+	// usage of each profile, and priced with the other projects
+	var profileNames []string
+	var err error
 
-		providerProjects, err := provider.LoadResources(usageData)
+	if runCtx.Config.UsageProfile == config.AllUsageProfiles {
+		profileNames, err = usageProfileNames(runCtx.Config.Projects)
 		if err != nil {
 			return err
 		}
+	}
+
+	projects, profileProjects, projectContexts, err := loadProjects(cmd, runCtx, profileNames)
+	if err != nil {
+		return err
+	}
 
-		projects = append(projects, providerProjects...)
+	allProjects := projects
+	for _, name := range profileNames {
+		allProjects = append(allProjects, profileProjects[name]...)
 	}
 
 	if !runCtx.Config.IsLogging() {
@@ -234,30 +192,41 @@ func runMain(cmd *cobra.Command, runCtx *config.RunContext) error {
 	}
 	spinner = ui.NewSpinner("Calculating monthly cost estimate", spinnerOpts)
 
-	for _, project := range projects {
-		if err := prices.PopulatePrices(runCtx.Config, project); err != nil {
-			spinner.Fail()
-			fmt.Fprintln(os.Stderr, "")
-
-			if e := unwrapped(err); errors.Is(e, apiclient.ErrInvalidAPIKey) {
-				return fmt.Errorf("%v\n%s %s %s %s %s\n%s",
-					e.Error(),
-					"Please check your",
-					ui.PrimaryString(config.CredentialsFilePath()),
-					"file or",
-					ui.PrimaryString("INFRACOST_API_KEY"),
-					"environment variable.",
-					"If you continue having issues please email hello@infracost.io",
-				)
-			}
+	if err := prices.PopulatePrices(runCtx, allProjects); err != nil {
+		spinner.Fail()
+		fmt.Fprintln(os.Stderr, "")
 
-			if e, ok := err.(*apiclient.APIError); ok {
-				return fmt.Errorf("%v\n%s", e.Error(), "We have been notified of this issue.")
-			}
+		if e := unwrapped(err); errors.Is(e, apiclient.ErrInvalidAPIKey) {
+			return fmt.Errorf("%v\n%s %s %s %s %s\n%s",
+				e.Error(),
+				"Please check your",
+				ui.PrimaryString(config.CredentialsFilePath()),
+				"file or",
+				ui.PrimaryString("INFRACOST_API_KEY"),
+				"environment variable.",
+				"If you continue having issues please email hello@infracost.io",
+			)
+		}
 
-			return err
+		var unavailableErr *apiclient.APIUnavailableError
+		if errors.As(err, &unavailableErr) {
+			return fmt.Errorf("%s\n%v\n%s %s %s",
+				"Pricing API unavailable, please try again later.",
+				unavailableErr.Error(),
+				"Set",
+				ui.PrimaryString("INFRACOST_API_MAX_RETRIES"),
+				"to retry more times.",
+			)
 		}
 
+		if e, ok := err.(*apiclient.APIError); ok {
+			return fmt.Errorf("%v\n%s", e.Error(), "We have been notified of this issue.")
+		}
+
+		return err
+	}
+
+	for _, project := range allProjects {
 		schema.CalculateCosts(project)
 		project.CalculateDiff()
 	}
@@ -267,7 +236,18 @@ func runMain(cmd *cobra.Command, runCtx *config.RunContext) error {
 	r := output.ToOutputFormat(projects)
 	r.Currency = runCtx.Config.Currency
 
-	var err error
+	if runCtx.Config.GroupBy != "" {
+		r.GroupBy = runCtx.Config.GroupBy
+		r.Groups = output.GroupCosts(r, r.GroupBy)
+	}
+
+	for _, name := range profileNames {
+		r.UsageProfiles = append(r.UsageProfiles, output.UsageProfileCosts(name, output.ToOutputFormat(profileProjects[name])))
+	}
+
+	if len(runCtx.Config.TagPolicies) > 0 {
+		r.TagPolicyViolations = output.CheckTagPolicies(r, runCtx.Config.TagPolicies)
+	}
 
 	dashboardClient := apiclient.NewDashboardAPIClient(runCtx)
 	r.RunID, err = dashboardClient.AddRun(runCtx, projectContexts, r)
@@ -297,6 +277,12 @@ func runMain(cmd *cobra.Command, runCtx *config.RunContext) error {
 	case "diff":
 		b, err = output.ToDiff(r, opts)
 		out = fmt.Sprintf("\n%s", string(b))
+	case "markdown":
+		b, err = output.ToMarkdown(r, opts)
+		out = string(b)
+	case "csv":
+		b, err = output.ToCSV(r, opts)
+		out = string(b)
 	default:
 		b, err = output.ToTable(r, opts)
 		out = fmt.Sprintf("\n%s", string(b))
@@ -311,7 +297,14 @@ func runMain(cmd *cobra.Command, runCtx *config.RunContext) error {
 		runCtx.SetContextValue("lineCount", lines)
 	}
 
+	violations := output.CheckPolicies(r, runCtx.Config.Policies)
+
 	env := buildRunEnv(runCtx, projectContexts, r)
+	env["policyCount"] = len(runCtx.Config.Policies)
+	env["policyViolationCount"] = len(violations)
+	env["overBudgetProjectCount"] = len(output.OverBudgetProjects(r))
+	env["tagPolicyCount"] = len(runCtx.Config.TagPolicies)
+	env["tagPolicyViolationCount"] = len(r.TagPolicyViolations)
 
 	pricingClient := apiclient.NewPricingAPIClient(runCtx.Config)
 	err = pricingClient.AddEvent("infracost-run", env)
@@ -321,7 +314,347 @@ func runMain(cmd *cobra.Command, runCtx *config.RunContext) error {
 
 	cmd.Printf("%s\n", out)
 
-	return nil
+	if err := policyError(violations); err != nil {
+		return err
+	}
+
+	if runCtx.Config.FailOnBudgetBreach {
+		if err := budgetError(r); err != nil {
+			return err
+		}
+	}
+
+	return tagPolicyError(r)
+}
+
+// projectResult is the result of loading the resources of a project in the config.
+type projectResult struct {
+	ctx             *config.ProjectContext
+	projects        []*schema.Project
+	profileProjects map[string][]*schema.Project
+	err             error
+}
+
+// loadProjects loads the resources of the projects in the config with a pool of
+// workers, so that slow projects like Terraform directories that need a plan can
+// be loaded at the same time. Projects that share a path are loaded one after the
+// other by the same worker, since terraform init and plan can't run concurrently
+// in one directory. The projects are returned in the same order as the config
+// regardless of which finish first, so the output is the same on every run.
+// The projects loaded with the usage of each of the profile names are returned
+// by profile name.
+func loadProjects(cmd *cobra.Command, runCtx *config.RunContext, profileNames []string) ([]*schema.Project, map[string][]*schema.Project, []*config.ProjectContext, error) {
+	projectCfgs := runCtx.Config.Projects
+	pathGroups := config.GroupProjectsByPath(projectCfgs)
+	parallelism := config.ParallelismFor(runCtx.Config.Parallelism, len(pathGroups))
+	runCtx.SetContextValue("parallelism", parallelism)
+
+	results := make([]*projectResult, len(projectCfgs))
+	jobs := make(chan []int)
+
+	// Once a project fails the projects that haven't started yet are skipped,
+	// the same as when the projects are loaded one at a time
+	var failed int32
+
+	var wg sync.WaitGroup
+	for w := 0; w < parallelism; w++ {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			for group := range jobs {
+				for _, i := range group {
+					if atomic.LoadInt32(&failed) == 1 {
+						break
+					}
+
+					ctx := config.NewProjectContext(runCtx, projectCfgs[i])
+					if parallelism > 1 {
+						ctx.LogPrefix = fmt.Sprintf("[%s] ", ui.DisplayPath(projectCfgs[i].Path))
+					}
+
+					projects, profileProjects, err := loadProject(cmd, ctx, profileNames)
+					if err != nil {
+						atomic.StoreInt32(&failed, 1)
+					}
+
+					results[i] = &projectResult{ctx: ctx, projects: projects, profileProjects: profileProjects, err: err}
+				}
+			}
+		}()
+	}
+
+	for _, group := range pathGroups {
+		jobs <- group
+	}
+	close(jobs)
+	wg.Wait()
+
+	projects := make([]*schema.Project, 0)
+	profileProjects := make(map[string][]*schema.Project, len(profileNames))
+	projectContexts := make([]*config.ProjectContext, 0, len(results))
+
+	for _, r := range results {
+		if r == nil {
+			continue
+		}
+
+		// Errors are reported with the context of the project that failed
+		runCtx.SetCurrentProjectContext(r.ctx)
+
+		if r.err != nil {
+			return projects, profileProjects, projectContexts, r.err
+		}
+
+		projectContexts = append(projectContexts, r.ctx)
+		projects = append(projects, r.projects...)
+
+		for _, name := range profileNames {
+			profileProjects[name] = append(profileProjects[name], r.profileProjects[name]...)
+		}
+	}
+
+	return projects, profileProjects, projectContexts, nil
+}
+
+// loadProject detects the type of the project and loads its resources with the usage data
+// from its usage file, overridden by the usage profile if one is set. The resources are
+// also loaded with the usage of each of the profile names, returned by profile name.
+// These are loaded by the same provider so the project is only planned once.
+func loadProject(cmd *cobra.Command, ctx *config.ProjectContext, profileNames []string) ([]*schema.Project, map[string][]*schema.Project, error) {
+	runCtx := ctx.RunContext
+	projectCfg := ctx.ProjectConfig
+
+	provider, err := providers.Detect(ctx)
+	if err != nil {
+		m := fmt.Sprintf("%s\n\n", err)
+		m += fmt.Sprintf("Use the %s flag to specify the path to one of the following:\n", ui.PrimaryString("--path"))
+		m += " - Terraform plan JSON file\n - Terraform/Terragrunt directory\n - Terraform plan file"
+
+		if cmd.Name() != "diff" {
+			m += "\n - Terraform state JSON file"
+		}
+
+		m += "\n - Pulumi preview JSON file"
+
+		if cmd.Name() != "diff" {
+			m += "\n - Pulumi state JSON file"
+		}
+
+		m += "\n - CloudFormation template file"
+		m += "\n - AWS CDK cloud assembly directory, e.g. cdk.out"
+		m += "\n - Kubernetes manifest file or directory"
+		m += "\n - Terraform Cloud workspace, e.g. tfc://org/workspace"
+
+		return nil, nil, clierror.NewSanitizedError(errors.New(m), "Could not detect path type")
+	}
+	ctx.SetContextValue("projectType", provider.Type())
+
+	if cmd.Name() == "diff" && provider.Type() == "terraform_state_json" {
+		m := "Cannot use Terraform state JSON with the infracost diff command.\n\n"
+		m += fmt.Sprintf("Use the %s flag to specify the path to one of the following:\n", ui.PrimaryString("--path"))
+		m += " - Terraform plan JSON file\n - Terraform/Terragrunt directory\n - Terraform plan file"
+		return nil, nil, clierror.NewSanitizedError(errors.New(m), "Cannot use Terraform state JSON with the infracost diff command")
+	}
+
+	if cmd.Name() == "diff" && provider.Type() == "pulumi_state_json" {
+		m := "Cannot use Pulumi state JSON with the infracost diff command.\n\n"
+		m += fmt.Sprintf("Use the %s flag to specify the path to a Pulumi preview JSON file, created with:\n", ui.PrimaryString("--path"))
+		m += "  pulumi preview --json > preview.json"
+		return nil, nil, clierror.NewSanitizedError(errors.New(m), "Cannot use Pulumi state JSON with the infracost diff command")
+	}
+
+	m := fmt.Sprintf("%sDetected %s at %s", ctx.LogPrefix, provider.DisplayType(), ui.DisplayPath(projectCfg.Path))
+	if runCtx.Config.IsLogging() {
+		log.Info(m)
+	} else {
+		fmt.Fprintln(os.Stderr, m)
+	}
+
+	// Generate usage file
+	if runCtx.Config.SyncUsageFile {
+		err := generateUsageFile(cmd, ctx, provider)
+		if err != nil {
+			return nil, nil, errors.Wrap(err, "Error generating usage file")
+		}
+	}
+
+	usageProfile := runCtx.Config.UsageProfile
+	if usageProfile == config.AllUsageProfiles {
+		usageProfile = ""
+	}
+
+	usageData, err := loadUsageData(cmd, ctx, usageProfile, false)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if len(usageData) > 0 {
+		ctx.SetContextValue("hasUsageFile", true)
+	}
+
+	providerProjects, err := provider.LoadResources(usageData)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	profileProjects := make(map[string][]*schema.Project, len(profileNames))
+	for _, name := range profileNames {
+		usageData, err := loadUsageData(cmd, ctx, name, true)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		profileProjects[name], err = provider.LoadResources(usageData)
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+
+	budget := projectBudget(projectCfg)
+	for _, p := range providerProjects {
+		p.Budget = budget
+	}
+
+	return providerProjects, profileProjects, nil
+}
+
+// loadUsageData loads the usage data from the usage file of the project, overridden by
+// the usage profile if one is given. Projects that don't have the profile use their usage
+// file as it is when comparing the profiles, since the profiles can differ between usage
+// files, and the invalid keys are only reported once.
+func loadUsageData(cmd *cobra.Command, ctx *config.ProjectContext, usageProfile string, isProfileComparison bool) (map[string]*schema.UsageData, error) {
+	projectCfg := ctx.ProjectConfig
+
+	if projectCfg.UsageFile == "" {
+		return usage.NewBlankUsageFile().ToUsageDataMap(), nil
+	}
+
+	usageFile, err := usage.LoadUsageFile(projectCfg.UsageFile)
+	if err != nil {
+		return nil, err
+	}
+
+	invalidKeys, err := usageFile.InvalidKeys()
+	if err != nil {
+		log.Errorf("%sError checking usage file keys: %v", ctx.LogPrefix, err)
+	} else if len(invalidKeys) > 0 && !isProfileComparison {
+		ui.PrintWarningf(cmd.ErrOrStderr(),
+			"%sThe following usage file parameters are invalid and will be ignored: %s\n",
+			ctx.LogPrefix,
+			strings.Join(invalidKeys, ", "),
+		)
+	}
+
+	if usageProfile != "" && (!isProfileComparison || contains(usageFile.ProfileNames(), usageProfile)) {
+		err := usageFile.ApplyProfile(usageProfile)
+		if err != nil {
+			return nil, errors.Wrapf(err, "Error loading usage file %s", projectCfg.UsageFile)
+		}
+	}
+
+	// Merge wildcard usages into individual usage
+	wildCardUsage := make(map[string]*usage.ResourceUsage)
+	for _, us := range usageFile.ResourceUsages {
+		if strings.HasSuffix(us.Name, "[*]") {
+			lastIndexOfOpenBracket := strings.LastIndex(us.Name, "[")
+			prefixName := us.Name[:lastIndexOfOpenBracket]
+			wildCardUsage[prefixName] = us
+		}
+	}
+
+	for _, us := range usageFile.ResourceUsages {
+		if strings.HasSuffix(us.Name, "[*]") {
+			continue
+		}
+
+		if !strings.HasSuffix(us.Name, "]") {
+			continue
+		}
+		lastIndexOfOpenBracket := strings.LastIndex(us.Name, "[")
+		prefixName := us.Name[:lastIndexOfOpenBracket]
+
+		us.MergeResourceUsage(wildCardUsage[prefixName])
+	}
+
+	return usageFile.ToUsageDataMap(), nil
+}
+
+// usageProfileNames returns the names of the profiles in the usage files of the
+// projects, in the order that they're first defined.
+func usageProfileNames(projectCfgs []*config.Project) ([]string, error) {
+	names := make([]string, 0)
+
+	for _, projectCfg := range projectCfgs {
+		if projectCfg.UsageFile == "" {
+			continue
+		}
+
+		usageFile, err := usage.LoadUsageFile(projectCfg.UsageFile)
+		if err != nil {
+			return names, err
+		}
+
+		for _, name := range usageFile.ProfileNames() {
+			if !contains(names, name) {
+				names = append(names, name)
+			}
+		}
+	}
+
+	if len(names) == 0 {
+		return names, errors.New("No usage profiles found in the usage files, add them under the profiles key, see https://infracost.io/usage-file")
+	}
+
+	return names, nil
+}
+
+// projectBudget returns the monthly budget set for the project in the config file, if any.
+func projectBudget(projectCfg *config.Project) *schema.Budget {
+	if projectCfg.MonthlyBudget == nil {
+		return nil
+	}
+
+	budget := &schema.Budget{
+		MonthlyBudget: decimal.NewFromFloat(*projectCfg.MonthlyBudget),
+	}
+
+	if projectCfg.BudgetAlertThreshold != nil {
+		threshold := decimal.NewFromFloat(*projectCfg.BudgetAlertThreshold)
+		budget.AlertThreshold = &threshold
+	}
+
+	return budget
+}
+
+// policyError returns an error containing the violation report if any cost policies failed.
+func policyError(violations []output.PolicyViolation) error {
+	if len(violations) == 0 {
+		return nil
+	}
+
+	return clierror.NewSanitizedError(&output.PolicyError{Violations: violations}, "Cost policy check failed")
+}
+
+// budgetError returns an error listing the projects that are over their monthly budget, if any.
+func budgetError(r output.Root) error {
+	overBudget := output.OverBudgetProjects(r)
+	if len(overBudget) == 0 {
+		return nil
+	}
+
+	return clierror.NewSanitizedError(&output.BudgetError{Currency: r.Currency, Projects: overBudget}, "Budget check failed")
+}
+
+// tagPolicyError returns an error listing the resources that violate enforced tag policies, if any.
+func tagPolicyError(r output.Root) error {
+	enforced := output.EnforcedTagPolicyViolations(r.TagPolicyViolations)
+	if len(enforced) == 0 {
+		return nil
+	}
+
+	return clierror.NewSanitizedError(&output.TagPolicyError{Currency: r.Currency, Violations: enforced}, "Tag policy check failed")
 }
 
 func loadRunFlags(cfg *config.Config, cmd *cobra.Command) error {
@@ -331,6 +664,9 @@ func loadRunFlags(cfg *config.Config, cmd *cobra.Command) error {
 	if cmd.Name() != "infracost" && !hasPathFlag && !hasConfigFile {
 		m := fmt.Sprintf("No path specified\n\nUse the %s flag to specify the path to one of the following:\n", ui.PrimaryString("--path"))
 		m += " - Terraform plan JSON file\n - Terraform/Terragrunt directory\n - Terraform plan file\n - Terraform state JSON file"
+		m += "\n - Pulumi preview JSON file\n - Pulumi state JSON file"
+		m += "\n - CloudFormation template file\n - AWS CDK cloud assembly directory, e.g. cdk.out"
+		m += "\n - Kubernetes manifest file or directory\n - Terraform Cloud workspace, e.g. tfc://org/workspace"
 		m += "\n\nAlternatively, use --config-file to process multiple projects, see https://infracost.io/config-file"
 
 		ui.PrintUsage(cmd)
@@ -339,13 +675,18 @@ func loadRunFlags(cfg *config.Config, cmd *cobra.Command) error {
 
 	hasProjectFlags := (hasPathFlag ||
 		cmd.Flags().Changed("usage-file") ||
+		cmd.Flags().Changed("billing-export") ||
 		cmd.Flags().Changed("terraform-plan-flags") ||
 		cmd.Flags().Changed("terraform-workspace") ||
-		cmd.Flags().Changed("terraform-use-state"))
+		cmd.Flags().Changed("terraform-use-state") ||
+		cmd.Flags().Changed("terraform-parse-hcl") ||
+		cmd.Flags().Changed("cloudformation-parameters-file") ||
+		cmd.Flags().Changed("cloudformation-region") ||
+		cmd.Flags().Changed("cloudformation-compare-to"))
 
 	if hasConfigFile && hasProjectFlags {
 		m := "--config-file flag cannot be used with the following flags: "
-		m += "--path, --terraform-*, --usage-file"
+		m += "--path, --terraform-*, --cloudformation-*, --usage-file, --billing-export"
 		ui.PrintUsage(cmd)
 		return errors.New(m)
 	}
@@ -355,8 +696,13 @@ func loadRunFlags(cfg *config.Config, cmd *cobra.Command) error {
 	if hasProjectFlags {
 		projectCfg.Path, _ = cmd.Flags().GetString("path")
 		projectCfg.UsageFile, _ = cmd.Flags().GetString("usage-file")
+		projectCfg.BillingExport, _ = cmd.Flags().GetString("billing-export")
 		projectCfg.TerraformPlanFlags, _ = cmd.Flags().GetString("terraform-plan-flags")
 		projectCfg.TerraformUseState, _ = cmd.Flags().GetBool("terraform-use-state")
+		projectCfg.TerraformParseHCL, _ = cmd.Flags().GetBool("terraform-parse-hcl")
+		projectCfg.CloudFormationParametersFile, _ = cmd.Flags().GetString("cloudformation-parameters-file")
+		projectCfg.CloudFormationRegion, _ = cmd.Flags().GetString("cloudformation-region")
+		projectCfg.CloudFormationCompareTo, _ = cmd.Flags().GetString("cloudformation-compare-to")
 
 		if cmd.Flags().Changed("terraform-workspace") {
 			projectCfg.TerraformWorkspace, _ = cmd.Flags().GetString("terraform-workspace")
@@ -372,22 +718,56 @@ func loadRunFlags(cfg *config.Config, cmd *cobra.Command) error {
 		}
 	}
 
+	if cmd.Flags().Changed("parallelism") {
+		cfg.Parallelism, _ = cmd.Flags().GetInt("parallelism")
+
+		if cfg.Parallelism < 1 {
+			ui.PrintUsage(cmd)
+			return errors.New("--parallelism must be greater than 0")
+		}
+	}
+
 	cfg.NoCache, _ = cmd.Flags().GetBool("no-cache")
 
+	if cmd.Flags().Changed("no-price-cache") {
+		cfg.NoPriceCache, _ = cmd.Flags().GetBool("no-price-cache")
+	}
+
 	cfg.Format, _ = cmd.Flags().GetString("format")
 	cfg.ShowSkipped, _ = cmd.Flags().GetBool("show-skipped")
 	cfg.SyncUsageFile, _ = cmd.Flags().GetBool("sync-usage-file")
 
+	if cmd.Flags().Changed("billing-export") && !cfg.SyncUsageFile {
+		ui.PrintUsage(cmd)
+		return errors.New("--billing-export flag can only be used with --sync-usage-file")
+	}
+
+	if cmd.Flags().Changed("pricing-snapshot") {
+		cfg.PricingSnapshot, _ = cmd.Flags().GetString("pricing-snapshot")
+	}
+
+	if cmd.Flags().Changed("fail-on-budget-breach") {
+		cfg.FailOnBudgetBreach, _ = cmd.Flags().GetBool("fail-on-budget-breach")
+	}
+
+	if cmd.Flags().Changed("group-by") {
+		cfg.GroupBy, _ = cmd.Flags().GetString("group-by")
+	}
+
+	if cmd.Flags().Changed("usage-profile") {
+		cfg.UsageProfile, _ = cmd.Flags().GetString("usage-profile")
+	}
+
 	includeAllFields := "all"
 	validFields := []string{"price", "monthlyQuantity", "unit", "hourlyCost", "monthlyCost"}
-	validFieldsFormats := []string{"table", "html"}
+	validFieldsFormats := []string{"table", "html", "markdown", "csv"}
 
 	if cmd.Flags().Changed("fields") {
 		fields, _ := cmd.Flags().GetStringSlice("fields")
 		if len(fields) == 0 {
 			ui.PrintWarningf(cmd.ErrOrStderr(), "fields is empty, using defaults: %s", cmd.Flag("fields").DefValue)
 		} else if cfg.Fields != nil && !contains(validFieldsFormats, cfg.Format) {
-			ui.PrintWarning(cmd.ErrOrStderr(), "fields is only supported for table and html output formats")
+			ui.PrintWarning(cmd.ErrOrStderr(), "fields is only supported for table, html, markdown and csv output formats")
 		} else if len(fields) == 1 && fields[0] == includeAllFields {
 			cfg.Fields = validFields
 		} else {
@@ -427,6 +807,26 @@ func checkRunConfig(warningWriter io.Writer, cfg *config.Config) error {
 		}
 	}
 
+	if !cfg.SyncUsageFile {
+		for _, project := range cfg.Projects {
+			if project.BillingExport != "" {
+				return fmt.Errorf("billing_export is set for %s but can only be used with --sync-usage-file", project.Path)
+			}
+		}
+	}
+
+	if cfg.PricingSnapshot != "" && !config.FileExists(cfg.PricingSnapshot) {
+		return fmt.Errorf("Pricing snapshot file does not exist at %s", cfg.PricingSnapshot)
+	}
+
+	if err := checkGroupBy(cfg.GroupBy); err != nil {
+		return err
+	}
+
+	if err := checkUsageProfile(warningWriter, cfg); err != nil {
+		return err
+	}
+
 	if money.GetCurrency(cfg.Currency) == nil {
 		ui.PrintWarning(warningWriter, fmt.Sprintf("Ignoring unknown currency '%s', using USD.\n", cfg.Currency))
 		cfg.Currency = "USD"
@@ -435,11 +835,45 @@ func checkRunConfig(warningWriter io.Writer, cfg *config.Config) error {
 	return nil
 }
 
+func checkGroupBy(groupBy string) error {
+	if groupBy != "" && !output.ValidGroupBy(groupBy) {
+		return fmt.Errorf("Invalid group-by '%s', valid options are tag:<key>, resource_type, provider or module", groupBy)
+	}
+
+	return nil
+}
+
+func checkUsageProfile(warningWriter io.Writer, cfg *config.Config) error {
+	if cfg.UsageProfile == "" {
+		return nil
+	}
+
+	hasUsageFile := false
+	for _, project := range cfg.Projects {
+		if project.UsageFile != "" {
+			hasUsageFile = true
+			break
+		}
+	}
+
+	if !hasUsageFile {
+		return errors.New("--usage-profile needs a usage-file with profiles")
+	}
+
+	if cfg.UsageProfile == config.AllUsageProfiles && !contains([]string{"table", "diff", "json"}, cfg.Format) {
+		ui.PrintWarning(warningWriter, "Comparing all usage profiles is only supported by table, diff and json output formats.\n")
+	}
+
+	return nil
+}
+
 func buildRunEnv(runCtx *config.RunContext, projectContexts []*config.ProjectContext, r output.Root) map[string]interface{} {
 	env := runCtx.EventEnvWithProjectContexts(projectContexts)
 	env["projectCount"] = len(projectContexts)
 	env["runSeconds"] = time.Now().Unix() - runCtx.StartTime
 	env["currency"] = runCtx.Config.Currency
+	env["usingPricingSnapshot"] = runCtx.Config.PricingSnapshot != ""
+	env["usingPriceCache"] = !runCtx.Config.NoPriceCache && runCtx.Config.PriceCacheTTL > 0 && runCtx.Config.PricingSnapshot == ""
 
 	usingCache := make([]bool, 0, len(projectContexts))
 	cacheErrors := make([]string, 0, len(projectContexts))
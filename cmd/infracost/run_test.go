@@ -40,3 +40,11 @@ func TestFlagErrorsTerraformWorkspaceFlagAndEnv(t *testing.T) {
 	os.Setenv("INFRACOST_TERRAFORM_WORKSPACE", "dev")
 	GoldenFileCommandTest(t, testutil.CalcGoldenFileTestdataDirName(), []string{"breakdown", "--path", "../../examples/terraform", "--terraform-workspace", "prod"}, nil)
 }
+
+func TestFlagErrorsParallelism(t *testing.T) {
+	GoldenFileCommandTest(t, testutil.CalcGoldenFileTestdataDirName(), []string{"breakdown", "--path", "./testdata/example_plan.json", "--parallelism", "0"}, nil)
+}
+
+func TestFlagErrorsBillingExportWithoutSyncUsageFile(t *testing.T) {
+	GoldenFileCommandTest(t, testutil.CalcGoldenFileTestdataDirName(), []string{"breakdown", "--path", "./testdata/example_plan.json", "--billing-export", "./testdata/cur.csv"}, nil)
+}
@@ -0,0 +1,327 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/infracost/infracost/internal/apiclient"
+	"github.com/infracost/infracost/internal/config"
+	"github.com/infracost/infracost/internal/output"
+	"github.com/infracost/infracost/internal/prices"
+	"github.com/infracost/infracost/internal/providers"
+	"github.com/infracost/infracost/internal/schema"
+	"github.com/infracost/infracost/internal/ui"
+	"github.com/infracost/infracost/internal/usage"
+	"github.com/manifoldco/promptui"
+	"github.com/pkg/errors"
+	"github.com/shopspring/decimal"
+	"github.com/spf13/cobra"
+)
+
+const defaultUsageFile = "infracost-usage.yml"
+
+func usageCmd(ctx *config.RunContext) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "usage",
+		Short: "Create and update usage files",
+		Long:  "Create and update usage files",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			// Show the help
+			return cmd.Help()
+		},
+	}
+
+	cmd.AddCommand(usageInitCmd(ctx))
+
+	return cmd
+}
+
+func usageInitCmd(ctx *config.RunContext) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "init",
+		Short: "Interactively set the usage of resources in a usage file",
+		Long: `Interactively set the usage of resources in a usage file.
+
+Prompts for the usage values of each usage-based resource, showing the monthly cost
+of the resource as the values are set. Values that are already in the usage file are
+used as the defaults, and pressing enter keeps them.`,
+		Example: `  Create a usage file for a Terraform directory:
+
+      infracost usage init --path /path/to/code --usage-file infracost-usage.yml`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if !cmd.Flags().Changed("path") {
+				ui.PrintUsage(cmd)
+				return fmt.Errorf("No path specified\n\nUse the %s flag to specify the path to the Terraform directory or JSON/plan file", ui.PrimaryString("--path"))
+			}
+
+			if err := checkAPIKey(ctx.Config.APIKey, ctx.Config.PricingAPIEndpoint, ctx.Config.DefaultPricingAPIEndpoint); err != nil {
+				return err
+			}
+
+			projectCfg := ctx.Config.Projects[0]
+			projectCfg.Path, _ = cmd.Flags().GetString("path")
+			projectCfg.UsageFile, _ = cmd.Flags().GetString("usage-file")
+			projectCfg.TerraformPlanFlags, _ = cmd.Flags().GetString("terraform-plan-flags")
+			projectCfg.TerraformParseHCL, _ = cmd.Flags().GetBool("terraform-parse-hcl")
+
+			if cmd.Flags().Changed("terraform-workspace") {
+				projectCfg.TerraformWorkspace, _ = cmd.Flags().GetString("terraform-workspace")
+			}
+
+			return runUsageInit(cmd, config.NewProjectContext(ctx, projectCfg))
+		},
+	}
+
+	cmd.Flags().StringP("path", "p", "", "Path to the Terraform directory or JSON/plan file")
+	cmd.Flags().String("usage-file", defaultUsageFile, "Path to the usage file to create or update")
+	cmd.Flags().String("terraform-plan-flags", "", "Flags to pass to 'terraform plan'. Applicable when path is a Terraform directory")
+	cmd.Flags().String("terraform-workspace", "", "Terraform workspace to use. Applicable when path is a Terraform directory")
+	cmd.Flags().Bool("terraform-parse-hcl", false, "Parse the Terraform HCL code instead of running terraform plan, no credentials needed (experimental).\nApplicable when path is a Terraform directory")
+
+	_ = cmd.MarkFlagFilename("path", "json", "tf")
+	_ = cmd.MarkFlagFilename("usage-file", "yml")
+
+	return cmd
+}
+
+// usageWizard prompts for the usage values of the resources of a project.
+type usageWizard struct {
+	provider      schema.Provider
+	usageFile     *usage.UsageFile
+	pricingClient *apiclient.PricingAPIClient
+}
+
+func runUsageInit(cmd *cobra.Command, ctx *config.ProjectContext) error {
+	provider, err := providers.Detect(ctx)
+	if err != nil {
+		return err
+	}
+
+	usageFile, err := usage.LoadUsageFile(ctx.ProjectConfig.UsageFile)
+	if err != nil {
+		return errors.Wrap(err, "Error loading usage file")
+	}
+
+	projects, err := provider.LoadResources(usageFile.ToUsageDataMap())
+	if err != nil {
+		return errors.Wrap(err, "Error loading resources")
+	}
+
+	// Add the missing resources and usage keys to the usage file, with their descriptions
+	_, err = usage.SyncUsageData(usageFile, projects, usage.SyncUsageDataOpts{})
+	if err != nil {
+		return errors.Wrap(err, "Error synchronizing usage data")
+	}
+
+	w := &usageWizard{
+		provider:      provider,
+		usageFile:     usageFile,
+		pricingClient: apiclient.NewPricingAPIClient(ctx.RunContext.Config),
+	}
+
+	err = w.run(cmd)
+	if errors.Is(err, promptui.ErrInterrupt) || errors.Is(err, promptui.ErrEOF) {
+		// The user cancelled, so don't write any changes
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	err = usageFile.WriteToPath(ctx.ProjectConfig.UsageFile)
+	if err != nil {
+		return errors.Wrap(err, "Error writing usage file")
+	}
+
+	cmd.Println("")
+	ui.PrintSuccessf(cmd.ErrOrStderr(), "Usage file saved to %s\nYou can now run %s",
+		ctx.ProjectConfig.UsageFile,
+		ui.PrimaryString(fmt.Sprintf("infracost breakdown --path %s --usage-file %s", ctx.ProjectConfig.Path, ctx.ProjectConfig.UsageFile)),
+	)
+
+	return nil
+}
+
+func (w *usageWizard) run(cmd *cobra.Command) error {
+	for _, resourceUsage := range w.usageFile.ResourceUsages {
+		// The cost of wildcard resources can't be shown, so they're only set in the file
+		if len(resourceUsage.Items) == 0 || strings.HasSuffix(resourceUsage.Name, "[*]") {
+			continue
+		}
+
+		cost, err := w.resourceCost(resourceUsage.Name)
+		if err != nil {
+			return err
+		}
+
+		cmd.Println("")
+		cmd.Printf("%s %s\n", ui.BoldString(resourceUsage.Name), ui.FaintString(w.formatCost(cost)))
+
+		s := promptui.Select{
+			Label: "Set the usage of this resource",
+			Items: []string{"Yes", "Skip", "Save and exit"},
+		}
+
+		i, _, err := s.Run()
+		if err != nil {
+			return err
+		}
+
+		if i == 1 {
+			continue
+		}
+		if i == 2 {
+			return nil
+		}
+
+		err = w.promptItems(cmd, resourceUsage.Name, resourceUsage.Items, "", cost)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// promptItems prompts for the values of the usage items of a resource, and shows the
+// change in the monthly cost of the resource after each value.
+func (w *usageWizard) promptItems(cmd *cobra.Command, name string, items []*schema.UsageItem, prefix string, cost *decimal.Decimal) error {
+	for _, item := range items {
+		if item.ValueType == schema.SubResourceUsage {
+			p := promptui.Prompt{
+				Label:     fmt.Sprintf("Set the usage of %s%s", prefix, item.Key),
+				IsConfirm: true,
+			}
+
+			if _, err := p.Run(); err != nil {
+				if errors.Is(err, promptui.ErrAbort) {
+					continue
+				}
+				return err
+			}
+
+			previous := item.Value
+			subResourceUsage := usage.SubResourceUsageValue(item)
+
+			err := w.promptItems(cmd, name, subResourceUsage.Items, prefix+item.Key+".", cost)
+
+			// Keep the sub-resource commented out in the usage file if none of its values are set
+			if !hasUsageValue(subResourceUsage.Items) {
+				item.Value = previous
+			}
+
+			if err != nil {
+				return err
+			}
+
+			cost, err = w.resourceCost(name)
+			if err != nil {
+				return err
+			}
+
+			continue
+		}
+
+		if item.Description != "" {
+			cmd.Println(ui.FaintString(item.Description))
+		}
+
+		p := promptui.Prompt{
+			Label:     prefix + item.Key,
+			Default:   usage.FormatUsageValue(item.Value),
+			AllowEdit: true,
+			Validate: func(input string) error {
+				if strings.TrimSpace(input) == "" {
+					return nil
+				}
+				_, err := usage.ParseUsageValue(item.ValueType, input)
+				return err
+			},
+		}
+
+		input, err := p.Run()
+		if err != nil {
+			return err
+		}
+
+		if strings.TrimSpace(input) == "" {
+			// Entering an empty value removes it from the usage file
+			item.Value = nil
+		} else {
+			item.Value, _ = usage.ParseUsageValue(item.ValueType, input)
+		}
+
+		newCost, err := w.resourceCost(name)
+		if err != nil {
+			return err
+		}
+
+		cmd.Printf("    %s Monthly cost: %s\n", ui.FaintString("└─"), w.formatCostChange(cost, newCost))
+		cost = newCost
+	}
+
+	return nil
+}
+
+// resourceCost loads the resource with the usage values that are set so far, and
+// returns its monthly cost.
+func (w *usageWizard) resourceCost(name string) (*decimal.Decimal, error) {
+	projects, err := w.provider.LoadResources(w.usageFile.ToUsageDataMap())
+	if err != nil {
+		return nil, errors.Wrap(err, "Error loading resources")
+	}
+
+	for _, project := range projects {
+		for _, r := range project.Resources {
+			if r.Name != name {
+				continue
+			}
+
+			if err := prices.GetPrices(w.pricingClient, []*schema.Resource{r}); err != nil {
+				return nil, err
+			}
+			r.CalculateCosts()
+
+			return r.MonthlyCost, nil
+		}
+	}
+
+	return nil, nil
+}
+
+func (w *usageWizard) formatCost(cost *decimal.Decimal) string {
+	if cost == nil {
+		return "-"
+	}
+
+	return fmt.Sprintf("%s/month", output.FormatCost2DP(w.pricingClient.Currency, cost))
+}
+
+func (w *usageWizard) formatCostChange(oldCost *decimal.Decimal, newCost *decimal.Decimal) string {
+	if oldCost == nil || newCost == nil {
+		return w.formatCost(newCost)
+	}
+
+	change := newCost.Sub(*oldCost)
+	if change.IsZero() {
+		return fmt.Sprintf("%s (no change)", w.formatCost(newCost))
+	}
+
+	sign := "+"
+	if change.IsNegative() {
+		sign = "-"
+	}
+	change = change.Abs()
+
+	return fmt.Sprintf("%s (%s%s)", w.formatCost(newCost), sign, output.FormatCost2DP(w.pricingClient.Currency, &change))
+}
+
+func hasUsageValue(items []*schema.UsageItem) bool {
+	for _, item := range items {
+		if item.Value != nil {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,16 @@
+package main_test
+
+import (
+	"github.com/infracost/infracost/internal/testutil"
+	"testing"
+)
+
+// The usage init command is interactive, so just test the help.
+
+func TestUsageNoArgs(t *testing.T) {
+	GoldenFileCommandTest(t, testutil.CalcGoldenFileTestdataDirName(), []string{"usage"}, nil)
+}
+
+func TestUsageInitHelpFlag(t *testing.T) {
+	GoldenFileCommandTest(t, testutil.CalcGoldenFileTestdataDirName(), []string{"usage", "init", "--help"}, nil)
+}
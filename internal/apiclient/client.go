@@ -5,7 +5,10 @@ import (
 	"encoding/json"
 	"fmt"
 	"io"
+	"math/rand"
 	"net/http"
+	"strconv"
+	"time"
 
 	"github.com/pkg/errors"
 	log "github.com/sirupsen/logrus"
@@ -14,10 +17,22 @@ import (
 	"github.com/infracost/infracost/internal/version"
 )
 
+var (
+	defaultTimeout = 60 * time.Second
+	retryWaitMin   = 1 * time.Second
+	retryWaitMax   = 30 * time.Second
+	maxRetryAfter  = 2 * time.Minute
+
+	// sleep waits between retries, and is replaced in tests so they don't wait
+	sleep = time.Sleep
+)
+
 type APIClient struct {
-	endpoint string
-	apiKey   string
-	runID    string
+	endpoint   string
+	apiKey     string
+	runID      string
+	timeout    time.Duration
+	maxRetries int
 }
 
 type GraphQLQuery struct {
@@ -40,13 +55,40 @@ type APIErrorResponse struct {
 
 var ErrInvalidAPIKey = errors.New("Invalid API key")
 
+// APIUnavailableError is returned when the API can't be reached, or keeps responding
+// with server errors or rate limits, after all the retries have been used.
+type APIUnavailableError struct {
+	err      error
+	attempts int
+}
+
+func (e *APIUnavailableError) Error() string {
+	return fmt.Sprintf("API unavailable after %d attempts: %v", e.attempts, e.err.Error())
+}
+
+func (e *APIUnavailableError) Unwrap() error {
+	return e.err
+}
+
+// retryableError is a failure that might succeed if the request is sent again,
+// e.g. a timeout or a 5xx response.
+type retryableError struct {
+	err        error
+	retryAfter time.Duration
+}
+
+func (e *retryableError) Error() string {
+	return e.err.Error()
+}
+
 func (c *APIClient) doQueries(queries []GraphQLQuery) ([]gjson.Result, error) {
 	if len(queries) == 0 {
 		log.Debug("Skipping GraphQL request as no queries have been specified")
 		return []gjson.Result{}, nil
 	}
 
-	respBody, err := c.doRequest("POST", "/graphql", queries)
+	// GraphQL queries don't modify anything so they are safe to retry
+	respBody, err := c.doRequestWithRetry("POST", "/graphql", queries)
 	return gjson.ParseBytes(respBody).Array(), err
 }
 
@@ -56,6 +98,42 @@ func (c *APIClient) doRequest(method string, path string, d interface{}) ([]byte
 		return []byte{}, errors.Wrap(err, "Error generating request body")
 	}
 
+	respBody, err := c.sendRequest(method, path, reqBody)
+	if e, ok := err.(*retryableError); ok {
+		return respBody, e.err
+	}
+
+	return respBody, err
+}
+
+// doRequestWithRetry sends the request, retrying it with exponential backoff and jitter
+// if it fails with a timeout, connection error, 5xx or 429 response. This should only
+// be used for idempotent requests.
+func (c *APIClient) doRequestWithRetry(method string, path string, d interface{}) ([]byte, error) {
+	reqBody, err := json.Marshal(d)
+	if err != nil {
+		return []byte{}, errors.Wrap(err, "Error generating request body")
+	}
+
+	for attempt := 0; ; attempt++ {
+		respBody, err := c.sendRequest(method, path, reqBody)
+
+		e, ok := err.(*retryableError)
+		if !ok {
+			return respBody, err
+		}
+
+		if attempt >= c.maxRetries {
+			return []byte{}, &APIUnavailableError{e.err, attempt + 1}
+		}
+
+		wait := retryWait(attempt, e.retryAfter)
+		log.Debugf("API request to %s%s failed, retrying in %s: %v", c.endpoint, path, wait, e.err)
+		sleep(wait)
+	}
+}
+
+func (c *APIClient) sendRequest(method string, path string, reqBody []byte) ([]byte, error) {
 	req, err := http.NewRequest(method, c.endpoint+path, bytes.NewBuffer(reqBody))
 	if err != nil {
 		return []byte{}, errors.Wrap(err, "Error generating request")
@@ -63,16 +141,21 @@ func (c *APIClient) doRequest(method string, path string, d interface{}) ([]byte
 
 	c.AddAuthHeaders(req)
 
-	client := &http.Client{}
+	timeout := c.timeout
+	if timeout <= 0 {
+		timeout = defaultTimeout
+	}
+
+	client := &http.Client{Timeout: timeout}
 	resp, err := client.Do(req)
 	if err != nil {
-		return []byte{}, errors.Wrap(err, "Error sending API request")
+		return []byte{}, &retryableError{err: errors.Wrap(err, "Error sending API request")}
 	}
 	defer resp.Body.Close()
 
 	respBody, err := io.ReadAll(resp.Body)
 	if err != nil {
-		return []byte{}, &APIError{err, "Invalid API response"}
+		return []byte{}, &retryableError{err: &APIError{err, "Invalid API response"}}
 	}
 
 	if resp.StatusCode != 200 {
@@ -80,18 +163,60 @@ func (c *APIClient) doRequest(method string, path string, d interface{}) ([]byte
 
 		err = json.Unmarshal(respBody, &r)
 		if err != nil {
-			return []byte{}, &APIError{err, "Invalid API response"}
+			err = &APIError{err, "Invalid API response"}
+		} else if r.Error == "Invalid API key" {
+			return []byte{}, ErrInvalidAPIKey
+		} else {
+			err = &APIError{errors.New(r.Error), "Received error from API"}
 		}
 
-		if r.Error == "Invalid API key" {
-			return []byte{}, ErrInvalidAPIKey
+		if resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= 500 {
+			return []byte{}, &retryableError{err, parseRetryAfter(resp.Header.Get("Retry-After"))}
 		}
-		return []byte{}, &APIError{errors.New(r.Error), "Received error from API"}
+
+		return []byte{}, err
 	}
 
 	return respBody, nil
 }
 
+// retryWait returns how long to wait before the next attempt. The Retry-After
+// duration from the API is used if there is one, otherwise the wait doubles
+// with each attempt and is jittered so concurrent requests don't retry together.
+func retryWait(attempt int, retryAfter time.Duration) time.Duration {
+	if retryAfter > 0 {
+		if retryAfter > maxRetryAfter {
+			return maxRetryAfter
+		}
+		return retryAfter
+	}
+
+	wait := retryWaitMin << uint(attempt)
+	if wait <= 0 || wait > retryWaitMax {
+		wait = retryWaitMax
+	}
+
+	return wait/2 + time.Duration(rand.Int63n(int64(wait/2)+1)) //nolint:gosec
+}
+
+// parseRetryAfter parses a Retry-After header, which can either be a number
+// of seconds or a HTTP date.
+func parseRetryAfter(v string) time.Duration {
+	if v == "" {
+		return 0
+	}
+
+	if secs, err := strconv.Atoi(v); err == nil {
+		return time.Duration(secs) * time.Second
+	}
+
+	if t, err := http.ParseTime(v); err == nil {
+		return time.Until(t)
+	}
+
+	return 0
+}
+
 func (c *APIClient) AddDefaultHeaders(req *http.Request) {
 	req.Header.Set("content-type", "application/json")
 	req.Header.Set("User-Agent", userAgent())
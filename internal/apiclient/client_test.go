@@ -0,0 +1,131 @@
+package apiclient
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func useShortRetryWaits(t *testing.T) {
+	prevMin, prevMax := retryWaitMin, retryWaitMax
+	retryWaitMin, retryWaitMax = time.Millisecond, 5*time.Millisecond
+	t.Cleanup(func() {
+		retryWaitMin, retryWaitMax = prevMin, prevMax
+	})
+}
+
+func TestDoQueriesRetriesServerErrors(t *testing.T) {
+	useShortRetryWaits(t)
+
+	var attempts int32
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&attempts, 1) < 3 {
+			w.WriteHeader(http.StatusBadGateway)
+			_, _ = w.Write([]byte("Bad gateway"))
+			return
+		}
+		_, _ = w.Write([]byte(`[{"data":{"products":[]}}]`))
+	}))
+	defer ts.Close()
+
+	c := &APIClient{endpoint: ts.URL, maxRetries: 3}
+	results, err := c.doQueries([]GraphQLQuery{{Query: "query"}})
+	require.NoError(t, err)
+	assert.Len(t, results, 1)
+	assert.Equal(t, int32(3), atomic.LoadInt32(&attempts))
+}
+
+func TestDoQueriesHonoursRetryAfter(t *testing.T) {
+	useShortRetryWaits(t)
+
+	var waits []time.Duration
+	prevSleep := sleep
+	sleep = func(d time.Duration) {
+		waits = append(waits, d)
+	}
+	t.Cleanup(func() {
+		sleep = prevSleep
+	})
+
+	var attempts int32
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&attempts, 1) == 1 {
+			w.Header().Set("Retry-After", "7")
+			w.WriteHeader(http.StatusTooManyRequests)
+			_, _ = w.Write([]byte(`{"error":"Rate limit exceeded"}`))
+			return
+		}
+		_, _ = w.Write([]byte(`[{"data":{"products":[]}}]`))
+	}))
+	defer ts.Close()
+
+	c := &APIClient{endpoint: ts.URL, maxRetries: 1}
+	_, err := c.doQueries([]GraphQLQuery{{Query: "query"}})
+	require.NoError(t, err)
+	assert.Equal(t, int32(2), atomic.LoadInt32(&attempts))
+	assert.Equal(t, []time.Duration{7 * time.Second}, waits)
+}
+
+func TestDoQueriesUnavailable(t *testing.T) {
+	useShortRetryWaits(t)
+
+	var attempts int32
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&attempts, 1)
+		w.WriteHeader(http.StatusServiceUnavailable)
+		_, _ = w.Write([]byte(`{"error":"Service unavailable"}`))
+	}))
+	defer ts.Close()
+
+	c := &APIClient{endpoint: ts.URL, maxRetries: 2}
+	_, err := c.doQueries([]GraphQLQuery{{Query: "query"}})
+
+	var unavailableErr *APIUnavailableError
+	require.True(t, errors.As(err, &unavailableErr))
+	assert.Equal(t, "API unavailable after 3 attempts: Received error from API: Service unavailable", err.Error())
+	assert.Equal(t, int32(3), atomic.LoadInt32(&attempts))
+}
+
+func TestDoQueriesDoesNotRetryClientErrors(t *testing.T) {
+	useShortRetryWaits(t)
+
+	var attempts int32
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&attempts, 1)
+		w.WriteHeader(http.StatusForbidden)
+		_, _ = w.Write([]byte(`{"error":"Invalid API key"}`))
+	}))
+	defer ts.Close()
+
+	c := &APIClient{endpoint: ts.URL, maxRetries: 3}
+	_, err := c.doQueries([]GraphQLQuery{{Query: "query"}})
+	assert.Equal(t, ErrInvalidAPIKey, err)
+	assert.Equal(t, int32(1), atomic.LoadInt32(&attempts))
+}
+
+func TestDoRequestTimeout(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		time.Sleep(100 * time.Millisecond)
+	}))
+	defer ts.Close()
+
+	c := &APIClient{endpoint: ts.URL, timeout: 10 * time.Millisecond}
+	_, err := c.doRequest("POST", "/event", map[string]string{})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "Error sending API request")
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	assert.Equal(t, time.Duration(0), parseRetryAfter(""))
+	assert.Equal(t, 5*time.Second, parseRetryAfter("5"))
+	assert.Equal(t, time.Duration(0), parseRetryAfter("invalid"))
+
+	d := parseRetryAfter(time.Now().Add(time.Minute).UTC().Format(http.TimeFormat))
+	assert.Greater(t, d, 50*time.Second)
+}
@@ -42,6 +42,7 @@ func NewDashboardAPIClient(ctx *config.RunContext) *DashboardAPIClient {
 		APIClient: APIClient{
 			endpoint: ctx.Config.DashboardAPIEndpoint,
 			apiKey:   ctx.Config.APIKey,
+			timeout:  ctx.Config.APITimeout,
 		},
 		dashboardEnabled: ctx.Config.EnableDashboard,
 	}
@@ -0,0 +1,142 @@
+package apiclient
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/tidwall/gjson"
+
+	"github.com/infracost/infracost/internal/schema"
+)
+
+var priceCacheDir = filepath.Join(".infracost", "pricing-cache")
+
+// PriceCache is an on-disk cache of Cloud Pricing API query results. Each result
+// is stored in its own file, named by the hash of the query filters and currency,
+// so the cache can be shared by concurrent workers and across runs.
+type PriceCache struct {
+	dir    string
+	ttl    time.Duration
+	hits   int64
+	misses int64
+}
+
+type queryHashKey struct {
+	ProductFilter *schema.ProductFilter `json:"productFilter"`
+	PriceFilter   *schema.PriceFilter   `json:"priceFilter"`
+	Currency      string                `json:"currency"`
+}
+
+// NewPriceCache returns a cache that stores results in dir. Results older than
+// ttl are treated as missing.
+func NewPriceCache(dir string, ttl time.Duration) *PriceCache {
+	return &PriceCache{
+		dir: dir,
+		ttl: ttl,
+	}
+}
+
+// Get returns the cached result for the filters, if there is one that hasn't expired.
+func (c *PriceCache) Get(product *schema.ProductFilter, price *schema.PriceFilter, currency string) (gjson.Result, bool) {
+	path, err := c.path(product, price, currency)
+	if err != nil {
+		log.Debugf("Skipping price cache: %v", err)
+		atomic.AddInt64(&c.misses, 1)
+		return gjson.Result{}, false
+	}
+
+	info, err := os.Stat(path)
+	if err != nil || time.Since(info.ModTime()) > c.ttl {
+		atomic.AddInt64(&c.misses, 1)
+		return gjson.Result{}, false
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil || !gjson.ValidBytes(data) {
+		log.Debugf("Skipping price cache: Error reading cache file %s", path)
+		atomic.AddInt64(&c.misses, 1)
+		return gjson.Result{}, false
+	}
+
+	atomic.AddInt64(&c.hits, 1)
+	return gjson.ParseBytes(data), true
+}
+
+// Set stores the result for the filters. Results with errors aren't cached so
+// they are retried on the next run. Failures to write are only logged since
+// the cache is an optimization.
+func (c *PriceCache) Set(product *schema.ProductFilter, price *schema.PriceFilter, currency string, result gjson.Result) {
+	if result.Get("errors").Exists() || !result.Get("data.products").Exists() {
+		return
+	}
+
+	path, err := c.path(product, price, currency)
+	if err != nil {
+		log.Debugf("Failed to write price cache: %v", err)
+		return
+	}
+
+	err = os.MkdirAll(c.dir, 0700)
+	if err != nil {
+		log.Debugf("Couldn't create %v directory: %v", c.dir, err)
+		return
+	}
+
+	// Write to a temp file and rename it so concurrent readers never see a partial file
+	tmp, err := os.CreateTemp(c.dir, ".tmp-*")
+	if err != nil {
+		log.Debugf("Failed to write price cache: %v", err)
+		return
+	}
+	defer os.Remove(tmp.Name())
+
+	_, err = tmp.WriteString(result.Raw)
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		log.Debugf("Failed to write price cache: %v", err)
+		return
+	}
+
+	err = os.Rename(tmp.Name(), path)
+	if err != nil {
+		log.Debugf("Failed to write price cache: %v", err)
+	}
+}
+
+// Stats returns the number of cache hits and misses so far.
+func (c *PriceCache) Stats() (hits int64, misses int64) {
+	return atomic.LoadInt64(&c.hits), atomic.LoadInt64(&c.misses)
+}
+
+func (c *PriceCache) path(product *schema.ProductFilter, price *schema.PriceFilter, currency string) (string, error) {
+	hash, err := queryHash(product, price, currency)
+	if err != nil {
+		return "", err
+	}
+
+	return filepath.Join(c.dir, hash+".json"), nil
+}
+
+// queryHash returns a hash of the serialized filters and currency. Queries with
+// the same hash always return the same prices.
+func queryHash(product *schema.ProductFilter, price *schema.PriceFilter, currency string) (string, error) {
+	b, err := json.Marshal(queryHashKey{
+		ProductFilter: product,
+		PriceFilter:   price,
+		Currency:      currency,
+	})
+	if err != nil {
+		return "", err
+	}
+
+	sum := sha256.Sum256(b)
+	return hex.EncodeToString(sum[:]), nil
+}
@@ -0,0 +1,75 @@
+package apiclient
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tidwall/gjson"
+
+	"github.com/infracost/infracost/internal/schema"
+)
+
+func TestPriceCache(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "pricing-cache")
+	cache := NewPriceCache(dir, time.Hour)
+
+	product := &schema.ProductFilter{
+		VendorName:       strPtr("aws"),
+		AttributeFilters: []*schema.AttributeFilter{{Key: "instanceType", Value: strPtr("t3.micro")}},
+	}
+	price := &schema.PriceFilter{PurchaseOption: strPtr("on_demand")}
+	result := gjson.Parse(`{"data":{"products":[{"prices":[{"priceHash":"abc","USD":"0.0104"}]}]}}`)
+
+	_, ok := cache.Get(product, price, "USD")
+	assert.False(t, ok)
+
+	cache.Set(product, price, "USD", result)
+
+	res, ok := cache.Get(product, price, "USD")
+	require.True(t, ok)
+	assert.Equal(t, "0.0104", res.Get("data.products.0.prices.0.USD").String())
+
+	_, ok = cache.Get(product, price, "EUR")
+	assert.False(t, ok, "results should be keyed on currency")
+
+	_, ok = cache.Get(product, &schema.PriceFilter{PurchaseOption: strPtr("reserved")}, "USD")
+	assert.False(t, ok, "results should be keyed on the price filter")
+
+	hits, misses := cache.Stats()
+	assert.Equal(t, int64(1), hits)
+	assert.Equal(t, int64(3), misses)
+}
+
+func TestPriceCacheExpired(t *testing.T) {
+	dir := t.TempDir()
+	cache := NewPriceCache(dir, time.Hour)
+
+	product := &schema.ProductFilter{VendorName: strPtr("aws")}
+	cache.Set(product, nil, "USD", gjson.Parse(`{"data":{"products":[]}}`))
+
+	files, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	require.Len(t, files, 1)
+
+	old := time.Now().Add(-2 * time.Hour)
+	err = os.Chtimes(filepath.Join(dir, files[0].Name()), old, old)
+	require.NoError(t, err)
+
+	_, ok := cache.Get(product, nil, "USD")
+	assert.False(t, ok)
+}
+
+func TestPriceCacheSkipsErrors(t *testing.T) {
+	dir := t.TempDir()
+	cache := NewPriceCache(dir, time.Hour)
+
+	product := &schema.ProductFilter{VendorName: strPtr("aws")}
+	cache.Set(product, nil, "USD", gjson.Parse(`{"errors":[{"message":"Internal server error"}]}`))
+
+	_, ok := cache.Get(product, nil, "USD")
+	assert.False(t, ok)
+}
@@ -2,17 +2,27 @@ package apiclient
 
 import (
 	"fmt"
+	"runtime"
+	"sync"
+
 	"github.com/infracost/infracost/internal/config"
 	"github.com/infracost/infracost/internal/schema"
 
+	"github.com/pkg/errors"
 	log "github.com/sirupsen/logrus"
 	"github.com/tidwall/gjson"
 )
 
+var defaultPricingBatchSize = 100
+
 type PricingAPIClient struct {
 	APIClient
-	Currency       string
-	EventsDisabled bool
+	Currency            string
+	EventsDisabled      bool
+	PricingSnapshotPath string
+	Cache               *PriceCache
+	BatchSize           int
+	Concurrency         int
 }
 
 type PriceQueryKey struct {
@@ -25,22 +35,65 @@ type PriceQueryResult struct {
 	Result gjson.Result
 }
 
+// priceQuery is a unique pair of product and price filters. The indexes of all the
+// keys that share the filters are tracked so the result can be fanned back out.
+type priceQuery struct {
+	product *schema.ProductFilter
+	price   *schema.PriceFilter
+	keyIdxs []int
+}
+
 func NewPricingAPIClient(cfg *config.Config) *PricingAPIClient {
 	currency := cfg.Currency
 	if currency == "" {
 		currency = "USD"
 	}
 
+	var cache *PriceCache
+	if !cfg.NoPriceCache && cfg.PriceCacheTTL > 0 && cfg.PricingSnapshot == "" {
+		cache = NewPriceCache(priceCacheDir, cfg.PriceCacheTTL)
+	}
+
+	batchSize := cfg.PricingBatchSize
+	if batchSize <= 0 {
+		batchSize = defaultPricingBatchSize
+	}
+
+	concurrency := cfg.PricingConcurrency
+	if concurrency <= 0 {
+		concurrency = defaultPricingConcurrency()
+	}
+
 	return &PricingAPIClient{
 		APIClient: APIClient{
-			endpoint: cfg.PricingAPIEndpoint,
-			apiKey:   cfg.APIKey,
+			endpoint:   cfg.PricingAPIEndpoint,
+			apiKey:     cfg.APIKey,
+			timeout:    cfg.APITimeout,
+			maxRetries: cfg.APIMaxRetries,
 		},
-		Currency:       currency,
-		EventsDisabled: cfg.EventsDisabled,
+		Currency:            currency,
+		EventsDisabled:      cfg.EventsDisabled,
+		PricingSnapshotPath: cfg.PricingSnapshot,
+		Cache:               cache,
+		BatchSize:           batchSize,
+		Concurrency:         concurrency,
 	}
 }
 
+// defaultPricingConcurrency calculates the number of concurrent requests using
+// the following formula: min(max(4, numCPU * 4), 16)
+func defaultPricingConcurrency() int {
+	n := 4
+	numCPU := runtime.NumCPU()
+	if numCPU*4 > n {
+		n = numCPU * 4
+	}
+	if n > 16 {
+		n = 16
+	}
+	return n
+}
+
 func (c *PricingAPIClient) AddEvent(name string, env map[string]interface{}) error {
 	if c.EventsDisabled {
 		return nil
@@ -55,22 +108,158 @@ func (c *PricingAPIClient) AddEvent(name string, env map[string]interface{}) err
 	return err
 }
 
-func (c *PricingAPIClient) RunQueries(r *schema.Resource) ([]PriceQueryResult, error) {
-	keys, queries := c.batchQueries(r)
+// RunQueries gets the prices for the cost components of all the resources. Identical
+// queries are only run once, even if they are from different resources or projects,
+// and the result is returned for every cost component that uses them.
+func (c *PricingAPIClient) RunQueries(resources []*schema.Resource) ([]PriceQueryResult, error) {
+	keys := make([]PriceQueryKey, 0)
+	for _, r := range resources {
+		keys = append(keys, c.queryKeys(r)...)
+	}
 
-	if len(queries) == 0 {
-		log.Debugf("Skipping getting pricing details for %s since there are no queries to run", r.Name)
+	if len(keys) == 0 {
+		log.Debugf("Skipping getting pricing details since there are no queries to run")
 		return []PriceQueryResult{}, nil
 	}
 
-	log.Debugf("Getting pricing details from %s for %s", c.endpoint, r.Name)
+	queries, err := c.dedupeQueries(keys)
+	if err != nil {
+		return []PriceQueryResult{}, err
+	}
 
-	results, err := c.doQueries(queries)
+	log.Debugf("Getting pricing details for %d cost components using %d unique queries", len(keys), len(queries))
+
+	var results []gjson.Result
+	if c.PricingSnapshotPath != "" {
+		results, err = c.querySnapshot(queries)
+	} else {
+		results, err = c.queryAPI(queries)
+	}
 	if err != nil {
 		return []PriceQueryResult{}, err
 	}
 
-	return c.zipQueryResults(keys, results), nil
+	return c.fanOutQueryResults(keys, queries, results), nil
+}
+
+// querySnapshot looks up the prices for the queries in the local pricing snapshot
+// instead of sending the queries to the Cloud Pricing API.
+func (c *PricingAPIClient) querySnapshot(queries []*priceQuery) ([]gjson.Result, error) {
+	log.Debugf("Getting pricing details from snapshot %s", c.PricingSnapshotPath)
+
+	snapshot, err := LoadPricingSnapshot(c.PricingSnapshotPath)
+	if err != nil {
+		return []gjson.Result{}, err
+	}
+
+	results := make([]gjson.Result, 0, len(queries))
+	for _, q := range queries {
+		result, err := snapshot.Query(q.product, q.price, c.Currency)
+		if err != nil {
+			return []gjson.Result{}, err
+		}
+		results = append(results, result)
+	}
+
+	return results, nil
+}
+
+// queryAPI uses the results from the price cache where possible and sends the
+// remaining queries to the Cloud Pricing API in concurrent batches. The new
+// results are then written back to the cache.
+func (c *PricingAPIClient) queryAPI(queries []*priceQuery) ([]gjson.Result, error) {
+	results := make([]gjson.Result, len(queries))
+
+	missingIdxs := make([]int, 0, len(queries))
+	for i, q := range queries {
+		if c.Cache != nil {
+			if result, ok := c.Cache.Get(q.product, q.price, c.Currency); ok {
+				results[i] = result
+				continue
+			}
+		}
+
+		missingIdxs = append(missingIdxs, i)
+	}
+
+	if len(missingIdxs) == 0 {
+		log.Debugf("Using cached pricing details for all queries")
+		return results, nil
+	}
+
+	batches := make([][]int, 0, len(missingIdxs)/c.BatchSize+1)
+	for start := 0; start < len(missingIdxs); start += c.BatchSize {
+		end := start + c.BatchSize
+		if end > len(missingIdxs) {
+			end = len(missingIdxs)
+		}
+		batches = append(batches, missingIdxs[start:end])
+	}
+
+	log.Debugf("Getting pricing details from %s for %d queries in %d batches (%d queries cached)", c.endpoint, len(missingIdxs), len(batches), len(queries)-len(missingIdxs))
+
+	numWorkers := c.Concurrency
+	if numWorkers > len(batches) {
+		numWorkers = len(batches)
+	}
+
+	jobs := make(chan []int, len(batches))
+	resultErrors := make(chan error, len(batches))
+
+	var wg sync.WaitGroup
+	for i := 0; i < numWorkers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for batch := range jobs {
+				resultErrors <- c.queryBatch(queries, batch, results)
+			}
+		}()
+	}
+
+	for _, batch := range batches {
+		jobs <- batch
+	}
+	close(jobs)
+
+	wg.Wait()
+	close(resultErrors)
+
+	for err := range resultErrors {
+		if err != nil {
+			return []gjson.Result{}, err
+		}
+	}
+
+	return results, nil
+}
+
+// queryBatch sends the queries at the given indexes in a single GraphQL request
+// and stores the results at the same indexes.
+func (c *PricingAPIClient) queryBatch(queries []*priceQuery, idxs []int, results []gjson.Result) error {
+	gqlQueries := make([]GraphQLQuery, 0, len(idxs))
+	for _, i := range idxs {
+		gqlQueries = append(gqlQueries, c.buildQuery(queries[i].product, queries[i].price))
+	}
+
+	batchResults, err := c.doQueries(gqlQueries)
+	if err != nil {
+		return err
+	}
+
+	if len(batchResults) != len(idxs) {
+		return errors.Errorf("Expected %d results from the Cloud Pricing API but got %d", len(idxs), len(batchResults))
+	}
+
+	for j, i := range idxs {
+		results[i] = batchResults[j]
+
+		if c.Cache != nil {
+			c.Cache.Set(queries[i].product, queries[i].price, c.Currency, batchResults[j])
+		}
+	}
+
+	return nil
 }
 
 func (c *PricingAPIClient) buildQuery(product *schema.ProductFilter, price *schema.PriceFilter) GraphQLQuery {
@@ -92,35 +281,69 @@ func (c *PricingAPIClient) buildQuery(product *schema.ProductFilter, price *sche
 	return GraphQLQuery{query, v}
 }
 
-// Batch all the queries for this resource so we can use one GraphQL call.
-// Use PriceQueryKeys to keep track of which query maps to which sub-resource and price component.
-func (c *PricingAPIClient) batchQueries(r *schema.Resource) ([]PriceQueryKey, []GraphQLQuery) {
+// queryKeys returns a key for every cost component of the resource and its sub-resources.
+// The keys are used to keep track of which result maps to which sub-resource and cost component.
+func (c *PricingAPIClient) queryKeys(r *schema.Resource) []PriceQueryKey {
 	keys := make([]PriceQueryKey, 0)
-	queries := make([]GraphQLQuery, 0)
+
+	if r.IsSkipped {
+		return keys
+	}
 
 	for _, component := range r.CostComponents {
 		keys = append(keys, PriceQueryKey{r, component})
-		queries = append(queries, c.buildQuery(component.ProductFilter, component.PriceFilter))
 	}
 
 	for _, subresource := range r.FlattenedSubResources() {
 		for _, component := range subresource.CostComponents {
 			keys = append(keys, PriceQueryKey{subresource, component})
-			queries = append(queries, c.buildQuery(component.ProductFilter, component.PriceFilter))
 		}
 	}
 
-	return keys, queries
+	return keys
+}
+
+// dedupeQueries groups the keys that have identical product and price filters
+// so each unique query is only run once.
+func (c *PricingAPIClient) dedupeQueries(keys []PriceQueryKey) ([]*priceQuery, error) {
+	queries := make([]*priceQuery, 0)
+	seen := make(map[string]*priceQuery)
+
+	for i, k := range keys {
+		hash, err := queryHash(k.CostComponent.ProductFilter, k.CostComponent.PriceFilter, c.Currency)
+		if err != nil {
+			return nil, errors.Wrap(err, "Error generating pricing query")
+		}
+
+		if q, ok := seen[hash]; ok {
+			q.keyIdxs = append(q.keyIdxs, i)
+			continue
+		}
+
+		q := &priceQuery{
+			product: k.CostComponent.ProductFilter,
+			price:   k.CostComponent.PriceFilter,
+			keyIdxs: []int{i},
+		}
+		seen[hash] = q
+		queries = append(queries, q)
+	}
+
+	return queries, nil
 }
 
-func (c *PricingAPIClient) zipQueryResults(k []PriceQueryKey, r []gjson.Result) []PriceQueryResult {
-	res := make([]PriceQueryResult, 0, len(k))
+// fanOutQueryResults returns the result of each unique query for all the keys that share it,
+// in the same order as the keys.
+func (c *PricingAPIClient) fanOutQueryResults(keys []PriceQueryKey, queries []*priceQuery, results []gjson.Result) []PriceQueryResult {
+	res := make([]PriceQueryResult, len(keys))
 
-	for i, k := range k {
-		res = append(res, PriceQueryResult{
-			PriceQueryKey: k,
-			Result:        r[i],
-		})
+	for i, q := range queries {
+		for _, idx := range q.keyIdxs {
+			res[idx] = PriceQueryResult{
+				PriceQueryKey: keys[idx],
+				Result:        results[i],
+			}
+		}
 	}
 
 	return res
@@ -0,0 +1,234 @@
+package apiclient
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+	"sync"
+
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+	"github.com/tidwall/gjson"
+
+	"github.com/infracost/infracost/internal/schema"
+)
+
+// PricingSnapshot is a local export of Cloud Pricing API products and prices.
+// It can be used in place of the Cloud Pricing API so that prices can be
+// looked up without any network access. The file is JSON in the form:
+//
+//	{
+//		"products": [
+//			{
+//				"vendorName": "aws",
+//				"service": "AmazonEC2",
+//				"productFamily": "Compute Instance",
+//				"region": "us-east-1",
+//				"sku": "ABC123",
+//				"attributes": {"instanceType": "t3.micro"},
+//				"prices": [
+//					{"priceHash": "abc-123", "purchaseOption": "on_demand", "unit": "Hrs", "USD": "0.0104"}
+//				]
+//			}
+//		]
+//	}
+//
+// Each price is a map of price attributes, e.g. purchaseOption or startUsageAmount,
+// and the amount of the price keyed by currency code.
+type PricingSnapshot struct {
+	Products []*SnapshotProduct `json:"products"`
+}
+
+type SnapshotProduct struct {
+	VendorName    string            `json:"vendorName"`
+	Service       string            `json:"service"`
+	ProductFamily string            `json:"productFamily"`
+	Region        string            `json:"region"`
+	Sku           string            `json:"sku"`
+	Attributes    map[string]string `json:"attributes"`
+	Prices        []SnapshotPrice   `json:"prices"`
+}
+
+type SnapshotPrice map[string]string
+
+var (
+	pricingSnapshots   = map[string]*PricingSnapshot{}
+	pricingSnapshotsMu sync.Mutex
+)
+
+// LoadPricingSnapshot reads the pricing snapshot at the given path. Snapshots are
+// cached so the file is only read once per run, even when pricing multiple projects.
+func LoadPricingSnapshot(path string) (*PricingSnapshot, error) {
+	pricingSnapshotsMu.Lock()
+	defer pricingSnapshotsMu.Unlock()
+
+	if s, ok := pricingSnapshots[path]; ok {
+		return s, nil
+	}
+
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, errors.Wrap(err, "Error reading pricing snapshot file")
+	}
+
+	var s PricingSnapshot
+	err = json.Unmarshal(b, &s)
+	if err != nil {
+		return nil, errors.Wrap(err, "Error parsing pricing snapshot file")
+	}
+
+	log.Debugf("Loaded %d products from pricing snapshot %s", len(s.Products), path)
+
+	pricingSnapshots[path] = &s
+	return &s, nil
+}
+
+// Query returns the products and prices matching the filters in the same shape as
+// the Cloud Pricing API GraphQL response, so the results can be used interchangeably.
+func (s *PricingSnapshot) Query(product *schema.ProductFilter, price *schema.PriceFilter, currency string) (gjson.Result, error) {
+	products := make([]map[string]interface{}, 0)
+
+	for _, p := range s.Products {
+		ok, err := p.matches(product)
+		if err != nil {
+			return gjson.Result{}, err
+		}
+		if !ok {
+			continue
+		}
+
+		prices := make([]map[string]string, 0)
+		for _, pr := range p.Prices {
+			ok, err := pr.matches(price)
+			if err != nil {
+				return gjson.Result{}, err
+			}
+			if !ok {
+				continue
+			}
+
+			amount, ok := pr[currency]
+			if !ok {
+				log.Debugf("Skipping price %s from pricing snapshot since it has no %s amount", pr["priceHash"], currency)
+				continue
+			}
+
+			prices = append(prices, map[string]string{
+				"priceHash": pr["priceHash"],
+				currency:    amount,
+			})
+		}
+
+		products = append(products, map[string]interface{}{
+			"prices": prices,
+		})
+	}
+
+	b, err := json.Marshal(map[string]interface{}{
+		"data": map[string]interface{}{
+			"products": products,
+		},
+	})
+	if err != nil {
+		return gjson.Result{}, errors.Wrap(err, "Error generating pricing snapshot result")
+	}
+
+	return gjson.ParseBytes(b), nil
+}
+
+func (p *SnapshotProduct) matches(f *schema.ProductFilter) (bool, error) {
+	if f == nil {
+		return true, nil
+	}
+
+	if !matchesStr(f.VendorName, p.VendorName) ||
+		!matchesStr(f.Service, p.Service) ||
+		!matchesStr(f.ProductFamily, p.ProductFamily) ||
+		!matchesStr(f.Region, p.Region) ||
+		!matchesStr(f.Sku, p.Sku) {
+		return false, nil
+	}
+
+	for _, a := range f.AttributeFilters {
+		v, ok := p.Attributes[a.Key]
+		if !ok {
+			return false, nil
+		}
+
+		if !matchesStr(a.Value, v) {
+			return false, nil
+		}
+
+		ok, err := matchesRegex(a.ValueRegex, v)
+		if err != nil || !ok {
+			return false, err
+		}
+	}
+
+	return true, nil
+}
+
+func (p SnapshotPrice) matches(f *schema.PriceFilter) (bool, error) {
+	if f == nil {
+		return true, nil
+	}
+
+	if !matchesStr(f.PurchaseOption, p["purchaseOption"]) ||
+		!matchesStr(f.Unit, p["unit"]) ||
+		!matchesStr(f.Description, p["description"]) ||
+		!matchesStr(f.StartUsageAmount, p["startUsageAmount"]) ||
+		!matchesStr(f.EndUsageAmount, p["endUsageAmount"]) ||
+		!matchesStr(f.TermLength, p["termLength"]) ||
+		!matchesStr(f.TermPurchaseOption, p["termPurchaseOption"]) ||
+		!matchesStr(f.TermOfferingClass, p["termOfferingClass"]) {
+		return false, nil
+	}
+
+	return matchesRegex(f.DescriptionRegex, p["description"])
+}
+
+func matchesStr(filter *string, v string) bool {
+	return filter == nil || *filter == v
+}
+
+var regexCache sync.Map
+
+// matchesRegex matches the value against a Cloud Pricing API regex filter.
+// These are in the form /pattern/flags, e.g. /^t3\.micro$/i.
+func matchesRegex(filter *string, v string) (bool, error) {
+	if filter == nil {
+		return true, nil
+	}
+
+	if r, ok := regexCache.Load(*filter); ok {
+		return r.(*regexp.Regexp).MatchString(v), nil
+	}
+
+	pattern := *filter
+	if strings.HasPrefix(pattern, "/") && strings.LastIndex(pattern, "/") > 0 {
+		i := strings.LastIndex(pattern, "/")
+		pattern = (*filter)[1:i]
+
+		// Only keep the flags that Go regexes support, e.g. ignore the JS global flag
+		flags := ""
+		for _, f := range (*filter)[i+1:] {
+			if strings.ContainsRune("ims", f) {
+				flags += string(f)
+			}
+		}
+
+		if flags != "" {
+			pattern = fmt.Sprintf("(?%s)%s", flags, pattern)
+		}
+	}
+
+	r, err := regexp.Compile(pattern)
+	if err != nil {
+		return false, errors.Wrapf(err, "Invalid regex filter %s", *filter)
+	}
+
+	regexCache.Store(*filter, r)
+	return r.MatchString(v), nil
+}
@@ -0,0 +1,106 @@
+package apiclient
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/infracost/infracost/internal/schema"
+)
+
+func strPtr(s string) *string {
+	return &s
+}
+
+func TestPricingSnapshotQuery(t *testing.T) {
+	snapshot := &PricingSnapshot{
+		Products: []*SnapshotProduct{
+			{
+				VendorName:    "aws",
+				Service:       "AmazonEC2",
+				ProductFamily: "Compute Instance",
+				Region:        "us-east-1",
+				Attributes:    map[string]string{"instanceType": "t3.micro", "tenancy": "Shared"},
+				Prices: []SnapshotPrice{
+					{"priceHash": "on-demand-hash", "purchaseOption": "on_demand", "description": "Linux/UNIX usage", "USD": "0.0104"},
+					{"priceHash": "reserved-hash", "purchaseOption": "reserved", "termLength": "1yr", "USD": "0.0065"},
+				},
+			},
+			{
+				VendorName:    "aws",
+				Service:       "AmazonEC2",
+				ProductFamily: "Compute Instance",
+				Region:        "us-east-1",
+				Attributes:    map[string]string{"instanceType": "t3.large", "tenancy": "Shared"},
+				Prices: []SnapshotPrice{
+					{"priceHash": "large-hash", "purchaseOption": "on_demand", "USD": "0.0832"},
+				},
+			},
+		},
+	}
+
+	tests := map[string]struct {
+		product        *schema.ProductFilter
+		price          *schema.PriceFilter
+		currency       string
+		expectedHashes []string
+	}{
+		"exact attribute match": {
+			product: &schema.ProductFilter{
+				VendorName:       strPtr("aws"),
+				Region:           strPtr("us-east-1"),
+				AttributeFilters: []*schema.AttributeFilter{{Key: "instanceType", Value: strPtr("t3.micro")}},
+			},
+			price:          &schema.PriceFilter{PurchaseOption: strPtr("on_demand")},
+			currency:       "USD",
+			expectedHashes: []string{"on-demand-hash"},
+		},
+		"case insensitive regex attribute match": {
+			product: &schema.ProductFilter{
+				AttributeFilters: []*schema.AttributeFilter{{Key: "instanceType", ValueRegex: strPtr("/^T3\\.LARGE$/i")}},
+			},
+			currency:       "USD",
+			expectedHashes: []string{"large-hash"},
+		},
+		"description regex match": {
+			product: &schema.ProductFilter{
+				AttributeFilters: []*schema.AttributeFilter{{Key: "instanceType", Value: strPtr("t3.micro")}},
+			},
+			price:          &schema.PriceFilter{DescriptionRegex: strPtr("/linux/i")},
+			currency:       "USD",
+			expectedHashes: []string{"on-demand-hash"},
+		},
+		"no matching region": {
+			product: &schema.ProductFilter{
+				Region: strPtr("eu-west-1"),
+			},
+			currency:       "USD",
+			expectedHashes: []string{},
+		},
+		"missing currency": {
+			product: &schema.ProductFilter{
+				AttributeFilters: []*schema.AttributeFilter{{Key: "instanceType", Value: strPtr("t3.large")}},
+			},
+			currency:       "EUR",
+			expectedHashes: []string{},
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			res, err := snapshot.Query(tc.product, tc.price, tc.currency)
+			require.NoError(t, err)
+
+			hashes := []string{}
+			for _, p := range res.Get("data.products").Array() {
+				for _, price := range p.Get("prices").Array() {
+					hashes = append(hashes, price.Get("priceHash").String())
+					assert.True(t, price.Get(tc.currency).Exists())
+				}
+			}
+
+			assert.Equal(t, tc.expectedHashes, hashes)
+		})
+	}
+}
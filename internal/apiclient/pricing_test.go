@@ -0,0 +1,91 @@
+package apiclient
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/infracost/infracost/internal/config"
+	"github.com/infracost/infracost/internal/schema"
+)
+
+func TestRunQueriesDedupesAndBatches(t *testing.T) {
+	var mu sync.Mutex
+	var batchSizes []int
+	skus := map[string]int{}
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var queries []struct {
+			Variables struct {
+				ProductFilter schema.ProductFilter `json:"productFilter"`
+			} `json:"variables"`
+		}
+		err := json.NewDecoder(r.Body).Decode(&queries)
+		require.NoError(t, err)
+
+		results := make([]interface{}, 0, len(queries))
+
+		mu.Lock()
+		batchSizes = append(batchSizes, len(queries))
+		for _, q := range queries {
+			sku := *q.Variables.ProductFilter.Sku
+			skus[sku]++
+			results = append(results, map[string]interface{}{
+				"data": map[string]interface{}{
+					"products": []interface{}{
+						map[string]interface{}{
+							"prices": []interface{}{
+								map[string]string{"priceHash": sku + "-hash", "USD": "1"},
+							},
+						},
+					},
+				},
+			})
+		}
+		mu.Unlock()
+
+		_ = json.NewEncoder(w).Encode(results)
+	}))
+	defer ts.Close()
+
+	c := NewPricingAPIClient(&config.Config{
+		PricingAPIEndpoint: ts.URL,
+		PricingBatchSize:   2,
+		PricingConcurrency: 2,
+	})
+
+	resources := make([]*schema.Resource, 0)
+	for i := 0; i < 10; i++ {
+		resources = append(resources, &schema.Resource{
+			Name: fmt.Sprintf("aws_instance.web[%d]", i),
+			CostComponents: []*schema.CostComponent{
+				{Name: "Instance usage", ProductFilter: &schema.ProductFilter{Sku: strPtr(fmt.Sprintf("instance-%d", i%3))}},
+				{Name: "Storage", ProductFilter: &schema.ProductFilter{Sku: strPtr("storage")}},
+			},
+		})
+	}
+	resources = append(resources, &schema.Resource{
+		Name:      "aws_skipped.skipped",
+		IsSkipped: true,
+		CostComponents: []*schema.CostComponent{
+			{Name: "Skipped", ProductFilter: &schema.ProductFilter{Sku: strPtr("skipped")}},
+		},
+	})
+
+	results, err := c.RunQueries(resources)
+	require.NoError(t, err)
+	require.Len(t, results, 20)
+
+	for _, r := range results {
+		assert.Equal(t, *r.CostComponent.ProductFilter.Sku+"-hash", r.Result.Get("data.products.0.prices.0.priceHash").String())
+	}
+
+	assert.Equal(t, map[string]int{"instance-0": 1, "instance-1": 1, "instance-2": 1, "storage": 1}, skus)
+	assert.ElementsMatch(t, []int{2, 2}, batchSizes)
+}
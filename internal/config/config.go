@@ -5,7 +5,9 @@ import (
 	"log"
 	"os"
 	"path/filepath"
+	"runtime"
 	"strings"
+	"time"
 
 	"github.com/joho/godotenv"
 	"github.com/kelseyhightower/envconfig"
@@ -34,11 +36,77 @@ type Project struct {
 	TerraformCloudToken string `yaml:"terraform_cloud_token,omitempty" envconfig:"INFRACOST_TERRAFORM_CLOUD_TOKEN"`
 	// UsageFile is the full path to usage file that specifies values for usage-based resources
 	UsageFile string `yaml:"usage_file,omitempty" ignored:"true"`
+	// BillingExport is the path to a billing export CSV file, e.g. an AWS Cost and Usage
+	// Report, that's used to sync the usage file from the actual usage of the resources.
+	BillingExport string `yaml:"billing_export,omitempty" ignored:"true"`
 	// TerraformUseState sets if the users wants to use the terraform state for infracost ops.
-	TerraformUseState bool              `yaml:"terraform_use_state,omitempty" ignored:"true"`
-	Env               map[string]string `yaml:"env,omitempty" ignored:"true"`
+	TerraformUseState bool `yaml:"terraform_use_state,omitempty" ignored:"true"`
+	// TerraformParseHCL sets if the Terraform files should be evaluated directly instead of
+	// running terraform plan, which doesn't need provider credentials or backend access.
+	TerraformParseHCL bool `yaml:"terraform_parse_hcl,omitempty" ignored:"true"`
+	// TerragruntParallelism is the number of Terragrunt modules that are planned at the
+	// same time. Defaults to the parallelism of the run.
+	TerragruntParallelism int `yaml:"terragrunt_parallelism,omitempty" envconfig:"INFRACOST_TERRAGRUNT_PARALLELISM"`
+	// CloudFormationParametersFile is the path to a JSON file with the parameter values
+	// of a CloudFormation template.
+	CloudFormationParametersFile string `yaml:"cloudformation_parameters_file,omitempty" ignored:"true"`
+	// CloudFormationRegion is the region that a CloudFormation template is deployed to,
+	// which is used for AWS::Region and to price the resources.
+	CloudFormationRegion string `yaml:"cloudformation_region,omitempty" ignored:"true"`
+	// CloudFormationCompareTo is the path to the deployed template of the stack, or to
+	// a change set JSON file from aws cloudformation describe-change-set, which is
+	// used to show the cost changes of a stack update.
+	CloudFormationCompareTo string            `yaml:"cloudformation_compare_to,omitempty" ignored:"true"`
+	Env                     map[string]string `yaml:"env,omitempty" ignored:"true"`
+	// MonthlyBudget is the expected maximum monthly cost of the project.
+	MonthlyBudget *float64 `yaml:"monthly_budget,omitempty" ignored:"true"`
+	// BudgetAlertThreshold is the percentage of the monthly budget, e.g. 80, above which
+	// the project is flagged in the output. Requires MonthlyBudget.
+	BudgetAlertThreshold *float64 `yaml:"budget_alert_threshold,omitempty" ignored:"true"`
 }
 
+// Policy defines a cost policy that is checked against the Infracost output.
+// The policy fails if any of the limits that it sets are exceeded.
+type Policy struct {
+	// Name is used to identify the policy in the violation report.
+	Name string `yaml:"name,omitempty"`
+	// MaxProjectMonthlyCost is the maximum total monthly cost of each project.
+	MaxProjectMonthlyCost *float64 `yaml:"max_project_monthly_cost,omitempty"`
+	// MaxProjectMonthlyDiff is the maximum increase in the monthly cost of each project.
+	// Only applicable to diffs.
+	MaxProjectMonthlyDiff *float64 `yaml:"max_project_monthly_diff,omitempty"`
+	// MaxResourceMonthlyCost is the maximum monthly cost of each resource.
+	MaxResourceMonthlyCost *float64 `yaml:"max_resource_monthly_cost,omitempty"`
+	// DisallowedCostComponents fails resources with a cost component containing any
+	// of these values in its name, e.g. gp2. Matching is case-insensitive.
+	DisallowedCostComponents []string `yaml:"disallowed_cost_components,omitempty"`
+	// ResourceTypes limits the resource checks to these resource types, e.g. aws_ebs_volume.
+	ResourceTypes []string `yaml:"resource_types,omitempty"`
+}
+
+// TagPolicy defines the tags that billable resources must have, e.g. for cost allocation.
+type TagPolicy struct {
+	// Name is used to identify the policy in the output.
+	Name string `yaml:"name,omitempty"`
+	// RequiredKeys are the tag keys that every billable resource must have.
+	RequiredKeys []string `yaml:"required_keys,omitempty"`
+	// DisallowedValues are the values that are not allowed for each tag key,
+	// e.g. environment: [test, tmp].
+	DisallowedValues map[string][]string `yaml:"disallowed_values,omitempty"`
+	// ResourceTypes limits the checks to these resource types, e.g. aws_instance.
+	ResourceTypes []string `yaml:"resource_types,omitempty"`
+	// Enforce returns an error if any resources violate the policy.
+	Enforce bool `yaml:"enforce,omitempty"`
+}
+
+// maxDefaultParallelism is the maximum number of projects that are loaded at the
+// same time when the parallelism isn't set.
+const maxDefaultParallelism = 16
+
+// AllUsageProfiles is the usage profile value that compares the costs of all the
+// profiles in the usage files.
+const AllUsageProfiles = "all"
+
 type Config struct {
 	Credentials   Credentials
 	Configuration Configuration
@@ -54,16 +122,50 @@ type Config struct {
 	DashboardAPIEndpoint      string `yaml:"dashboard_api_endpoint,omitempty" envconfig:"INFRACOST_DASHBOARD_API_ENDPOINT"`
 	EnableDashboard           bool   `yaml:"enable_dashboard,omitempty" envconfig:"INFRACOST_ENABLE_DASHBOARD"`
 
+	// APITimeout is the timeout for each request to the Infracost APIs.
+	APITimeout time.Duration `yaml:"api_timeout,omitempty" envconfig:"INFRACOST_API_TIMEOUT"`
+	// APIMaxRetries is how many times Cloud Pricing API queries are retried if the
+	// API is unavailable or rate limiting requests. Set to 0 to turn off retries.
+	APIMaxRetries int `yaml:"api_max_retries,omitempty" envconfig:"INFRACOST_API_MAX_RETRIES"`
+
 	Currency string `envconfig:"INFRACOST_CURRENCY"`
 
-	Projects      []*Project `yaml:"projects" ignored:"true"`
-	Format        string     `yaml:"format,omitempty" ignored:"true"`
-	ShowSkipped   bool       `yaml:"show_skipped,omitempty" ignored:"true"`
-	SyncUsageFile bool       `yaml:"sync_usage_file,omitempty" ignored:"true"`
-	Fields        []string   `yaml:"fields,omitempty" ignored:"true"`
+	// PricingSnapshot is the path to a local pricing snapshot file. When set prices are
+	// looked up from this file instead of the Cloud Pricing API.
+	PricingSnapshot string `yaml:"pricing_snapshot,omitempty" envconfig:"INFRACOST_PRICING_SNAPSHOT"`
+
+	// NoPriceCache turns off the on-disk cache of Cloud Pricing API results.
+	NoPriceCache bool `yaml:"no_price_cache,omitempty" envconfig:"INFRACOST_NO_PRICE_CACHE"`
+	// PriceCacheTTL is how long cached Cloud Pricing API results are used for, e.g. 12h.
+	PriceCacheTTL time.Duration `yaml:"price_cache_ttl,omitempty" envconfig:"INFRACOST_PRICE_CACHE_TTL"`
+
+	// PricingBatchSize is the maximum number of queries sent to the Cloud Pricing API in one request.
+	PricingBatchSize int `yaml:"pricing_batch_size,omitempty" envconfig:"INFRACOST_PRICING_BATCH_SIZE"`
+	// PricingConcurrency is the number of requests sent to the Cloud Pricing API at the same time.
+	// Defaults to 4 per CPU, up to a maximum of 16.
+	PricingConcurrency int `yaml:"pricing_concurrency,omitempty" envconfig:"INFRACOST_PRICING_CONCURRENCY"`
+
+	// Parallelism is the number of projects that are loaded at the same time, e.g. by
+	// running terraform plan. Defaults to the number of CPUs, up to a maximum of 16.
+	Parallelism int `yaml:"parallelism,omitempty" envconfig:"INFRACOST_PARALLELISM"`
+
+	Projects      []*Project   `yaml:"projects" ignored:"true"`
+	Policies      []*Policy    `yaml:"policies,omitempty" ignored:"true"`
+	TagPolicies   []*TagPolicy `yaml:"tag_policies,omitempty" ignored:"true"`
+	Format        string       `yaml:"format,omitempty" ignored:"true"`
+	ShowSkipped   bool         `yaml:"show_skipped,omitempty" ignored:"true"`
+	SyncUsageFile bool         `yaml:"sync_usage_file,omitempty" ignored:"true"`
+	Fields        []string     `yaml:"fields,omitempty" ignored:"true"`
+	GroupBy       string       `yaml:"group_by,omitempty" ignored:"true"`
+	// UsageProfile is the name of the profile in the usage files to use, or
+	// AllUsageProfiles to compare the costs of all the profiles.
+	UsageProfile string `yaml:"usage_profile,omitempty" ignored:"true"`
 
 	NoCache bool `yaml:"fields,omitempty" ignored:"true"`
 
+	// FailOnBudgetBreach returns an error if any project is over its monthly budget.
+	FailOnBudgetBreach bool `yaml:"fail_on_budget_breach,omitempty" envconfig:"INFRACOST_FAIL_ON_BUDGET_BREACH"`
+
 	// for testing
 	EventsDisabled       bool
 	LogWriter            io.Writer
@@ -86,11 +188,17 @@ func DefaultConfig() *Config {
 		PricingAPIEndpoint:        "",
 		DashboardAPIEndpoint:      "https://dashboard.api.infracost.io",
 
+		APITimeout:    60 * time.Second,
+		APIMaxRetries: 3,
+
 		Projects: []*Project{{}},
 
 		Format: "table",
 		Fields: []string{"monthlyQuantity", "unit", "monthlyCost"},
 
+		NoPriceCache:  IsTest(),
+		PriceCacheTTL: 24 * time.Hour,
+
 		EventsDisabled: IsTest(),
 	}
 }
@@ -101,7 +209,17 @@ func (c *Config) LoadFromConfigFile(path string) error {
 		return err
 	}
 
+	if len(cfgFile.Projects) == 0 {
+		return &YamlError{raw: ErrorNilProjects}
+	}
+
 	c.Projects = cfgFile.Projects
+	c.Policies = cfgFile.Policies
+	c.TagPolicies = cfgFile.TagPolicies
+
+	if cfgFile.Parallelism > 0 {
+		c.Parallelism = cfgFile.Parallelism
+	}
 
 	// Reload the environment to overwrite any of the config file configs
 	err = c.LoadFromEnv()
@@ -112,6 +230,21 @@ func (c *Config) LoadFromConfigFile(path string) error {
 	return nil
 }
 
+// LoadPoliciesFromConfigFile loads the cost and tag policies from the config
+// file, which doesn't need any projects since they're only used to check
+// existing Infracost JSON files.
+func (c *Config) LoadPoliciesFromConfigFile(path string) error {
+	cfgFile, err := loadConfigFile(path)
+	if err != nil {
+		return err
+	}
+
+	c.Policies = cfgFile.Policies
+	c.TagPolicies = cfgFile.TagPolicies
+
+	return nil
+}
+
 func (c *Config) LoadFromEnv() error {
 	err := c.loadEnvVars()
 	if err != nil {
@@ -193,6 +326,52 @@ func (c *Config) IsLogging() bool {
 	return c.LogLevel != ""
 }
 
+// ParallelismFor returns how many of count items, e.g. projects, to load at the
+// same time for the configured parallelism n. When n isn't set it defaults to the
+// number of CPUs up to maxDefaultParallelism. It is never more than count.
+func ParallelismFor(n int, count int) int {
+	if n <= 0 {
+		n = runtime.NumCPU()
+		if n > maxDefaultParallelism {
+			n = maxDefaultParallelism
+		}
+	}
+
+	if n > count {
+		n = count
+	}
+
+	if n < 1 {
+		n = 1
+	}
+
+	return n
+}
+
+// GroupProjectsByPath returns the indexes of the projects grouped by their path,
+// in the order the paths first appear. Projects with the same path, e.g. for
+// different workspaces, share the .terraform directory so they can't be loaded
+// at the same time.
+func GroupProjectsByPath(projects []*Project) [][]int {
+	groups := make([][]int, 0, len(projects))
+	groupIndexes := make(map[string]int, len(projects))
+
+	for i, project := range projects {
+		path := filepath.Clean(project.Path)
+
+		g, ok := groupIndexes[path]
+		if !ok {
+			g = len(groups)
+			groupIndexes[path] = g
+			groups = append(groups, nil)
+		}
+
+		groups[g] = append(groups[g], i)
+	}
+
+	return groups
+}
+
 func IsTest() bool {
 	return os.Getenv("INFRACOST_ENV") == "test" || strings.HasSuffix(os.Args[0], ".test")
 }
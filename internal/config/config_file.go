@@ -109,8 +109,11 @@ func (y *YamlError) Error() string {
 }
 
 type fileSpec struct {
-	Version  string     `yaml:"version"`
-	Projects []*Project `yaml:"projects" ignored:"true"`
+	Version     string       `yaml:"version"`
+	Parallelism int          `yaml:"parallelism,omitempty"`
+	Projects    []*Project   `yaml:"projects" ignored:"true"`
+	Policies    []*Policy    `yaml:"policies" ignored:"true"`
+	TagPolicies []*TagPolicy `yaml:"tag_policies" ignored:"true"`
 }
 
 // UnmarshalYAML implements the yaml.v2.Unmarshaller interface. Marshalls the
@@ -119,8 +122,11 @@ type fileSpec struct {
 // type so that we don't run into error collisions with the base yaml.v2 errors.
 func (f *fileSpec) UnmarshalYAML(unmarshal func(interface{}) error) error {
 	type roughFile struct {
-		Version  string                   `yaml:"version"`
-		Projects []map[string]interface{} `yaml:"projects"`
+		Version     string                   `yaml:"version"`
+		Parallelism interface{}              `yaml:"parallelism"`
+		Projects    []map[string]interface{} `yaml:"projects"`
+		Policies    []map[string]interface{} `yaml:"policies"`
+		TagPolicies []map[string]interface{} `yaml:"tag_policies"`
 	}
 
 	var r roughFile
@@ -129,20 +135,7 @@ func (f *fileSpec) UnmarshalYAML(unmarshal func(interface{}) error) error {
 		return &YamlError{raw: ErrorInvalidConfigFile}
 	}
 
-	p := Project{}
-	v := reflect.TypeOf(p)
-	numFields := v.NumField()
-	allowedKeys := make(map[string]struct{}, numFields)
-
-	for i := 0; i < numFields; i++ {
-		tag := v.Field(i).Tag.Get("yaml")
-		pieces := strings.Split(tag, ",")
-		allowedKeys[strings.TrimSpace(pieces[0])] = struct{}{}
-	}
-
-	if len(r.Projects) == 0 {
-		return &YamlError{raw: ErrorNilProjects}
-	}
+	allowedKeys := yamlKeys(Project{})
 
 	validationError := &YamlError{
 		base: "config file is invalid, see https://infracost.io/config-file for valid options",
@@ -175,11 +168,111 @@ func (f *fileSpec) UnmarshalYAML(unmarshal func(interface{}) error) error {
 			projectError.add(fmt.Errorf("%s is not a valid project configuration option", k))
 		}
 
+		if v, ok := fields["terragrunt_parallelism"]; ok {
+			if p, ok := v.(int); !ok || p < 1 {
+				projectError.add(fmt.Errorf("terragrunt_parallelism must be a number greater than 0"))
+			}
+		}
+
+		if _, ok := fields["budget_alert_threshold"]; ok {
+			if _, ok := fields["monthly_budget"]; !ok {
+				projectError.add(fmt.Errorf("budget_alert_threshold requires monthly_budget to be set"))
+			}
+		}
+
 		if projectError.isValid() {
 			validationError.add(projectError)
 		}
 	}
 
+	if r.Parallelism != nil {
+		if p, ok := r.Parallelism.(int); !ok || p < 1 {
+			validationError.add(fmt.Errorf("parallelism must be a number greater than 0"))
+		}
+	}
+
+	allowedPolicyKeys := yamlKeys(Policy{})
+
+	for i, fields := range r.Policies {
+		name, _ := fields["name"].(string)
+		if name == "" {
+			validationError.add(&YamlError{
+				base:   fmt.Sprintf("policy config at index %d was invalid", i),
+				errors: []error{fmt.Errorf("policy must have a name")},
+			})
+			continue
+		}
+
+		policyError := &YamlError{
+			base: fmt.Sprintf("policy config defined for name: [%s] is invalid", name),
+		}
+
+		sorted := make([]string, 0, len(fields))
+		for k := range fields {
+			sorted = append(sorted, k)
+		}
+		sort.Strings(sorted)
+
+		hasCheck := false
+		for _, k := range sorted {
+			if _, ok := allowedPolicyKeys[k]; !ok {
+				policyError.add(fmt.Errorf("%s is not a valid policy configuration option", k))
+				continue
+			}
+
+			if k != "name" && k != "resource_types" {
+				hasCheck = true
+			}
+		}
+
+		if !hasCheck {
+			policyError.add(fmt.Errorf("policy must set at least one limit, e.g. max_resource_monthly_cost"))
+		}
+
+		if policyError.isValid() {
+			validationError.add(policyError)
+		}
+	}
+
+	allowedTagPolicyKeys := yamlKeys(TagPolicy{})
+
+	for i, fields := range r.TagPolicies {
+		name, _ := fields["name"].(string)
+		if name == "" {
+			validationError.add(&YamlError{
+				base:   fmt.Sprintf("tag policy config at index %d was invalid", i),
+				errors: []error{fmt.Errorf("tag policy must have a name")},
+			})
+			continue
+		}
+
+		policyError := &YamlError{
+			base: fmt.Sprintf("tag policy config defined for name: [%s] is invalid", name),
+		}
+
+		sorted := make([]string, 0, len(fields))
+		for k := range fields {
+			sorted = append(sorted, k)
+		}
+		sort.Strings(sorted)
+
+		for _, k := range sorted {
+			if _, ok := allowedTagPolicyKeys[k]; !ok {
+				policyError.add(fmt.Errorf("%s is not a valid tag policy configuration option", k))
+			}
+		}
+
+		_, hasRequiredKeys := fields["required_keys"]
+		_, hasDisallowedValues := fields["disallowed_values"]
+		if !hasRequiredKeys && !hasDisallowedValues {
+			policyError.add(fmt.Errorf("tag policy must set required_keys or disallowed_values"))
+		}
+
+		if policyError.isValid() {
+			validationError.add(policyError)
+		}
+	}
+
 	if validationError.isValid() {
 		return validationError
 	}
@@ -202,10 +295,29 @@ func (f *fileSpec) UnmarshalYAML(unmarshal func(interface{}) error) error {
 	}
 
 	f.Version = c.Version
+	f.Parallelism = c.Parallelism
 	f.Projects = c.Projects
+	f.Policies = c.Policies
+	f.TagPolicies = c.TagPolicies
 	return nil
 }
 
+// yamlKeys returns the yaml keys of the struct fields so they can be used to
+// check for invalid keys in the config file.
+func yamlKeys(v interface{}) map[string]struct{} {
+	t := reflect.TypeOf(v)
+	numFields := t.NumField()
+	keys := make(map[string]struct{}, numFields)
+
+	for i := 0; i < numFields; i++ {
+		tag := t.Field(i).Tag.Get("yaml")
+		pieces := strings.Split(tag, ",")
+		keys[strings.TrimSpace(pieces[0])] = struct{}{}
+	}
+
+	return keys
+}
+
 func loadConfigFile(path string) (fileSpec, error) {
 	var cfgFile fileSpec
 
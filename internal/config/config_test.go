@@ -116,3 +116,396 @@ projects:
 		})
 	}
 }
+
+func TestConfigLoadPoliciesFromConfigFile(t *testing.T) {
+	tmp := t.TempDir()
+	maxDiff := 500.0
+	maxResourceCost := 2000.0
+
+	tests := []struct {
+		name     string
+		contents []byte
+		expected []*Policy
+		error    error
+	}{
+		{
+			name: "should parse valid policies",
+			contents: []byte(`version: 0.1
+
+projects:
+  - path: path/to/my_terraform
+
+policies:
+  - name: Monthly diff under 500 USD
+    max_project_monthly_diff: 500
+  - name: No expensive resources or gp2 volumes
+    max_resource_monthly_cost: 2000
+    disallowed_cost_components: ["gp2"]
+    resource_types:
+      - aws_instance
+      - aws_ebs_volume
+`),
+			expected: []*Policy{
+				{
+					Name:                  "Monthly diff under 500 USD",
+					MaxProjectMonthlyDiff: &maxDiff,
+				},
+				{
+					Name:                     "No expensive resources or gp2 volumes",
+					MaxResourceMonthlyCost:   &maxResourceCost,
+					DisallowedCostComponents: []string{"gp2"},
+					ResourceTypes:            []string{"aws_instance", "aws_ebs_volume"},
+				},
+			},
+		},
+		{
+			name: "should error invalid policies given",
+			contents: []byte(`version: 0.1
+
+projects:
+  - path: path/to/my_terraform
+
+policies:
+  - max_project_monthly_cost: 100
+  - name: No limits
+    resource_types: ["aws_instance"]
+  - name: Invalid key
+    max_resource_monthly_cost: 100
+    invalid_key: "test"
+`),
+			error: &YamlError{
+				base: "config file is invalid, see https://infracost.io/config-file for valid options",
+				errors: []error{
+					&YamlError{
+						base:   "policy config at index 0 was invalid",
+						errors: []error{errors.New("policy must have a name")},
+					},
+					&YamlError{
+						base:   "policy config defined for name: [No limits] is invalid",
+						errors: []error{errors.New("policy must set at least one limit, e.g. max_resource_monthly_cost")},
+					},
+					&YamlError{
+						base:   "policy config defined for name: [Invalid key] is invalid",
+						errors: []error{errors.New("invalid_key is not a valid policy configuration option")},
+					},
+				},
+			},
+		},
+	}
+
+	for i, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := Config{}
+			path := filepath.Join(tmp, fmt.Sprintf("conf-%d.yaml", i))
+			err := os.WriteFile(path, tt.contents, os.ModePerm)
+			require.NoError(t, err)
+
+			err = c.LoadFromConfigFile(path)
+
+			require.Equal(t, tt.error, err)
+			require.EqualValues(t, tt.expected, c.Policies)
+		})
+	}
+}
+
+func TestConfigLoadPoliciesFromConfigFileWithoutProjects(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "conf.yaml")
+	err := os.WriteFile(path, []byte(`version: 0.1
+
+policies:
+  - name: Monthly diff under 500 USD
+    max_project_monthly_diff: 500
+tag_policies:
+  - name: Team tag
+    required_keys: ["team"]
+`), os.ModePerm)
+	require.NoError(t, err)
+
+	c := Config{}
+	err = c.LoadPoliciesFromConfigFile(path)
+	require.NoError(t, err)
+	require.Len(t, c.Policies, 1)
+	require.Len(t, c.TagPolicies, 1)
+	require.Empty(t, c.Projects)
+
+	err = c.LoadFromConfigFile(path)
+	require.Equal(t, &YamlError{raw: ErrorNilProjects}, err)
+}
+
+func TestConfigLoadBudgetsFromConfigFile(t *testing.T) {
+	tmp := t.TempDir()
+	budget := 1000.0
+	threshold := 80.0
+
+	tests := []struct {
+		name     string
+		contents []byte
+		expected []*Project
+		error    error
+	}{
+		{
+			name: "should parse project budgets",
+			contents: []byte(`version: 0.1
+
+projects:
+  - path: path/to/my_terraform
+    monthly_budget: 1000
+    budget_alert_threshold: 80
+  - path: path/to/my_terraform_two
+`),
+			expected: []*Project{
+				{
+					Path:                 "path/to/my_terraform",
+					MonthlyBudget:        &budget,
+					BudgetAlertThreshold: &threshold,
+				},
+				{
+					Path: "path/to/my_terraform_two",
+				},
+			},
+		},
+		{
+			name: "should error alert threshold without budget",
+			contents: []byte(`version: 0.1
+
+projects:
+  - path: path/to/my_terraform
+    budget_alert_threshold: 80
+`),
+			error: &YamlError{
+				base: "config file is invalid, see https://infracost.io/config-file for valid options",
+				errors: []error{
+					&YamlError{
+						base:   "project config defined for path: [path/to/my_terraform] is invalid",
+						errors: []error{errors.New("budget_alert_threshold requires monthly_budget to be set")},
+					},
+				},
+			},
+		},
+	}
+
+	for i, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := Config{}
+			path := filepath.Join(tmp, fmt.Sprintf("conf-%d.yaml", i))
+			err := os.WriteFile(path, tt.contents, os.ModePerm)
+			require.NoError(t, err)
+
+			err = c.LoadFromConfigFile(path)
+
+			require.Equal(t, tt.error, err)
+			require.EqualValues(t, tt.expected, c.Projects)
+		})
+	}
+}
+
+func TestConfigLoadTagPoliciesFromConfigFile(t *testing.T) {
+	tmp := t.TempDir()
+
+	tests := []struct {
+		name     string
+		contents []byte
+		expected []*TagPolicy
+		error    error
+	}{
+		{
+			name: "should parse tag policies",
+			contents: []byte(`version: 0.1
+
+projects:
+  - path: path/to/my_terraform
+
+tag_policies:
+  - name: Cost allocation tags
+    required_keys: ["Environment", "Team"]
+    enforce: true
+  - name: No test resources
+    disallowed_values:
+      Environment: ["test"]
+    resource_types: ["aws_instance"]
+`),
+			expected: []*TagPolicy{
+				{
+					Name:         "Cost allocation tags",
+					RequiredKeys: []string{"Environment", "Team"},
+					Enforce:      true,
+				},
+				{
+					Name:             "No test resources",
+					DisallowedValues: map[string][]string{"Environment": {"test"}},
+					ResourceTypes:    []string{"aws_instance"},
+				},
+			},
+		},
+		{
+			name: "should error invalid tag policies",
+			contents: []byte(`version: 0.1
+
+projects:
+  - path: path/to/my_terraform
+
+tag_policies:
+  - required_keys: ["Team"]
+  - name: Cost allocation tags
+    enforce: true
+    required_tags: ["Team"]
+`),
+			error: &YamlError{
+				base: "config file is invalid, see https://infracost.io/config-file for valid options",
+				errors: []error{
+					&YamlError{
+						base:   "tag policy config at index 0 was invalid",
+						errors: []error{errors.New("tag policy must have a name")},
+					},
+					&YamlError{
+						base: "tag policy config defined for name: [Cost allocation tags] is invalid",
+						errors: []error{
+							errors.New("required_tags is not a valid tag policy configuration option"),
+							errors.New("tag policy must set required_keys or disallowed_values"),
+						},
+					},
+				},
+			},
+		},
+	}
+
+	for i, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := Config{}
+			path := filepath.Join(tmp, fmt.Sprintf("conf-%d.yaml", i))
+			err := os.WriteFile(path, tt.contents, os.ModePerm)
+			require.NoError(t, err)
+
+			err = c.LoadFromConfigFile(path)
+
+			require.Equal(t, tt.error, err)
+			require.EqualValues(t, tt.expected, c.TagPolicies)
+		})
+	}
+}
+
+func TestConfigLoadParallelismFromConfigFile(t *testing.T) {
+	tmp := t.TempDir()
+
+	tests := []struct {
+		name     string
+		contents []byte
+		expected int
+		error    error
+	}{
+		{
+			name: "should parse parallelism",
+			contents: []byte(`version: 0.1
+parallelism: 4
+
+projects:
+  - path: path/to/my_terraform
+`),
+			expected: 4,
+		},
+		{
+			name: "should error invalid parallelism",
+			contents: []byte(`version: 0.1
+parallelism: 0
+
+projects:
+  - path: path/to/my_terraform
+`),
+			error: &YamlError{
+				base: "config file is invalid, see https://infracost.io/config-file for valid options",
+				errors: []error{
+					errors.New("parallelism must be a number greater than 0"),
+				},
+			},
+		},
+	}
+
+	for i, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := Config{}
+			path := filepath.Join(tmp, fmt.Sprintf("conf-%d.yaml", i))
+			err := os.WriteFile(path, tt.contents, os.ModePerm)
+			require.NoError(t, err)
+
+			err = c.LoadFromConfigFile(path)
+
+			require.Equal(t, tt.error, err)
+			require.Equal(t, tt.expected, c.Parallelism)
+		})
+	}
+}
+
+func TestConfigLoadTerragruntParallelismFromConfigFile(t *testing.T) {
+	tmp := t.TempDir()
+
+	tests := []struct {
+		name     string
+		contents []byte
+		expected int
+		error    error
+	}{
+		{
+			name: "should parse terragrunt parallelism",
+			contents: []byte(`version: 0.1
+
+projects:
+  - path: path/to/my_terragrunt
+    terragrunt_parallelism: 2
+`),
+			expected: 2,
+		},
+		{
+			name: "should error invalid terragrunt parallelism",
+			contents: []byte(`version: 0.1
+
+projects:
+  - path: path/to/my_terragrunt
+    terragrunt_parallelism: all
+`),
+			error: &YamlError{
+				base: "config file is invalid, see https://infracost.io/config-file for valid options",
+				errors: []error{
+					&YamlError{
+						base:   "project config defined for path: [path/to/my_terragrunt] is invalid",
+						errors: []error{errors.New("terragrunt_parallelism must be a number greater than 0")},
+					},
+				},
+			},
+		},
+	}
+
+	for i, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := Config{}
+			path := filepath.Join(tmp, fmt.Sprintf("conf-%d.yaml", i))
+			err := os.WriteFile(path, tt.contents, os.ModePerm)
+			require.NoError(t, err)
+
+			err = c.LoadFromConfigFile(path)
+			require.Equal(t, tt.error, err)
+
+			if tt.error == nil {
+				require.Equal(t, tt.expected, c.Projects[0].TerragruntParallelism)
+			}
+		})
+	}
+}
+
+func TestParallelismFor(t *testing.T) {
+	require.Equal(t, 4, ParallelismFor(4, 10))
+	require.Equal(t, 2, ParallelismFor(4, 2))
+	require.Equal(t, 1, ParallelismFor(4, 0))
+	require.LessOrEqual(t, ParallelismFor(0, 100), maxDefaultParallelism)
+}
+
+func TestGroupProjectsByPath(t *testing.T) {
+	projects := []*Project{
+		{Path: "infra/app", TerraformWorkspace: "dev"},
+		{Path: "infra/db"},
+		{Path: "./infra/app/", TerraformWorkspace: "prod"},
+		{Path: "infra/app", TerraformPlanFlags: "-var-file=staging.tfvars"},
+	}
+
+	require.Equal(t, [][]int{{0, 2, 3}, {1}}, GroupProjectsByPath(projects))
+	require.Equal(t, [][]int{}, GroupProjectsByPath(nil))
+}
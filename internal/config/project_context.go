@@ -5,6 +5,7 @@ import (
 	"os/exec"
 	"path/filepath"
 	"strings"
+	"sync"
 
 	"github.com/infracost/infracost/internal/schema"
 	log "github.com/sirupsen/logrus"
@@ -14,9 +15,16 @@ type ProjectContext struct {
 	RunContext    *RunContext
 	ProjectConfig *Project
 	contextVals   map[string]interface{}
+	// mu guards contextVals since providers can set them from several goroutines,
+	// e.g. when Terragrunt modules are planned at the same time.
+	mu sync.Mutex
 
 	UsingCache bool
 	CacheErr   string
+
+	// LogPrefix is added to the spinner messages of the project when projects are
+	// loaded in parallel, so the messages of each project can be told apart.
+	LogPrefix string
 }
 
 func NewProjectContext(runCtx *RunContext, projectCfg *Project) *ProjectContext {
@@ -36,11 +44,32 @@ func EmptyProjectContext() *ProjectContext {
 }
 
 func (c *ProjectContext) SetContextValue(key string, value interface{}) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
 	c.contextVals[key] = value
 }
 
+// ContextValues returns a copy of the context values, so they can be read while
+// they're still being set by other goroutines.
 func (c *ProjectContext) ContextValues() map[string]interface{} {
-	return c.contextVals
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	vals := make(map[string]interface{}, len(c.contextVals))
+	for k, v := range c.contextVals {
+		vals[k] = v
+	}
+
+	return vals
+}
+
+// SetContextValuesFrom sets the context values of the project from d, e.g. the
+// results of syncing the usage file.
+func (c *ProjectContext) SetContextValuesFrom(d ProjectContexter) {
+	for k, v := range d.ProjectContext() {
+		c.SetContextValue(k, v)
+	}
 }
 
 func DetectProjectMetadata(path string) *schema.ProjectMetadata {
@@ -0,0 +1,41 @@
+package config
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestProjectContextValuesConcurrent(t *testing.T) {
+	ctx := EmptyProjectContext()
+
+	var wg sync.WaitGroup
+	for w := 0; w < 4; w++ {
+		wg.Add(1)
+
+		go func(w int) {
+			defer wg.Done()
+
+			for i := 0; i < 100; i++ {
+				ctx.SetContextValue(fmt.Sprintf("key%d-%d", w, i), i)
+			}
+		}(w)
+	}
+
+	for i := 0; i < 100; i++ {
+		for k, v := range ctx.ContextValues() {
+			require.NotNil(t, v, k)
+		}
+	}
+
+	wg.Wait()
+
+	vals := ctx.ContextValues()
+	require.Len(t, vals, 400)
+
+	// The returned values are a copy
+	vals["other"] = true
+	require.NotContains(t, ctx.ContextValues(), "other")
+}
@@ -99,11 +99,6 @@ func (c *RunContext) SetCurrentProjectContext(ctx *ProjectContext) {
 	c.currentProjectCtx = ctx
 }
 
-// setProjectContextValue Set context value into currentProjectContext
-func (c *RunContext) setProjectContextValue(key string, value interface{}) {
-	c.currentProjectCtx.SetContextValue(key, value)
-}
-
 func (c *RunContext) loadInitialContextValues() {
 	c.SetContextValue("version", baseVersion(version.Version))
 	c.SetContextValue("fullVersion", version.Version)
@@ -124,13 +119,6 @@ type ProjectContexter interface {
 	ProjectContext() map[string]interface{}
 }
 
-func (c *RunContext) SetProjectContextFrom(d ProjectContexter) {
-	m := d.ProjectContext()
-	for k, v := range m {
-		c.setProjectContextValue(k, v)
-	}
-}
-
 func baseVersion(v string) string {
 	return strings.SplitN(v, "+", 2)[0]
 }
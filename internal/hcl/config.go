@@ -0,0 +1,397 @@
+package hcl
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/hcl2/ext/typeexpr"
+	"github.com/hashicorp/hcl2/hcl"
+	"github.com/hashicorp/hcl2/hclparse"
+	"github.com/hashicorp/terraform-config-inspect/tfconfig"
+	"github.com/zclconf/go-cty/cty"
+)
+
+var fileSchema = &hcl.BodySchema{
+	Blocks: []hcl.BlockHeaderSchema{
+		{Type: "terraform"},
+		{Type: "variable", LabelNames: []string{"name"}},
+		{Type: "locals"},
+		{Type: "output", LabelNames: []string{"name"}},
+		{Type: "provider", LabelNames: []string{"name"}},
+		{Type: "resource", LabelNames: []string{"type", "name"}},
+		{Type: "data", LabelNames: []string{"type", "name"}},
+		{Type: "module", LabelNames: []string{"name"}},
+	},
+}
+
+var variableSchema = &hcl.BodySchema{
+	Attributes: []hcl.AttributeSchema{
+		{Name: "default"},
+		{Name: "type"},
+	},
+}
+
+var outputSchema = &hcl.BodySchema{
+	Attributes: []hcl.AttributeSchema{
+		{Name: "value"},
+	},
+}
+
+var providerSchema = &hcl.BodySchema{
+	Attributes: []hcl.AttributeSchema{
+		{Name: "alias"},
+	},
+}
+
+var resourceSchema = &hcl.BodySchema{
+	Attributes: []hcl.AttributeSchema{
+		{Name: "count"},
+		{Name: "for_each"},
+		{Name: "provider"},
+	},
+}
+
+var moduleSchema = &hcl.BodySchema{
+	Attributes: []hcl.AttributeSchema{
+		{Name: "source", Required: true},
+		{Name: "version"},
+		{Name: "count"},
+		{Name: "for_each"},
+		{Name: "providers"},
+		{Name: "depends_on"},
+	},
+}
+
+// resourceMetaArguments are the attributes and blocks of resources that configure
+// how Terraform manages the resource, rather than the resource itself.
+var resourceMetaArguments = map[string]bool{
+	"count":       true,
+	"for_each":    true,
+	"provider":    true,
+	"depends_on":  true,
+	"lifecycle":   true,
+	"provisioner": true,
+	"connection":  true,
+}
+
+// moduleConfig is the configuration in the .tf files of a module directory.
+type moduleConfig struct {
+	dir               string
+	variables         []*variable
+	locals            []*hcl.Attribute
+	outputs           []*hcl.Attribute
+	providers         []*providerConfig
+	resources         []*resource
+	moduleCalls       []*moduleCall
+	requiredProviders map[string]string
+}
+
+type variable struct {
+	name         string
+	typ          cty.Type
+	defaultValue cty.Value
+	hasDefault   bool
+}
+
+type providerConfig struct {
+	name  string
+	alias string
+	body  hcl.Body
+}
+
+// key returns the key of the provider configuration, e.g. aws or aws.west for an aliased provider.
+func (p *providerConfig) key() string {
+	if p.alias == "" {
+		return p.name
+	}
+
+	return fmt.Sprintf("%s.%s", p.name, p.alias)
+}
+
+type resource struct {
+	mode     string
+	typ      string
+	name     string
+	provider string
+	count    hcl.Expression
+	forEach  hcl.Expression
+	body     hcl.Body
+}
+
+// address returns the address of the resource in its module, e.g. aws_instance.web or data.aws_ami.ubuntu.
+func (r *resource) address() string {
+	if r.mode == dataMode {
+		return fmt.Sprintf("data.%s.%s", r.typ, r.name)
+	}
+
+	return fmt.Sprintf("%s.%s", r.typ, r.name)
+}
+
+type moduleCall struct {
+	name      string
+	source    string
+	count     hcl.Expression
+	forEach   hcl.Expression
+	providers map[string]string
+	inputs    hcl.Attributes
+}
+
+// loadModuleConfig parses the .tf and .tf.json files in the directory. Blocks that can't
+// be parsed are skipped and returned as diagnostics so that the rest of the module can
+// still be evaluated.
+func loadModuleConfig(dir string) (*moduleConfig, hcl.Diagnostics) {
+	var diags hcl.Diagnostics
+
+	files, err := moduleFiles(dir)
+	if err != nil {
+		return nil, diags.Append(&hcl.Diagnostic{
+			Severity: hcl.DiagError,
+			Summary:  "Failed to read module directory",
+			Detail:   fmt.Sprintf("Module directory %s could not be read: %s.", dir, err),
+		})
+	}
+
+	c := &moduleConfig{
+		dir:               dir,
+		requiredProviders: requiredProviders(dir),
+	}
+
+	parser := hclparse.NewParser()
+
+	for _, filename := range files {
+		var f *hcl.File
+		var fileDiags hcl.Diagnostics
+
+		if strings.HasSuffix(filename, ".json") {
+			f, fileDiags = parser.ParseJSONFile(filename)
+		} else {
+			f, fileDiags = parser.ParseHCLFile(filename)
+		}
+		diags = append(diags, fileDiags...)
+		if f == nil {
+			continue
+		}
+
+		content, _, contentDiags := f.Body.PartialContent(fileSchema)
+		diags = append(diags, contentDiags...)
+
+		for _, block := range content.Blocks {
+			diags = append(diags, c.addBlock(block)...)
+		}
+	}
+
+	sort.Slice(c.locals, func(i, j int) bool {
+		return c.locals[i].Name < c.locals[j].Name
+	})
+	sort.Slice(c.outputs, func(i, j int) bool {
+		return c.outputs[i].Name < c.outputs[j].Name
+	})
+
+	return c, diags
+}
+
+func (c *moduleConfig) addBlock(block *hcl.Block) hcl.Diagnostics {
+	switch block.Type {
+	case "variable":
+		v, diags := decodeVariable(block)
+		if v != nil {
+			c.variables = append(c.variables, v)
+		}
+		return diags
+	case "locals":
+		attrs, diags := block.Body.JustAttributes()
+		for _, attr := range attrs {
+			c.locals = append(c.locals, attr)
+		}
+		return diags
+	case "output":
+		content, _, diags := block.Body.PartialContent(outputSchema)
+		if attr, ok := content.Attributes["value"]; ok {
+			c.outputs = append(c.outputs, &hcl.Attribute{Name: block.Labels[0], Expr: attr.Expr, Range: attr.Range})
+		}
+		return diags
+	case "provider":
+		content, _, diags := block.Body.PartialContent(providerSchema)
+		p := &providerConfig{name: block.Labels[0], body: block.Body}
+		if attr, ok := content.Attributes["alias"]; ok {
+			p.alias = hcl.ExprAsKeyword(attr.Expr)
+			if p.alias == "" {
+				v, _ := attr.Expr.Value(nil)
+				if v.Type().Equals(cty.String) && v.IsKnown() && !v.IsNull() {
+					p.alias = v.AsString()
+				}
+			}
+		}
+		c.providers = append(c.providers, p)
+		return diags
+	case "resource", "data":
+		r, diags := decodeResource(block)
+		c.resources = append(c.resources, r)
+		return diags
+	case "module":
+		m, diags := decodeModuleCall(block)
+		if m != nil {
+			c.moduleCalls = append(c.moduleCalls, m)
+		}
+		return diags
+	}
+
+	return nil
+}
+
+func decodeVariable(block *hcl.Block) (*variable, hcl.Diagnostics) {
+	content, _, diags := block.Body.PartialContent(variableSchema)
+
+	v := &variable{
+		name: block.Labels[0],
+		typ:  cty.DynamicPseudoType,
+	}
+
+	if attr, ok := content.Attributes["type"]; ok {
+		typ, typeDiags := typeexpr.TypeConstraint(attr.Expr)
+		diags = append(diags, typeDiags...)
+		if !typeDiags.HasErrors() {
+			v.typ = typ
+		}
+	}
+
+	if attr, ok := content.Attributes["default"]; ok {
+		val, valDiags := attr.Expr.Value(nil)
+		diags = append(diags, valDiags...)
+		if !valDiags.HasErrors() {
+			v.defaultValue = val
+			v.hasDefault = true
+		}
+	}
+
+	return v, diags
+}
+
+func decodeResource(block *hcl.Block) (*resource, hcl.Diagnostics) {
+	content, _, diags := block.Body.PartialContent(resourceSchema)
+
+	r := &resource{
+		mode: managedMode,
+		typ:  block.Labels[0],
+		name: block.Labels[1],
+		body: block.Body,
+	}
+
+	if block.Type == "data" {
+		r.mode = dataMode
+	}
+
+	if attr, ok := content.Attributes["count"]; ok {
+		r.count = attr.Expr
+	}
+
+	if attr, ok := content.Attributes["for_each"]; ok {
+		r.forEach = attr.Expr
+	}
+
+	if attr, ok := content.Attributes["provider"]; ok {
+		t, tDiags := hcl.AbsTraversalForExpr(attr.Expr)
+		diags = append(diags, tDiags...)
+		if !tDiags.HasErrors() {
+			r.provider = formatTraversal(t)
+		}
+	}
+
+	return r, diags
+}
+
+func decodeModuleCall(block *hcl.Block) (*moduleCall, hcl.Diagnostics) {
+	content, remain, diags := block.Body.PartialContent(moduleSchema)
+	if diags.HasErrors() {
+		return nil, diags
+	}
+
+	m := &moduleCall{
+		name:      block.Labels[0],
+		providers: make(map[string]string),
+	}
+
+	source, sourceDiags := content.Attributes["source"].Expr.Value(nil)
+	diags = append(diags, sourceDiags...)
+	if sourceDiags.HasErrors() || !source.Type().Equals(cty.String) || source.IsNull() {
+		return nil, diags
+	}
+	m.source = source.AsString()
+
+	if attr, ok := content.Attributes["count"]; ok {
+		m.count = attr.Expr
+	}
+
+	if attr, ok := content.Attributes["for_each"]; ok {
+		m.forEach = attr.Expr
+	}
+
+	if attr, ok := content.Attributes["providers"]; ok {
+		pairs, pairDiags := hcl.ExprMap(attr.Expr)
+		diags = append(diags, pairDiags...)
+
+		for _, pair := range pairs {
+			k, kDiags := hcl.AbsTraversalForExpr(pair.Key)
+			v, vDiags := hcl.AbsTraversalForExpr(pair.Value)
+			diags = append(diags, kDiags...)
+			diags = append(diags, vDiags...)
+
+			if !kDiags.HasErrors() && !vDiags.HasErrors() {
+				m.providers[formatTraversal(k)] = formatTraversal(v)
+			}
+		}
+	}
+
+	inputs, inputDiags := remain.JustAttributes()
+	diags = append(diags, inputDiags...)
+	m.inputs = inputs
+
+	return m, diags
+}
+
+// moduleFiles returns the paths of the Terraform files in the directory sorted by name,
+// which is the order that Terraform loads them in.
+func moduleFiles(dir string) ([]string, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	var files []string
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || strings.HasPrefix(name, ".") {
+			continue
+		}
+
+		if strings.HasSuffix(name, ".tf") || strings.HasSuffix(name, ".tf.json") {
+			files = append(files, filepath.Join(dir, name))
+		}
+	}
+
+	sort.Strings(files)
+
+	return files, nil
+}
+
+// requiredProviders returns the source address of the providers in the
+// required_providers block of the module, e.g. aws returns hashicorp/aws.
+func requiredProviders(dir string) map[string]string {
+	sources := make(map[string]string)
+
+	mod, _ := tfconfig.LoadModule(dir)
+	if mod == nil {
+		return sources
+	}
+
+	for name, p := range mod.RequiredProviders {
+		if p.Source != "" {
+			sources[name] = p.Source
+		}
+	}
+
+	return sources
+}
@@ -0,0 +1,676 @@
+package hcl
+
+import (
+	"fmt"
+	"path/filepath"
+	"sort"
+
+	"github.com/hashicorp/hcl2/hcl"
+	"github.com/hashicorp/hcl2/hcl/hclsyntax"
+	"github.com/zclconf/go-cty/cty"
+	"github.com/zclconf/go-cty/cty/convert"
+)
+
+const (
+	managedMode = "managed"
+	dataMode    = "data"
+
+	// maxPasses is the maximum number of times that a module is evaluated to resolve
+	// values that depend on each other, e.g. a local that uses a module output which
+	// uses another local.
+	maxPasses = 10
+)
+
+// module is an instance of a module, i.e. the root module or one instance of a module
+// call, and the values that have been evaluated for it so far.
+type module struct {
+	loader *loader
+	parent *module
+	config *moduleConfig
+
+	// address is the address of the module instance, e.g. module.app["web"].
+	// It is empty for the root module.
+	address string
+	// key is the key of the module call in the Terraform modules manifest, e.g. app.db.
+	key string
+	// providers maps the provider configurations that were passed to the module
+	// to the provider configurations in the parent module.
+	providers map[string]string
+
+	inputs    map[string]cty.Value
+	variables map[string]cty.Value
+	locals    map[string]cty.Value
+	outputs   map[string]cty.Value
+	resources map[string]map[string]cty.Value
+	data      map[string]map[string]cty.Value
+	modules   map[string]cty.Value
+
+	instances []*resourceInstance
+	children  []*module
+
+	warnings    []string
+	warningKeys map[string]bool
+}
+
+// resourceInstance is a single instance of a resource, e.g. one of the instances
+// created by count.
+type resourceInstance struct {
+	resource *resource
+	address  string
+	index    cty.Value
+	values   cty.Value
+}
+
+// instanceKey is the count index or for_each key of an instance of a resource or module.
+// The index is cty.NilVal when neither count nor for_each are set.
+type instanceKey struct {
+	index     cty.Value
+	eachValue cty.Value
+}
+
+func newModule(l *loader, parent *module, config *moduleConfig, address, key string) *module {
+	m := &module{
+		loader:    l,
+		parent:    parent,
+		config:    config,
+		address:   address,
+		key:       key,
+		providers: make(map[string]string),
+		inputs:    make(map[string]cty.Value),
+		variables: make(map[string]cty.Value),
+		locals:    make(map[string]cty.Value),
+		outputs:   make(map[string]cty.Value),
+		resources: make(map[string]map[string]cty.Value),
+		data:      make(map[string]map[string]cty.Value),
+		modules:   make(map[string]cty.Value),
+	}
+
+	for _, v := range config.variables {
+		m.variables[v.name] = cty.DynamicVal
+	}
+
+	for _, l := range config.locals {
+		m.locals[l.Name] = cty.DynamicVal
+	}
+
+	for _, o := range config.outputs {
+		m.outputs[o.Name] = cty.DynamicVal
+	}
+
+	for _, r := range config.resources {
+		values := m.resources
+		if r.mode == dataMode {
+			values = m.data
+		}
+
+		if _, ok := values[r.typ]; !ok {
+			values[r.typ] = make(map[string]cty.Value)
+		}
+		values[r.typ][r.name] = cty.DynamicVal
+	}
+
+	for _, c := range config.moduleCalls {
+		m.modules[c.name] = cty.DynamicVal
+	}
+
+	return m
+}
+
+// run evaluates the module until its values stop changing, since values can depend
+// on values that are evaluated later in a pass, e.g. a local that uses a module output.
+func (m *module) run() {
+	var prev cty.Value
+	for i := 0; i < maxPasses; i++ {
+		m.evaluate()
+
+		state := m.state()
+		if i > 0 && state.RawEquals(prev) {
+			return
+		}
+		prev = state
+	}
+}
+
+func (m *module) evaluate() {
+	m.warnings = nil
+	m.warningKeys = make(map[string]bool)
+
+	m.evaluateVariables()
+	m.evaluateLocals()
+	m.evaluateResources()
+	m.evaluateModuleCalls()
+	m.evaluateOutputs()
+}
+
+// state returns all the values of the module so they can be compared between passes.
+func (m *module) state() cty.Value {
+	return cty.ObjectVal(map[string]cty.Value{
+		"variables": cty.ObjectVal(m.variables),
+		"locals":    cty.ObjectVal(m.locals),
+		"resources": nestedObjectVal(m.resources),
+		"data":      nestedObjectVal(m.data),
+		"modules":   cty.ObjectVal(m.modules),
+		"outputs":   cty.ObjectVal(m.outputs),
+	})
+}
+
+func (m *module) evalContext() *hcl.EvalContext {
+	vars := map[string]cty.Value{
+		"var":    cty.ObjectVal(m.variables),
+		"local":  cty.ObjectVal(m.locals),
+		"module": cty.ObjectVal(m.modules),
+		"data":   nestedObjectVal(m.data),
+		"path": cty.ObjectVal(map[string]cty.Value{
+			"module": cty.StringVal(m.config.dir),
+			"root":   cty.StringVal(m.loader.rootDir),
+			"cwd":    cty.StringVal(m.loader.cwd),
+		}),
+		"terraform": cty.ObjectVal(map[string]cty.Value{
+			"workspace": cty.StringVal(m.loader.opts.Workspace),
+		}),
+	}
+
+	for t, resources := range m.resources {
+		vars[t] = cty.ObjectVal(resources)
+	}
+
+	return &hcl.EvalContext{
+		Variables: vars,
+		Functions: functions(m.config.dir),
+	}
+}
+
+func (m *module) evaluateVariables() {
+	for _, v := range m.config.variables {
+		val, ok := m.inputs[v.name]
+		if !ok {
+			if !v.hasDefault {
+				m.warnf("Input variable %s has no value, any values that use it are unknown", m.describe("var."+v.name))
+				m.variables[v.name] = cty.DynamicVal
+				continue
+			}
+			val = v.defaultValue
+		}
+
+		converted, err := convert.Convert(val, v.typ)
+		if err != nil {
+			m.warnf("Input variable %s is not a valid %s: %s", m.describe("var."+v.name), v.typ.FriendlyName(), err)
+			converted = val
+		}
+
+		m.variables[v.name] = converted
+	}
+}
+
+func (m *module) evaluateLocals() {
+	// Locals can use other locals so keep evaluating them until they stop changing
+	for i := 0; i <= len(m.config.locals); i++ {
+		changed := false
+		ctx := m.evalContext()
+
+		for _, l := range m.config.locals {
+			val := m.evalExpr(l.Expr, ctx, "local."+l.Name)
+			if !val.RawEquals(m.locals[l.Name]) {
+				m.locals[l.Name] = val
+				changed = true
+			}
+		}
+
+		if !changed {
+			return
+		}
+	}
+}
+
+func (m *module) evaluateResources() {
+	var instances []*resourceInstance
+
+	ctx := m.evalContext()
+
+	for _, r := range m.config.resources {
+		keys := m.instanceKeys(r.count, r.forEach, ctx, r.address())
+
+		resourceInstances := make([]*resourceInstance, 0, len(keys))
+		values := make([]cty.Value, 0, len(keys))
+
+		for _, key := range keys {
+			address := r.address() + formatIndex(key.index)
+			if m.address != "" {
+				address = fmt.Sprintf("%s.%s", m.address, address)
+			}
+
+			v := m.evalBody(r.body, key.context(ctx), r.address(), resourceMetaArguments)
+
+			resourceInstances = append(resourceInstances, &resourceInstance{
+				resource: r,
+				address:  address,
+				index:    key.index,
+				values:   v,
+			})
+			values = append(values, v)
+		}
+
+		instances = append(instances, resourceInstances...)
+
+		val := instancesVal(r.count, r.forEach, keys, values)
+		if r.mode == dataMode {
+			m.data[r.typ][r.name] = val
+		} else {
+			m.resources[r.typ][r.name] = val
+		}
+	}
+
+	m.instances = instances
+}
+
+func (m *module) evaluateModuleCalls() {
+	var children []*module
+
+	existing := make(map[string]*module, len(m.children))
+	for _, c := range m.children {
+		existing[c.address] = c
+	}
+
+	ctx := m.evalContext()
+
+	for _, call := range m.config.moduleCalls {
+		what := "module." + call.name
+
+		config, ok := m.loader.moduleConfig(m, call)
+		if !ok {
+			m.warnf("Module %s could not be found, run terraform init to download it. Resources in the module are not included", m.describe(what))
+			continue
+		}
+
+		keys := m.instanceKeys(call.count, call.forEach, ctx, what)
+		values := make([]cty.Value, 0, len(keys))
+
+		for _, key := range keys {
+			address := what + formatIndex(key.index)
+			if m.address != "" {
+				address = fmt.Sprintf("%s.%s", m.address, address)
+			}
+
+			child, ok := existing[address]
+			if !ok {
+				child = newModule(m.loader, m, config, address, m.childKey(call.name))
+			}
+
+			keyCtx := key.context(ctx)
+			for name, attr := range call.inputs {
+				child.inputs[name] = m.evalExpr(attr.Expr, keyCtx, fmt.Sprintf("%s.%s", what, name))
+			}
+			child.providers = call.providers
+
+			child.run()
+
+			children = append(children, child)
+			values = append(values, cty.ObjectVal(child.outputs))
+		}
+
+		m.modules[call.name] = instancesVal(call.count, call.forEach, keys, values)
+	}
+
+	m.children = children
+}
+
+func (m *module) evaluateOutputs() {
+	ctx := m.evalContext()
+
+	for _, o := range m.config.outputs {
+		m.outputs[o.Name] = m.evalExpr(o.Expr, ctx, "output."+o.Name)
+	}
+}
+
+// instanceKeys returns the keys of the instances that count or for_each create. If the
+// value is unknown, e.g. because it depends on a resource attribute that is computed
+// when the resource is created, a single instance is assumed.
+func (m *module) instanceKeys(count, forEach hcl.Expression, ctx *hcl.EvalContext, what string) []instanceKey {
+	if count != nil {
+		val := m.evalExpr(count, ctx, "count of "+what)
+
+		n, err := convert.Convert(val, cty.Number)
+		if err != nil || !n.IsKnown() || n.IsNull() {
+			m.warnf("Count of %s could not be evaluated, assuming 1 instance", m.describe(what))
+			return []instanceKey{{index: cty.NumberIntVal(0)}}
+		}
+
+		c, _ := n.AsBigFloat().Int64()
+		keys := make([]instanceKey, 0, c)
+		for i := int64(0); i < c; i++ {
+			keys = append(keys, instanceKey{index: cty.NumberIntVal(i)})
+		}
+
+		return keys
+	}
+
+	if forEach != nil {
+		val := m.evalExpr(forEach, ctx, "for_each of "+what)
+
+		if !val.IsWhollyKnown() || val.IsNull() || !(val.CanIterateElements()) {
+			m.warnf("For_each of %s could not be evaluated, assuming 1 instance", m.describe(what))
+			return []instanceKey{{index: cty.NilVal}}
+		}
+
+		isSet := val.Type().IsSetType()
+
+		keys := make([]instanceKey, 0, val.LengthInt())
+		for it := val.ElementIterator(); it.Next(); {
+			k, v := it.Element()
+			if isSet {
+				k = v
+			}
+
+			k, err := convert.Convert(k, cty.String)
+			if err != nil || k.IsNull() {
+				m.warnf("For_each of %s must be a map or a set of strings, assuming 1 instance", m.describe(what))
+				return []instanceKey{{index: cty.NilVal}}
+			}
+
+			keys = append(keys, instanceKey{index: k, eachValue: v})
+		}
+
+		return keys
+	}
+
+	return []instanceKey{{index: cty.NilVal}}
+}
+
+// context returns the evaluation context for an instance, which sets count.index or
+// each.key and each.value.
+func (k instanceKey) context(ctx *hcl.EvalContext) *hcl.EvalContext {
+	if k.index == cty.NilVal {
+		return ctx
+	}
+
+	child := ctx.NewChild()
+
+	if k.index.Type().Equals(cty.Number) {
+		child.Variables = map[string]cty.Value{
+			"count": cty.ObjectVal(map[string]cty.Value{"index": k.index}),
+		}
+	} else {
+		child.Variables = map[string]cty.Value{
+			"each": cty.ObjectVal(map[string]cty.Value{"key": k.index, "value": k.eachValue}),
+		}
+	}
+
+	return child
+}
+
+// evalBody evaluates the attributes and nested blocks of the body into an object.
+// Nested blocks are converted to lists of objects, the same as in the Terraform plan JSON.
+func (m *module) evalBody(body hcl.Body, ctx *hcl.EvalContext, what string, skip map[string]bool) cty.Value {
+	vals := make(map[string]cty.Value)
+
+	syntaxBody, ok := body.(*hclsyntax.Body)
+	if !ok {
+		// JSON bodies don't distinguish between attributes and blocks without a schema
+		attrs, _ := body.JustAttributes()
+		for name, attr := range attrs {
+			if skip[name] {
+				continue
+			}
+			vals[name] = m.evalExpr(attr.Expr, ctx, fmt.Sprintf("%s.%s", what, name))
+		}
+
+		return cty.ObjectVal(vals)
+	}
+
+	names := make([]string, 0, len(syntaxBody.Attributes))
+	for name := range syntaxBody.Attributes {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		if skip[name] {
+			continue
+		}
+		vals[name] = m.evalExpr(syntaxBody.Attributes[name].Expr, ctx, fmt.Sprintf("%s.%s", what, name))
+	}
+
+	blocks := make(map[string][]cty.Value)
+	for _, b := range syntaxBody.Blocks {
+		if skip[b.Type] {
+			continue
+		}
+
+		if b.Type == "dynamic" && len(b.Labels) == 1 {
+			blocks[b.Labels[0]] = append(blocks[b.Labels[0]], m.expandDynamicBlock(b, ctx, fmt.Sprintf("%s.%s", what, b.Labels[0]))...)
+			continue
+		}
+
+		blocks[b.Type] = append(blocks[b.Type], m.evalBody(b.Body, ctx, fmt.Sprintf("%s.%s", what, b.Type), nil))
+	}
+
+	for t, list := range blocks {
+		vals[t] = cty.TupleVal(list)
+	}
+
+	return cty.ObjectVal(vals)
+}
+
+// expandDynamicBlock returns the blocks that a dynamic block generates.
+func (m *module) expandDynamicBlock(b *hclsyntax.Block, ctx *hcl.EvalContext, what string) []cty.Value {
+	iterator := b.Labels[0]
+	if attr, ok := b.Body.Attributes["iterator"]; ok {
+		iterator = hcl.ExprAsKeyword(attr.Expr)
+	}
+
+	var content *hclsyntax.Body
+	for _, c := range b.Body.Blocks {
+		if c.Type == "content" {
+			content = c.Body
+		}
+	}
+
+	forEach, ok := b.Body.Attributes["for_each"]
+	if !ok || content == nil {
+		return nil
+	}
+
+	val := m.evalExpr(forEach.Expr, ctx, "for_each of "+what)
+	if !val.IsWhollyKnown() || val.IsNull() || !val.CanIterateElements() {
+		m.warnf("For_each of dynamic block %s could not be evaluated, the blocks are not included", m.describe(what))
+		return nil
+	}
+
+	var blocks []cty.Value
+	for it := val.ElementIterator(); it.Next(); {
+		k, v := it.Element()
+
+		child := ctx.NewChild()
+		child.Variables = map[string]cty.Value{
+			iterator: cty.ObjectVal(map[string]cty.Value{"key": k, "value": v}),
+		}
+
+		blocks = append(blocks, m.evalBody(content, child, what, nil))
+	}
+
+	return blocks
+}
+
+// evalExpr evaluates the expression. Errors are reported as warnings and the value
+// that could not be evaluated is unknown. Values that use attributes of resources that
+// are only known once the resource is created, e.g. IDs, are expected to be unknown
+// so aren't reported.
+func (m *module) evalExpr(expr hcl.Expression, ctx *hcl.EvalContext, what string) cty.Value {
+	val, diags := expr.Value(ctx)
+	if !diags.HasErrors() {
+		return val
+	}
+
+	if m.usesComputedValue(expr, ctx) {
+		return val
+	}
+
+	for _, d := range diags {
+		if d.Severity == hcl.DiagError {
+			m.warnf("Could not evaluate %s: %s", m.describe(what), diagnosticMessage(d))
+		}
+	}
+
+	return val
+}
+
+// usesComputedValue returns true if the expression references resource attributes or
+// module outputs that could not be resolved, and all the other references could be.
+func (m *module) usesComputedValue(expr hcl.Expression, ctx *hcl.EvalContext) bool {
+	found := false
+
+	for _, t := range expr.Variables() {
+		if _, diags := t.TraverseAbs(ctx); !diags.HasErrors() {
+			continue
+		}
+
+		root := t.RootName()
+		if root != "data" && root != "module" && root != "self" {
+			if _, ok := m.resources[root]; !ok {
+				return false
+			}
+		}
+
+		found = true
+	}
+
+	return found
+}
+
+// childKey returns the key of a module call in the Terraform modules manifest.
+func (m *module) childKey(name string) string {
+	if m.key == "" {
+		return name
+	}
+
+	return fmt.Sprintf("%s.%s", m.key, name)
+}
+
+// providerKey returns the key of the root module provider configuration that the
+// provider configuration key is passed down as, e.g. aws.west.
+func (m *module) providerKey(key string) string {
+	if m.parent == nil {
+		return key
+	}
+
+	if parentKey, ok := m.providers[key]; ok {
+		return m.parent.providerKey(parentKey)
+	}
+
+	return m.parent.providerKey(key)
+}
+
+// describe adds the module address to a name, e.g. module.app.var.name.
+func (m *module) describe(name string) string {
+	if m.address == "" {
+		return name
+	}
+
+	return fmt.Sprintf("%s.%s", m.address, name)
+}
+
+func (m *module) warnf(format string, a ...interface{}) {
+	msg := fmt.Sprintf(format, a...)
+	if m.warningKeys[msg] {
+		return
+	}
+
+	m.warningKeys[msg] = true
+	m.warnings = append(m.warnings, msg)
+}
+
+// allWarnings returns the warnings of the module and its children.
+func (m *module) allWarnings() []string {
+	warnings := append([]string{}, m.warnings...)
+
+	for _, c := range m.children {
+		warnings = append(warnings, c.allWarnings()...)
+	}
+
+	return warnings
+}
+
+// instancesVal returns the value that references to the resource or module evaluate to:
+// a list when count is set, a map when for_each is set, otherwise the value itself.
+func instancesVal(count, forEach hcl.Expression, keys []instanceKey, values []cty.Value) cty.Value {
+	if count != nil {
+		return cty.TupleVal(values)
+	}
+
+	if forEach != nil {
+		if len(keys) == 1 && keys[0].index == cty.NilVal {
+			return cty.DynamicVal
+		}
+
+		m := make(map[string]cty.Value, len(keys))
+		for i, k := range keys {
+			m[k.index.AsString()] = values[i]
+		}
+
+		return cty.ObjectVal(m)
+	}
+
+	if len(values) == 0 {
+		return cty.DynamicVal
+	}
+
+	return values[0]
+}
+
+func nestedObjectVal(m map[string]map[string]cty.Value) cty.Value {
+	vals := make(map[string]cty.Value, len(m))
+	for k, v := range m {
+		vals[k] = cty.ObjectVal(v)
+	}
+
+	return cty.ObjectVal(vals)
+}
+
+// formatIndex returns the index part of an instance address, e.g. [0] or ["web"].
+func formatIndex(index cty.Value) string {
+	if index == cty.NilVal {
+		return ""
+	}
+
+	if index.Type().Equals(cty.Number) {
+		return fmt.Sprintf("[%s]", index.AsBigFloat().Text('f', -1))
+	}
+
+	return fmt.Sprintf("[%q]", index.AsString())
+}
+
+// formatTraversal returns the traversal as a string, e.g. aws_instance.web[0].id.
+func formatTraversal(t hcl.Traversal) string {
+	s := ""
+
+	for _, step := range t {
+		switch ts := step.(type) {
+		case hcl.TraverseRoot:
+			s += ts.Name
+		case hcl.TraverseAttr:
+			s += "." + ts.Name
+		case hcl.TraverseIndex:
+			if !ts.Key.IsKnown() || ts.Key.IsNull() {
+				continue
+			}
+			if t := ts.Key.Type(); t.Equals(cty.Number) || t.Equals(cty.String) {
+				s += formatIndex(ts.Key)
+			}
+		}
+	}
+
+	return s
+}
+
+func diagnosticMessage(d *hcl.Diagnostic) string {
+	msg := d.Summary
+	if d.Detail != "" {
+		msg = fmt.Sprintf("%s; %s", msg, d.Detail)
+	}
+
+	if d.Subject != nil {
+		msg = fmt.Sprintf("%s:%d,%d: %s", filepath.Base(d.Subject.Filename), d.Subject.Start.Line, d.Subject.Start.Column, msg)
+	}
+
+	return msg
+}
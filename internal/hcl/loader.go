@@ -0,0 +1,280 @@
+// Package hcl evaluates Terraform HCL code without running Terraform, so that the
+// resources of a project can be found without provider credentials or backend access.
+// The result is returned as Terraform plan JSON so it can be parsed the same way as
+// the output of terraform show.
+package hcl
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/hcl2/hcl"
+	"github.com/hashicorp/hcl2/hcl/hclsyntax"
+	"github.com/hashicorp/hcl2/hclparse"
+	"github.com/pkg/errors"
+	"github.com/zclconf/go-cty/cty"
+)
+
+// Options are the values that Terraform would get from the command line and environment.
+type Options struct {
+	// Workspace is the value of terraform.workspace, the default workspace is used if empty.
+	Workspace string
+	// VarFiles are the paths of tfvars files to load after terraform.tfvars and
+	// *.auto.tfvars, relative to the module directory.
+	VarFiles []string
+	// Vars are input variable values, e.g. from -var flags. They take precedence over
+	// values from tfvars files.
+	Vars map[string]string
+	// Env is checked for TF_VAR_ environment variables before the OS environment.
+	Env map[string]string
+}
+
+// Result is the Terraform plan JSON of the evaluated module and any warnings about
+// values that could not be evaluated.
+type Result struct {
+	PlanJSON []byte
+	Warnings []string
+}
+
+type loader struct {
+	rootDir  string
+	cwd      string
+	opts     Options
+	manifest map[string]string
+	configs  map[string]*moduleConfig
+	diags    hcl.Diagnostics
+}
+
+// LoadPlanJSON evaluates the Terraform module in the directory and returns its
+// resources as Terraform plan JSON. Values that can't be evaluated, e.g. because they
+// use attributes that are only known once a resource is created, are left out of the
+// resource values.
+func LoadPlanJSON(dir string, opts Options) (*Result, error) {
+	if opts.Workspace == "" {
+		opts.Workspace = "default"
+	}
+
+	cwd, _ := os.Getwd()
+
+	l := &loader{
+		rootDir:  dir,
+		cwd:      cwd,
+		opts:     opts,
+		manifest: loadModulesManifest(dir),
+		configs:  make(map[string]*moduleConfig),
+	}
+
+	config, ok := l.load(dir)
+	if !ok {
+		return nil, errors.Errorf("Could not load Terraform files from %s", dir)
+	}
+
+	root := newModule(l, nil, config, "", "")
+
+	inputs, warnings := l.rootInputs(config)
+	root.inputs = inputs
+
+	root.run()
+
+	for _, d := range l.diags {
+		if d.Severity == hcl.DiagError {
+			warnings = append(warnings, fmt.Sprintf("Could not parse Terraform file: %s", diagnosticMessage(d)))
+		}
+	}
+	warnings = append(warnings, root.allWarnings()...)
+
+	j, err := json.Marshal(root.planJSON())
+	if err != nil {
+		return nil, errors.Wrap(err, "Error generating plan JSON")
+	}
+
+	return &Result{
+		PlanJSON: j,
+		Warnings: warnings,
+	}, nil
+}
+
+func (l *loader) load(dir string) (*moduleConfig, bool) {
+	dir = filepath.Clean(dir)
+
+	if c, ok := l.configs[dir]; ok {
+		return c, c != nil
+	}
+
+	c, diags := loadModuleConfig(dir)
+	l.diags = append(l.diags, diags...)
+	l.configs[dir] = c
+
+	return c, c != nil
+}
+
+// moduleConfig returns the config of the module that the call uses. Local modules are
+// loaded relative to the calling module, other modules are loaded from where terraform
+// init downloaded them to.
+func (l *loader) moduleConfig(m *module, call *moduleCall) (*moduleConfig, bool) {
+	if strings.HasPrefix(call.source, "./") || strings.HasPrefix(call.source, "../") {
+		return l.load(filepath.Join(m.config.dir, call.source))
+	}
+
+	dir, ok := l.manifest[m.childKey(call.name)]
+	if !ok {
+		return nil, false
+	}
+
+	return l.load(filepath.Join(l.rootDir, dir))
+}
+
+// loadModulesManifest returns the directories of the modules that terraform init has
+// downloaded, keyed by the module call key, e.g. app.db for module db inside module app.
+func loadModulesManifest(dir string) map[string]string {
+	manifest := make(map[string]string)
+
+	b, err := os.ReadFile(filepath.Join(dir, ".terraform", "modules", "modules.json"))
+	if err != nil {
+		return manifest
+	}
+
+	var j struct {
+		Modules []struct {
+			Key string `json:"Key"`
+			Dir string `json:"Dir"`
+		} `json:"Modules"`
+	}
+
+	if err := json.Unmarshal(b, &j); err != nil {
+		return manifest
+	}
+
+	for _, m := range j.Modules {
+		if m.Key != "" {
+			manifest[m.Key] = m.Dir
+		}
+	}
+
+	return manifest
+}
+
+// rootInputs returns the values of the root module variables in the same order of
+// precedence as Terraform: environment variables, terraform.tfvars, *.auto.tfvars,
+// then the var files and vars from the options.
+func (l *loader) rootInputs(config *moduleConfig) (map[string]cty.Value, []string) {
+	var warnings []string
+	inputs := make(map[string]cty.Value)
+
+	types := make(map[string]cty.Type, len(config.variables))
+	for _, v := range config.variables {
+		types[v.name] = v.typ
+
+		if val, ok := l.opts.Env["TF_VAR_"+v.name]; ok {
+			inputs[v.name] = parseVarValue(val, v.typ)
+		} else if val, ok := os.LookupEnv("TF_VAR_" + v.name); ok {
+			inputs[v.name] = parseVarValue(val, v.typ)
+		}
+	}
+
+	var varFiles []string
+	for _, name := range []string{"terraform.tfvars", "terraform.tfvars.json"} {
+		if _, err := os.Stat(filepath.Join(l.rootDir, name)); err == nil {
+			varFiles = append(varFiles, name)
+		}
+	}
+
+	autoFiles, _ := filepath.Glob(filepath.Join(l.rootDir, "*.auto.tfvars"))
+	autoJSONFiles, _ := filepath.Glob(filepath.Join(l.rootDir, "*.auto.tfvars.json"))
+	autoFiles = append(autoFiles, autoJSONFiles...)
+	sort.Strings(autoFiles)
+	for _, f := range autoFiles {
+		varFiles = append(varFiles, filepath.Base(f))
+	}
+
+	varFiles = append(varFiles, l.opts.VarFiles...)
+
+	for _, f := range varFiles {
+		path := f
+		if !filepath.IsAbs(path) {
+			path = filepath.Join(l.rootDir, f)
+		}
+
+		vals, err := loadVarFile(path)
+		if err != nil {
+			warnings = append(warnings, fmt.Sprintf("Could not load variables from %s: %s", f, err))
+			continue
+		}
+
+		for k, v := range vals {
+			inputs[k] = v
+		}
+	}
+
+	names := make([]string, 0, len(l.opts.Vars))
+	for k := range l.opts.Vars {
+		names = append(names, k)
+	}
+	sort.Strings(names)
+
+	for _, k := range names {
+		typ, ok := types[k]
+		if !ok {
+			typ = cty.DynamicPseudoType
+		}
+		inputs[k] = parseVarValue(l.opts.Vars[k], typ)
+	}
+
+	return inputs, warnings
+}
+
+func loadVarFile(path string) (map[string]cty.Value, error) {
+	parser := hclparse.NewParser()
+
+	var f *hcl.File
+	var diags hcl.Diagnostics
+	if strings.HasSuffix(path, ".json") {
+		f, diags = parser.ParseJSONFile(path)
+	} else {
+		f, diags = parser.ParseHCLFile(path)
+	}
+	if diags.HasErrors() {
+		return nil, diags
+	}
+
+	attrs, diags := f.Body.JustAttributes()
+	if diags.HasErrors() {
+		return nil, diags
+	}
+
+	vals := make(map[string]cty.Value, len(attrs))
+	for name, attr := range attrs {
+		val, diags := attr.Expr.Value(nil)
+		if diags.HasErrors() {
+			return nil, diags
+		}
+		vals[name] = val
+	}
+
+	return vals, nil
+}
+
+// parseVarValue parses a variable value from the command line or environment. Like
+// Terraform, values for primitive types are used as strings and other values are
+// parsed as HCL expressions.
+func parseVarValue(raw string, typ cty.Type) cty.Value {
+	if typ.IsPrimitiveType() || typ.Equals(cty.DynamicPseudoType) {
+		return cty.StringVal(raw)
+	}
+
+	expr, diags := hclsyntax.ParseExpression([]byte(raw), "", hcl.Pos{Line: 1, Column: 1})
+	if diags.HasErrors() {
+		return cty.StringVal(raw)
+	}
+
+	val, diags := expr.Value(nil)
+	if diags.HasErrors() {
+		return cty.StringVal(raw)
+	}
+
+	return val
+}
@@ -0,0 +1,165 @@
+package hcl
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tidwall/gjson"
+)
+
+func loadTestPlanJSON(t *testing.T, dir string, opts Options) (gjson.Result, []string) {
+	t.Helper()
+
+	result, err := LoadPlanJSON(dir, opts)
+	require.NoError(t, err)
+
+	return gjson.ParseBytes(result.PlanJSON), result.Warnings
+}
+
+func TestLoadPlanJSONVariables(t *testing.T) {
+	tests := []struct {
+		name                 string
+		opts                 Options
+		expectedInstanceType string
+		expectedVolumeSize   int64
+		expectedName         string
+		expectedWorkspace    string
+	}{
+		{
+			name:                 "tfvars files",
+			opts:                 Options{},
+			expectedInstanceType: "t3.micro",
+			expectedVolumeSize:   100,
+			expectedName:         "app-prod-web",
+			expectedWorkspace:    "default",
+		},
+		{
+			name:                 "var files",
+			opts:                 Options{VarFiles: []string{"large.tfvars"}, Workspace: "prod"},
+			expectedInstanceType: "m5.large",
+			expectedVolumeSize:   100,
+			expectedName:         "app-prod-web",
+			expectedWorkspace:    "prod",
+		},
+		{
+			name:                 "vars take precedence over var files",
+			opts:                 Options{VarFiles: []string{"large.tfvars"}, Vars: map[string]string{"instance_type": "c5.xlarge", "environment": "test"}},
+			expectedInstanceType: "c5.xlarge",
+			expectedVolumeSize:   10,
+			expectedName:         "app-test-web",
+			expectedWorkspace:    "default",
+		},
+		{
+			name:                 "env vars",
+			opts:                 Options{Env: map[string]string{"TF_VAR_volume_size": "30", "TF_VAR_environment": "ignored"}},
+			expectedInstanceType: "t3.micro",
+			expectedVolumeSize:   300,
+			expectedName:         "app-prod-web",
+			expectedWorkspace:    "default",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			j, warnings := loadTestPlanJSON(t, "testdata/variables", tt.opts)
+			assert.Empty(t, warnings)
+
+			r := j.Get(`planned_values.root_module.resources.#(address=="aws_instance.web")`)
+			require.True(t, r.Exists())
+
+			assert.Equal(t, "registry.terraform.io/hashicorp/aws", r.Get("provider_name").String())
+			assert.Equal(t, tt.expectedInstanceType, r.Get("values.instance_type").String())
+			assert.Equal(t, tt.expectedVolumeSize, r.Get("values.root_block_device.0.volume_size").Int())
+			assert.Equal(t, tt.expectedName, r.Get("values.tags.Name").String())
+			assert.Equal(t, tt.expectedWorkspace, r.Get("values.tags.Workspace").String())
+
+			assert.Equal(t, "us-east-1", j.Get("configuration.provider_config.aws.expressions.region.constant_value").String())
+			assert.Equal(t, tt.expectedInstanceType, j.Get("variables.instance_type.value").String())
+		})
+	}
+}
+
+func TestLoadPlanJSONCountAndForEach(t *testing.T) {
+	j, warnings := loadTestPlanJSON(t, "testdata/count_for_each", Options{})
+	assert.Empty(t, warnings)
+
+	resources := j.Get("planned_values.root_module.resources")
+
+	var addresses []string
+	for _, r := range resources.Array() {
+		addresses = append(addresses, r.Get("address").String())
+	}
+
+	assert.Equal(t, []string{
+		"aws_instance.web[0]",
+		"aws_instance.web[1]",
+		`aws_sqs_queue.queue["emails"]`,
+		`aws_sqs_queue.queue["orders"]`,
+		`aws_ebs_volume.volume["data"]`,
+		`aws_ebs_volume.volume["logs"]`,
+		"aws_eip.web",
+		"aws_security_group.web",
+	}, addresses)
+
+	assert.Equal(t, "t3.large", resources.Get(`#(address=="aws_instance.web[0]").values.instance_type`).String())
+	assert.Equal(t, "t3.micro", resources.Get(`#(address=="aws_instance.web[1]").values.instance_type`).String())
+	assert.Equal(t, int64(1), resources.Get(`#(address=="aws_instance.web[1]").index`).Int())
+	assert.Equal(t, "orders", resources.Get(`#(address=="aws_sqs_queue.queue[\"orders\"]").values.name`).String())
+	assert.Equal(t, "orders", resources.Get(`#(address=="aws_sqs_queue.queue[\"orders\"]").index`).String())
+	assert.Equal(t, int64(20), resources.Get(`#(address=="aws_ebs_volume.volume[\"logs\"]").values.size`).Int())
+	assert.Equal(t, "st1", resources.Get(`#(address=="aws_ebs_volume.volume[\"logs\"]").values.type`).String())
+
+	// The instance ID is only known once the instance is created
+	eip := resources.Get(`#(address=="aws_eip.web")`)
+	assert.False(t, eip.Get("values.instance").Exists())
+
+	ingress := resources.Get(`#(address=="aws_security_group.web").values.ingress`).Array()
+	require.Len(t, ingress, 2)
+	assert.Equal(t, int64(80), ingress[0].Get("from_port").Int())
+	assert.Equal(t, int64(443), ingress[1].Get("to_port").Int())
+
+	refs := j.Get(`configuration.root_module.resources.#(address=="aws_eip.web").expressions.instance.references`)
+	assert.Equal(t, `["aws_instance.web","aws_instance.web[0]","aws_instance.web[0].id"]`, refs.Raw)
+}
+
+func TestLoadPlanJSONModules(t *testing.T) {
+	j, warnings := loadTestPlanJSON(t, "testdata/modules", Options{})
+	assert.Equal(t, []string{
+		"Module module.remote could not be found, run terraform init to download it. Resources in the module are not included",
+	}, warnings)
+
+	root := j.Get("planned_values.root_module")
+	assert.Equal(t, int64(200), root.Get(`resources.#(address=="aws_ebs_volume.extra").values.size`).Int())
+
+	children := root.Get("child_modules").Array()
+	require.Len(t, children, 2)
+
+	large := root.Get(`child_modules.#(address=="module.web[\"large\"]")`)
+	require.True(t, large.Exists())
+
+	instance := large.Get(`resources.#(address=="module.web[\"large\"].aws_instance.web")`)
+	assert.Equal(t, "m5.large", instance.Get("values.instance_type").String())
+	assert.Equal(t, "large", instance.Get("values.tags.Name").String())
+
+	moduleConfig := j.Get("configuration.root_module.module_calls.web")
+	assert.Equal(t, "./modules/web", moduleConfig.Get("source").String())
+	assert.Equal(t, "aws.west", moduleConfig.Get(`module.resources.#(address=="aws_instance.web").provider_config_key`).String())
+	assert.Equal(t, "us-west-2", j.Get("configuration.provider_config.aws\\.west.expressions.region.constant_value").String())
+}
+
+func TestLoadPlanJSONWarnings(t *testing.T) {
+	j, warnings := loadTestPlanJSON(t, "testdata/warnings", Options{})
+
+	assert.Equal(t, []string{
+		"Input variable var.instance_type has no value, any values that use it are unknown",
+		"Count of aws_instance.replica could not be evaluated, assuming 1 instance",
+		"Could not evaluate aws_eip.web.vpc: main.tf:19,14: Call to unknown function; There is no function named \"unknownfunc\".",
+	}, warnings)
+
+	resources := j.Get("planned_values.root_module.resources")
+	assert.Equal(t, "ami-674cbc1e", resources.Get(`#(address=="aws_instance.web").values.ami`).String())
+	assert.False(t, resources.Get(`#(address=="aws_instance.web").values.instance_type`).Exists())
+	assert.True(t, resources.Get(`#(address=="aws_instance.replica[0]")`).Exists())
+	assert.False(t, resources.Get(`#(address=="aws_eip.web").values.vpc`).Exists())
+}
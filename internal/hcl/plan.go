@@ -0,0 +1,302 @@
+package hcl
+
+import (
+	"encoding/json"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/hcl2/hcl"
+	"github.com/hashicorp/hcl2/hcl/hclsyntax"
+	"github.com/zclconf/go-cty/cty"
+)
+
+const planFormatVersion = "0.1"
+
+// planJSON returns the module in the format of the Terraform plan JSON. Only the fields
+// that are used to parse plan JSON into resources are set.
+func (m *module) planJSON() map[string]interface{} {
+	return map[string]interface{}{
+		"format_version": planFormatVersion,
+		"variables":      m.variablesJSON(),
+		"planned_values": map[string]interface{}{
+			"root_module": m.plannedValuesJSON(),
+		},
+		"configuration": map[string]interface{}{
+			"provider_config": m.providerConfigJSON(),
+			"root_module":     m.configurationJSON(),
+		},
+	}
+}
+
+func (m *module) variablesJSON() map[string]interface{} {
+	vars := make(map[string]interface{}, len(m.variables))
+
+	for name, val := range m.variables {
+		if v, ok := jsonValue(val); ok {
+			vars[name] = map[string]interface{}{"value": v}
+		}
+	}
+
+	return vars
+}
+
+func (m *module) plannedValuesJSON() map[string]interface{} {
+	resources := make([]interface{}, 0, len(m.instances))
+
+	for _, inst := range m.instances {
+		values, ok := jsonValue(inst.values)
+		if !ok {
+			values = map[string]interface{}{}
+		}
+
+		r := map[string]interface{}{
+			"address":       inst.address,
+			"mode":          inst.resource.mode,
+			"type":          inst.resource.typ,
+			"name":          inst.resource.name,
+			"provider_name": m.providerName(inst.resource),
+			"values":        values,
+		}
+
+		if inst.index != cty.NilVal {
+			r["index"], _ = jsonValue(inst.index)
+		}
+
+		resources = append(resources, r)
+	}
+
+	j := map[string]interface{}{
+		"resources": resources,
+	}
+
+	if m.address != "" {
+		j["address"] = m.address
+	}
+
+	if len(m.children) > 0 {
+		children := make([]interface{}, 0, len(m.children))
+		for _, c := range m.children {
+			children = append(children, c.plannedValuesJSON())
+		}
+		j["child_modules"] = children
+	}
+
+	return j
+}
+
+func (m *module) configurationJSON() map[string]interface{} {
+	resources := make([]interface{}, 0, len(m.config.resources))
+
+	for _, r := range m.config.resources {
+		resources = append(resources, map[string]interface{}{
+			"address":             r.address(),
+			"mode":                r.mode,
+			"type":                r.typ,
+			"name":                r.name,
+			"provider_config_key": m.providerKey(resourceProviderKey(r)),
+			"expressions":         expressionsJSON(r.body),
+		})
+	}
+
+	j := map[string]interface{}{
+		"resources": resources,
+	}
+
+	moduleCalls := make(map[string]interface{})
+	for _, call := range m.config.moduleCalls {
+		c := map[string]interface{}{
+			"source": call.source,
+		}
+
+		// All the instances of a module call have the same configuration
+		for _, child := range m.children {
+			if child.key == m.childKey(call.name) {
+				c["module"] = child.configurationJSON()
+				break
+			}
+		}
+
+		moduleCalls[call.name] = c
+	}
+
+	if len(moduleCalls) > 0 {
+		j["module_calls"] = moduleCalls
+	}
+
+	return j
+}
+
+// providerConfigJSON returns the provider configurations of the root module with the
+// attributes that could be evaluated as constant values, e.g. the region.
+func (m *module) providerConfigJSON() map[string]interface{} {
+	providers := make(map[string]interface{}, len(m.config.providers))
+	ctx := m.evalContext()
+
+	for _, p := range m.config.providers {
+		exprs := make(map[string]interface{})
+
+		attrs, _ := p.body.JustAttributes()
+		for name, attr := range attrs {
+			if name == "alias" {
+				continue
+			}
+
+			val, diags := attr.Expr.Value(ctx)
+			if diags.HasErrors() || !val.Type().IsPrimitiveType() {
+				continue
+			}
+
+			if v, ok := jsonValue(val); ok && v != nil {
+				exprs[name] = map[string]interface{}{"constant_value": v}
+			}
+		}
+
+		j := map[string]interface{}{
+			"name":        p.name,
+			"expressions": exprs,
+		}
+
+		if p.alias != "" {
+			j["alias"] = p.alias
+		}
+
+		providers[p.key()] = j
+	}
+
+	return providers
+}
+
+// providerName returns the source address of the provider of the resource, e.g.
+// registry.terraform.io/hashicorp/aws.
+func (m *module) providerName(r *resource) string {
+	name := strings.SplitN(resourceProviderKey(r), ".", 2)[0]
+
+	source, ok := m.config.requiredProviders[name]
+	if !ok {
+		source = "hashicorp/" + name
+	}
+
+	if strings.Count(source, "/") == 1 {
+		source = "registry.terraform.io/" + source
+	}
+
+	return strings.ToLower(source)
+}
+
+// resourceProviderKey returns the key of the provider configuration that the resource uses
+// in its module, which defaults to the prefix of the resource type, e.g. aws for aws_instance.
+func resourceProviderKey(r *resource) string {
+	if r.provider != "" {
+		return r.provider
+	}
+
+	return strings.SplitN(r.typ, "_", 2)[0]
+}
+
+// expressionsJSON returns the references of the top-level attributes in the body,
+// which are used to find the resources that a resource references.
+func expressionsJSON(body hcl.Body) map[string]interface{} {
+	exprs := make(map[string]interface{})
+
+	var attrs hcl.Attributes
+	if syntaxBody, ok := body.(*hclsyntax.Body); ok {
+		attrs = make(hcl.Attributes, len(syntaxBody.Attributes))
+		for name, attr := range syntaxBody.Attributes {
+			attrs[name] = attr.AsHCLAttribute()
+		}
+	} else {
+		attrs, _ = body.JustAttributes()
+	}
+
+	for name, attr := range attrs {
+		if resourceMetaArguments[name] {
+			continue
+		}
+
+		refs := references(attr.Expr)
+		if len(refs) > 0 {
+			exprs[name] = map[string]interface{}{"references": refs}
+		}
+	}
+
+	return exprs
+}
+
+// references returns the references in the expression in the same format as Terraform,
+// e.g. aws_instance.web.id references aws_instance.web.id and aws_instance.web.
+func references(expr hcl.Expression) []string {
+	var refs []string
+	seen := make(map[string]bool)
+
+	add := func(ref string) {
+		if !seen[ref] {
+			seen[ref] = true
+			refs = append(refs, ref)
+		}
+	}
+
+	for _, t := range expr.Variables() {
+		add(formatTraversal(t))
+
+		n := 2
+		if t.RootName() == "data" {
+			n = 3
+		}
+
+		if len(t) <= n {
+			continue
+		}
+
+		if _, ok := t[n].(hcl.TraverseIndex); ok {
+			add(formatTraversal(t[:n+1]))
+		}
+		add(formatTraversal(t[:n]))
+	}
+
+	sort.Strings(refs)
+
+	return refs
+}
+
+// jsonValue converts the value to a value that can be marshalled to JSON. Unknown values
+// are left out of objects and maps, since they are only known once the resource
+// is created. False is returned if the value is unknown.
+func jsonValue(val cty.Value) (interface{}, bool) {
+	if !val.IsKnown() {
+		return nil, false
+	}
+
+	if val.IsNull() {
+		return nil, true
+	}
+
+	t := val.Type()
+
+	switch {
+	case t.Equals(cty.String):
+		return val.AsString(), true
+	case t.Equals(cty.Number):
+		return json.Number(val.AsBigFloat().Text('f', -1)), true
+	case t.Equals(cty.Bool):
+		return val.True(), true
+	case t.IsListType() || t.IsSetType() || t.IsTupleType():
+		list := make([]interface{}, 0, val.LengthInt())
+		for it := val.ElementIterator(); it.Next(); {
+			_, v := it.Element()
+			j, _ := jsonValue(v)
+			list = append(list, j)
+		}
+		return list, true
+	case t.IsMapType() || t.IsObjectType():
+		m := make(map[string]interface{})
+		for it := val.ElementIterator(); it.Next(); {
+			k, v := it.Element()
+			if j, ok := jsonValue(v); ok {
+				m[k.AsString()] = j
+			}
+		}
+		return m, true
+	}
+
+	return nil, false
+}
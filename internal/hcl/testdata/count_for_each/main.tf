@@ -0,0 +1,56 @@
+variable "instance_count" {
+  default = 2
+}
+
+variable "queues" {
+  type    = set(string)
+  default = ["orders", "emails"]
+}
+
+variable "volumes" {
+  type = map(object({
+    size = number
+    type = string
+  }))
+  default = {
+    data = { size = 100, type = "gp2" }
+    logs = { size = 20, type = "st1" }
+  }
+}
+
+variable "ingress_ports" {
+  default = [80, 443]
+}
+
+resource "aws_instance" "web" {
+  count         = var.instance_count
+  ami           = "ami-674cbc1e"
+  instance_type = "t3.${count.index == 0 ? "large" : "micro"}"
+}
+
+resource "aws_sqs_queue" "queue" {
+  for_each = var.queues
+  name     = each.key
+}
+
+resource "aws_ebs_volume" "volume" {
+  for_each          = var.volumes
+  availability_zone = "us-east-1a"
+  size              = each.value.size
+  type              = each.value.type
+}
+
+resource "aws_eip" "web" {
+  instance = aws_instance.web[0].id
+}
+
+resource "aws_security_group" "web" {
+  dynamic "ingress" {
+    for_each = var.ingress_ports
+    content {
+      from_port = ingress.value
+      to_port   = ingress.value
+      protocol  = "tcp"
+    }
+  }
+}
@@ -0,0 +1,34 @@
+provider "aws" {
+  region = "us-east-1"
+}
+
+provider "aws" {
+  alias  = "west"
+  region = "us-west-2"
+}
+
+module "web" {
+  source = "./modules/web"
+
+  for_each = {
+    small = "t3.small"
+    large = "m5.large"
+  }
+
+  name          = each.key
+  instance_type = each.value
+
+  providers = {
+    aws = aws.west
+  }
+}
+
+resource "aws_ebs_volume" "extra" {
+  availability_zone = "us-east-1a"
+  size              = module.web["large"].volume_size
+}
+
+module "remote" {
+  source  = "terraform-aws-modules/vpc/aws"
+  version = "3.0.0"
+}
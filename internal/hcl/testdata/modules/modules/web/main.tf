@@ -0,0 +1,20 @@
+variable "name" {
+  type = string
+}
+
+variable "instance_type" {
+  type = string
+}
+
+resource "aws_instance" "web" {
+  ami           = "ami-674cbc1e"
+  instance_type = var.instance_type
+
+  tags = {
+    Name = var.name
+  }
+}
+
+output "volume_size" {
+  value = var.instance_type == "m5.large" ? 200 : 50
+}
@@ -0,0 +1,43 @@
+provider "aws" {
+  region = var.region
+}
+
+variable "region" {
+  type    = string
+  default = "us-east-1"
+}
+
+variable "instance_type" {
+  type = string
+}
+
+variable "volume_size" {
+  type    = number
+  default = 10
+}
+
+variable "environment" {
+  type    = string
+  default = "dev"
+}
+
+locals {
+  name      = "${local.prefix}-web"
+  prefix    = "app-${var.environment}"
+  is_prod   = var.environment == "prod"
+  disk_size = local.is_prod ? var.volume_size * 10 : var.volume_size
+}
+
+resource "aws_instance" "web" {
+  ami           = "ami-674cbc1e"
+  instance_type = var.instance_type
+
+  root_block_device {
+    volume_size = local.disk_size
+  }
+
+  tags = {
+    Name      = local.name
+    Workspace = terraform.workspace
+  }
+}
@@ -0,0 +1,20 @@
+variable "instance_type" {
+  type = string
+}
+
+resource "aws_instance" "web" {
+  ami           = "ami-674cbc1e"
+  instance_type = var.instance_type
+}
+
+resource "aws_instance" "replica" {
+  count         = length(aws_instance.web.security_groups)
+  ami           = "ami-674cbc1e"
+  instance_type = "t3.micro"
+  subnet_id     = aws_instance.web.subnet_id
+}
+
+resource "aws_eip" "web" {
+  instance = aws_instance.web.id
+  vpc      = unknownfunc(true)
+}
@@ -0,0 +1,153 @@
+package output
+
+import (
+	"fmt"
+
+	"github.com/infracost/infracost/internal/schema"
+	"github.com/infracost/infracost/internal/ui"
+	"github.com/shopspring/decimal"
+)
+
+// Budget is the monthly budget of a project, or of all the projects that have a
+// budget, and how much of it is consumed by the monthly cost.
+type Budget struct {
+	MonthlyBudget      *decimal.Decimal `json:"monthlyBudget"`
+	AlertThreshold     *decimal.Decimal `json:"alertThreshold,omitempty"`
+	ConsumptionPercent *decimal.Decimal `json:"consumptionPercent"`
+	OverAlertThreshold bool             `json:"overAlertThreshold"`
+	OverBudget         bool             `json:"overBudget"`
+}
+
+// BudgetError is returned when one or more projects are over their monthly budget.
+type BudgetError struct {
+	Currency string
+	Projects []Project
+}
+
+func (e *BudgetError) Error() string {
+	s := "Budget check failed"
+	if len(e.Projects) == 1 {
+		s += ", 1 project is over budget:\n"
+	} else {
+		s += fmt.Sprintf(", %d projects are over budget:\n", len(e.Projects))
+	}
+
+	for _, p := range e.Projects {
+		var cost *decimal.Decimal
+		if p.Breakdown != nil {
+			cost = p.Breakdown.TotalMonthlyCost
+		}
+
+		s += fmt.Sprintf("\n  %s\n", ui.BoldString(p.Name))
+		s += fmt.Sprintf("    Monthly cost of %s is over the budget of %s",
+			formatCost2DP(e.Currency, cost),
+			formatCost2DP(e.Currency, p.Budget.MonthlyBudget),
+		)
+	}
+
+	return s
+}
+
+// OverBudgetProjects returns the projects that are over their monthly budget.
+func OverBudgetProjects(r Root) []Project {
+	projects := make([]Project, 0)
+
+	for _, p := range r.Projects {
+		if p.Budget != nil && p.Budget.OverBudget {
+			projects = append(projects, p)
+		}
+	}
+
+	return projects
+}
+
+func newBudget(monthlyBudget decimal.Decimal, alertThreshold *decimal.Decimal, monthlyCost *decimal.Decimal) *Budget {
+	cost := decimal.Zero
+	if monthlyCost != nil {
+		cost = *monthlyCost
+	}
+
+	b := &Budget{
+		MonthlyBudget:  decimalPtr(monthlyBudget),
+		AlertThreshold: alertThreshold,
+		OverBudget:     cost.GreaterThan(monthlyBudget),
+	}
+
+	if monthlyBudget.IsPositive() {
+		b.ConsumptionPercent = decimalPtr(cost.Div(monthlyBudget).Mul(decimal.NewFromInt(100)).Round(2))
+	}
+
+	if alertThreshold != nil {
+		b.OverAlertThreshold = b.OverBudget || (b.ConsumptionPercent != nil && b.ConsumptionPercent.GreaterThanOrEqual(*alertThreshold))
+	}
+
+	return b
+}
+
+func projectBudget(budget *schema.Budget, breakdown *Breakdown) *Budget {
+	if budget == nil {
+		return nil
+	}
+
+	var cost *decimal.Decimal
+	if breakdown != nil {
+		cost = breakdown.TotalMonthlyCost
+	}
+
+	return newBudget(budget.MonthlyBudget, budget.AlertThreshold, cost)
+}
+
+// totalBudget returns the combined budget of all the projects that have a budget,
+// or nil if none of them do.
+func totalBudget(projects []Project) *Budget {
+	var monthlyBudget, monthlyCost *decimal.Decimal
+
+	for _, p := range projects {
+		if p.Budget == nil || p.Budget.MonthlyBudget == nil {
+			continue
+		}
+
+		if monthlyBudget == nil {
+			monthlyBudget = decimalPtr(decimal.Zero)
+			monthlyCost = decimalPtr(decimal.Zero)
+		}
+
+		monthlyBudget = decimalPtr(monthlyBudget.Add(*p.Budget.MonthlyBudget))
+		if p.Breakdown != nil && p.Breakdown.TotalMonthlyCost != nil {
+			monthlyCost = decimalPtr(monthlyCost.Add(*p.Breakdown.TotalMonthlyCost))
+		}
+	}
+
+	if monthlyBudget == nil {
+		return nil
+	}
+
+	return newBudget(*monthlyBudget, nil, monthlyCost)
+}
+
+// formatBudget formats the budget for the table and diff outputs, e.g. $1,000.00 (85.5% used)
+func formatBudget(currency string, b *Budget) string {
+	details := ""
+	if b.ConsumptionPercent != nil {
+		details = fmt.Sprintf("%s%% used", b.ConsumptionPercent.Round(1).String())
+	}
+
+	flag := ""
+	if b.OverBudget {
+		flag = ui.ErrorString("over budget")
+	} else if b.OverAlertThreshold {
+		flag = ui.WarningStringf("over %s%% alert threshold", b.AlertThreshold.String())
+	}
+
+	if details != "" && flag != "" {
+		details += ", "
+	}
+	details += flag
+
+	s := formatCost2DP(currency, b.MonthlyBudget)
+	if details != "" {
+		s += fmt.Sprintf(" (%s)", details)
+	}
+
+	return s
+}
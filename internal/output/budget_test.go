@@ -0,0 +1,79 @@
+package output
+
+import (
+	"testing"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewBudget(t *testing.T) {
+	threshold := decimal.NewFromInt(80)
+
+	tests := []struct {
+		name           string
+		monthlyBudget  decimal.Decimal
+		monthlyCost    *decimal.Decimal
+		consumption    string
+		overAlert      bool
+		overBudget     bool
+		alertThreshold *decimal.Decimal
+	}{
+		{name: "under alert threshold", monthlyBudget: decimal.NewFromInt(1000), monthlyCost: decimalPtr(decimal.NewFromInt(500)), consumption: "50", alertThreshold: &threshold},
+		{name: "over alert threshold", monthlyBudget: decimal.NewFromInt(1000), monthlyCost: decimalPtr(decimal.NewFromInt(855)), consumption: "85.5", overAlert: true, alertThreshold: &threshold},
+		{name: "over budget", monthlyBudget: decimal.NewFromInt(1000), monthlyCost: decimalPtr(decimal.NewFromInt(1200)), consumption: "120", overAlert: true, overBudget: true, alertThreshold: &threshold},
+		{name: "over budget without alert threshold", monthlyBudget: decimal.NewFromInt(1000), monthlyCost: decimalPtr(decimal.NewFromInt(1200)), consumption: "120", overBudget: true},
+		{name: "no cost", monthlyBudget: decimal.NewFromInt(1000), consumption: "0"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b := newBudget(tt.monthlyBudget, tt.alertThreshold, tt.monthlyCost)
+			require.NotNil(t, b.ConsumptionPercent)
+			assert.Equal(t, tt.consumption, b.ConsumptionPercent.String())
+			assert.Equal(t, tt.overAlert, b.OverAlertThreshold)
+			assert.Equal(t, tt.overBudget, b.OverBudget)
+		})
+	}
+}
+
+func TestTotalBudget(t *testing.T) {
+	projects := []Project{
+		{
+			Name:      "over",
+			Breakdown: &Breakdown{TotalMonthlyCost: decimalPtr(decimal.NewFromInt(1500))},
+			Budget:    newBudget(decimal.NewFromInt(1000), nil, decimalPtr(decimal.NewFromInt(1500))),
+		},
+		{
+			Name:      "under",
+			Breakdown: &Breakdown{TotalMonthlyCost: decimalPtr(decimal.NewFromInt(300))},
+			Budget:    newBudget(decimal.NewFromInt(3000), nil, decimalPtr(decimal.NewFromInt(300))),
+		},
+		{
+			Name:      "no budget",
+			Breakdown: &Breakdown{TotalMonthlyCost: decimalPtr(decimal.NewFromInt(10000))},
+		},
+	}
+
+	b := totalBudget(projects)
+	require.NotNil(t, b)
+	assert.Equal(t, "4000", b.MonthlyBudget.String())
+	assert.Equal(t, "45", b.ConsumptionPercent.String())
+	assert.False(t, b.OverBudget)
+
+	assert.Nil(t, totalBudget(projects[2:]))
+
+	over := OverBudgetProjects(Root{Projects: projects})
+	require.Len(t, over, 1)
+	assert.Equal(t, "over", over[0].Name)
+}
+
+func TestFormatBudget(t *testing.T) {
+	threshold := decimal.NewFromInt(80)
+
+	assert.Equal(t, "$1,000.00 (50% used)", formatBudget("USD", newBudget(decimal.NewFromInt(1000), &threshold, decimalPtr(decimal.NewFromInt(500)))))
+	assert.Equal(t, "$1,000.00 (85.5% used, over 80% alert threshold)", formatBudget("USD", newBudget(decimal.NewFromInt(1000), &threshold, decimalPtr(decimal.NewFromInt(855)))))
+	assert.Equal(t, "$1,000.00 (120% used, over budget)", formatBudget("USD", newBudget(decimal.NewFromInt(1000), &threshold, decimalPtr(decimal.NewFromInt(1200)))))
+	assert.Equal(t, "$0.00 (over budget)", formatBudget("USD", newBudget(decimal.Zero, nil, decimalPtr(decimal.NewFromInt(10)))))
+}
@@ -25,11 +25,13 @@ func Combine(currency string, inputs []ReportInput, opts Options) Root {
 	var totalMonthlyCost *decimal.Decimal
 
 	projects := make([]Project, 0)
+	var erroredProjects []ErroredProject
 	summaries := make([]*Summary, 0, len(inputs))
 
 	for _, input := range inputs {
 
 		projects = append(projects, input.Root.Projects...)
+		erroredProjects = append(erroredProjects, input.Root.ErroredProjects...)
 
 		summaries = append(summaries, input.Root.Summary)
 
@@ -55,6 +57,8 @@ func Combine(currency string, inputs []ReportInput, opts Options) Root {
 	combined.TotalHourlyCost = totalHourlyCost
 	combined.TotalMonthlyCost = totalMonthlyCost
 	combined.TimeGenerated = time.Now()
+	combined.Budget = totalBudget(projects)
+	combined.ErroredProjects = erroredProjects
 	combined.Summary = MergeSummaries(summaries)
 
 	return combined
@@ -0,0 +1,158 @@
+package output
+
+import (
+	"bytes"
+	"encoding/csv"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/shopspring/decimal"
+)
+
+// ToCSV flattens the output into one row per cost component so it can be opened as
+// a spreadsheet. The price, quantity and cost columns are included based on the fields
+// option, and their values are not rounded.
+func ToCSV(out Root, opts Options) ([]byte, error) {
+	var buf bytes.Buffer
+	w := csv.NewWriter(&buf)
+
+	headers := []string{"Project", "Resource", "Resource type", "Tags", "Sub-resource", "Cost component"}
+	if contains(opts.Fields, "price") {
+		headers = append(headers, formatTitleWithCurrency("Price", out.Currency))
+	}
+	if contains(opts.Fields, "monthlyQuantity") {
+		headers = append(headers, "Monthly quantity")
+	}
+	if contains(opts.Fields, "unit") {
+		headers = append(headers, "Unit")
+	}
+	if contains(opts.Fields, "hourlyCost") {
+		headers = append(headers, formatTitleWithCurrency("Hourly cost", out.Currency))
+	}
+	if contains(opts.Fields, "monthlyCost") {
+		headers = append(headers, formatTitleWithCurrency("Monthly cost", out.Currency))
+	}
+
+	// The error column is only added when there are errored projects so the
+	// columns stay the same for most runs
+	hasErrors := len(out.ErroredProjects) > 0
+	if hasErrors {
+		headers = append(headers, "Error")
+	}
+
+	err := w.Write(headers)
+	if err != nil {
+		return []byte{}, err
+	}
+
+	for _, project := range out.Projects {
+		if project.Breakdown == nil {
+			continue
+		}
+
+		for _, r := range project.Breakdown.Resources {
+			resourceCols := []string{
+				project.Label(opts.DashboardEnabled),
+				r.Name,
+				resourceType(r.Name),
+				formatTags(r.Tags),
+			}
+
+			rows := csvResourceRows(resourceCols, r, "", opts.Fields)
+			if hasErrors {
+				for i := range rows {
+					rows[i] = append(rows[i], "")
+				}
+			}
+
+			err := w.WriteAll(rows)
+			if err != nil {
+				return []byte{}, err
+			}
+		}
+	}
+
+	for _, project := range out.ErroredProjects {
+		row := make([]string, len(headers))
+		row[0] = project.Label(opts.DashboardEnabled)
+		row[len(row)-1] = strings.TrimSpace(project.Error)
+
+		err := w.Write(row)
+		if err != nil {
+			return []byte{}, err
+		}
+	}
+
+	w.Flush()
+	if err := w.Error(); err != nil {
+		return []byte{}, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+// csvResourceRows returns the rows for the cost components of the resource and its
+// sub-resources. The sub-resource column contains the path to the sub-resource,
+// e.g. ebs_block_device[0].
+func csvResourceRows(resourceCols []string, r Resource, subResourcePath string, fields []string) [][]string {
+	rows := make([][]string, 0, len(r.CostComponents))
+
+	for _, c := range r.CostComponents {
+		row := append([]string{}, resourceCols...)
+		row = append(row, subResourcePath, c.Name)
+
+		if contains(fields, "price") {
+			row = append(row, c.Price.String())
+		}
+		if contains(fields, "monthlyQuantity") {
+			row = append(row, csvDecimal(c.MonthlyQuantity))
+		}
+		if contains(fields, "unit") {
+			row = append(row, c.Unit)
+		}
+		if contains(fields, "hourlyCost") {
+			row = append(row, csvDecimal(c.HourlyCost))
+		}
+		if contains(fields, "monthlyCost") {
+			row = append(row, csvDecimal(c.MonthlyCost))
+		}
+
+		rows = append(rows, row)
+	}
+
+	for _, s := range r.SubResources {
+		path := s.Name
+		if subResourcePath != "" {
+			path = subResourcePath + "." + s.Name
+		}
+
+		rows = append(rows, csvResourceRows(resourceCols, s, path, fields)...)
+	}
+
+	return rows
+}
+
+func csvDecimal(d *decimal.Decimal) string {
+	if d == nil {
+		return ""
+	}
+
+	return d.String()
+}
+
+// formatTags formats the tags as key=value pairs sorted by key, e.g. Environment=prod; Team=infra
+func formatTags(tags map[string]string) string {
+	keys := make([]string, 0, len(tags))
+	for k := range tags {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	pairs := make([]string, 0, len(keys))
+	for _, k := range keys {
+		pairs = append(pairs, fmt.Sprintf("%s=%s", k, tags[k]))
+	}
+
+	return strings.Join(pairs, "; ")
+}
@@ -0,0 +1,55 @@
+package output
+
+import (
+	"testing"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestToCSV(t *testing.T) {
+	r := Root{
+		Currency: "USD",
+		Projects: []Project{
+			{
+				Name: "my-project",
+				Breakdown: &Breakdown{
+					Resources: []Resource{
+						{
+							Name: "module.app.aws_instance.web[0]",
+							Tags: map[string]string{"Team": "infra", "Environment": "prod"},
+							CostComponents: []CostComponent{
+								{Name: "Instance usage", Unit: "hours", Price: decimal.RequireFromString("0.1"), MonthlyQuantity: decimalPtr(decimal.NewFromInt(730)), MonthlyCost: decimalPtr(decimal.NewFromInt(73))},
+							},
+							SubResources: []Resource{
+								{
+									Name: "root_block_device",
+									CostComponents: []CostComponent{
+										{Name: "Storage, gp2", Unit: "GB", Price: decimal.RequireFromString("0.1"), MonthlyQuantity: decimalPtr(decimal.NewFromInt(8)), MonthlyCost: decimalPtr(decimal.RequireFromString("0.8"))},
+									},
+								},
+							},
+						},
+						{
+							Name: "aws_lambda_function.hello",
+							CostComponents: []CostComponent{
+								{Name: "Requests", Unit: "1M requests", Price: decimal.RequireFromString("0.2")},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	b, err := ToCSV(r, Options{Fields: []string{"unit", "monthlyCost"}})
+	require.NoError(t, err)
+
+	expected := `Project,Resource,Resource type,Tags,Sub-resource,Cost component,Unit,Monthly cost
+my-project,module.app.aws_instance.web[0],aws_instance,Environment=prod; Team=infra,,Instance usage,hours,73
+my-project,module.app.aws_instance.web[0],aws_instance,Environment=prod; Team=infra,root_block_device,"Storage, gp2",GB,0.8
+my-project,aws_lambda_function.hello,aws_lambda_function,,,Requests,1M requests,
+`
+	assert.Equal(t, expected, string(b))
+}
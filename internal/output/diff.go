@@ -79,6 +79,10 @@ func ToDiff(out Root, opts Options) ([]byte, error) {
 			)
 		}
 
+		if project.Budget != nil {
+			s += fmt.Sprintf("\nBudget:  %s", formatBudget(out.Currency, project.Budget))
+		}
+
 		if i != len(out.Projects)-1 {
 			s += "\n\n"
 		}
@@ -90,6 +94,19 @@ func ToDiff(out Root, opts Options) ([]byte, error) {
 		s += fmt.Sprintf("\nRun %s to see their full breakdown.", ui.PrimaryString("infracost breakdown"))
 	}
 
+	if len(out.UsageProfiles) > 0 {
+		s += "\n\n----------------------------------\n"
+		s += fmt.Sprintf("%s\n\n%s",
+			ui.BoldString("Monthly cost change by usage profile"),
+			tableForUsageProfiles(out.Currency, out.UsageProfiles, true),
+		)
+	}
+
+	if len(out.ErroredProjects) > 0 {
+		s += "\n\n----------------------------------\n"
+		s += textForErroredProjects(out.ErroredProjects, opts.DashboardEnabled)
+	}
+
 	s += "\n\n----------------------------------\n"
 	if len(noDiffProjects) != len(out.Projects) {
 		s += fmt.Sprintf("Key: %s changed, %s added, %s removed",
@@ -0,0 +1,58 @@
+package output
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/infracost/infracost/internal/ui"
+)
+
+func (p *ErroredProject) Label(dashboardEnabled bool) string {
+	if !dashboardEnabled || p.Metadata == nil {
+		return p.Name
+	}
+	return fmt.Sprintf("%s (%s)", p.Name, p.Metadata.Path)
+}
+
+// erroredProjectsMessage returns the title of the errored projects section, e.g.
+// 2 projects couldn't be estimated and aren't included in the costs.
+func erroredProjectsMessage(count int) string {
+	if count == 1 {
+		return "1 project couldn't be estimated and isn't included in the costs"
+	}
+
+	return fmt.Sprintf("%d projects couldn't be estimated and aren't included in the costs", count)
+}
+
+// textForErroredProjects lists the errored projects with their errors for the
+// table and diff output formats.
+func textForErroredProjects(projects []ErroredProject, dashboardEnabled bool) string {
+	s := fmt.Sprintf("%s %s\n",
+		ui.BoldString("Errored projects:"),
+		erroredProjectsMessage(len(projects)),
+	)
+
+	for _, p := range projects {
+		s += fmt.Sprintf("\n  %s\n%s", ui.ErrorString(p.Label(dashboardEnabled)), ui.Indent(strings.TrimSpace(p.Error), "    "))
+	}
+
+	return s
+}
+
+// markdownErroredProjects returns a table of the errored projects and their errors.
+func markdownErroredProjects(projects []ErroredProject, dashboardEnabled bool) string {
+	s := "\n### Errored projects\n\n"
+	s += erroredProjectsMessage(len(projects)) + ".\n\n"
+
+	s += "| Project | Error |\n"
+	s += "| --- | --- |\n"
+
+	for _, p := range projects {
+		s += markdownRow([]string{
+			escapeMarkdown(p.Label(dashboardEnabled)),
+			strings.ReplaceAll(escapeMarkdown(strings.TrimSpace(p.Error)), "\n", "<br>"),
+		})
+	}
+
+	return s
+}
@@ -0,0 +1,95 @@
+package output
+
+import (
+	"testing"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/infracost/infracost/internal/schema"
+)
+
+func TestToOutputFormatErroredProjects(t *testing.T) {
+	ok := schema.NewProject("infra/prod/app", &schema.ProjectMetadata{Path: "infra/prod/app"})
+	ok.Resources = []*schema.Resource{
+		{
+			Name: "aws_instance.web",
+			CostComponents: []*schema.CostComponent{
+				{Name: "Instance usage", Unit: "hours", UnitMultiplier: decimal.NewFromInt(1), MonthlyQuantity: decimalPtr(decimal.NewFromInt(730))},
+			},
+		},
+	}
+	ok.Resources[0].CostComponents[0].SetPrice(decimal.RequireFromString("0.1"))
+	ok.Resources[0].CalculateCosts()
+
+	errored := schema.NewProject("infra/prod/db", &schema.ProjectMetadata{Path: "infra/prod/db"})
+	errored.Error = "Error running terraform plan: exit status 1"
+
+	out := ToOutputFormat([]*schema.Project{ok, errored})
+
+	require.Len(t, out.Projects, 1)
+	assert.Equal(t, "infra/prod/app", out.Projects[0].Name)
+	assert.Equal(t, "73", out.TotalMonthlyCost.String())
+
+	assert.Equal(t, []ErroredProject{
+		{
+			Name:     "infra/prod/db",
+			Metadata: &schema.ProjectMetadata{Path: "infra/prod/db"},
+			Error:    "Error running terraform plan: exit status 1",
+		},
+	}, out.ErroredProjects)
+}
+
+func TestToCSVErroredProjects(t *testing.T) {
+	r := Root{
+		Currency: "USD",
+		Projects: []Project{
+			{
+				Name: "infra/prod/app",
+				Breakdown: &Breakdown{
+					Resources: []Resource{
+						{
+							Name: "aws_instance.web",
+							CostComponents: []CostComponent{
+								{Name: "Instance usage", Unit: "hours", MonthlyCost: decimalPtr(decimal.NewFromInt(73))},
+							},
+						},
+					},
+				},
+			},
+		},
+		ErroredProjects: []ErroredProject{
+			{Name: "infra/prod/db", Error: "Error running terraform plan: exit status 1\nError: No value for required variable\n"},
+		},
+	}
+
+	b, err := ToCSV(r, Options{Fields: []string{"monthlyCost"}})
+	require.NoError(t, err)
+
+	expected := `Project,Resource,Resource type,Tags,Sub-resource,Cost component,Monthly cost,Error
+infra/prod/app,aws_instance.web,aws_instance,,,Instance usage,73,
+infra/prod/db,,,,,,,"Error running terraform plan: exit status 1
+Error: No value for required variable"
+`
+	assert.Equal(t, expected, string(b))
+}
+
+func TestMarkdownErroredProjects(t *testing.T) {
+	projects := []ErroredProject{
+		{Name: "infra/prod/db", Error: "Error running terraform plan: exit status 1\nError: Invalid value for | <var>"},
+		{Name: "infra/prod/cache", Error: "Error parsing Terraform JSON"},
+	}
+
+	expected := `
+### Errored projects
+
+2 projects couldn't be estimated and aren't included in the costs.
+
+| Project | Error |
+| --- | --- |
+| infra/prod/db | Error running terraform plan: exit status 1<br>Error: Invalid value for \| &lt;var&gt; |
+| infra/prod/cache | Error parsing Terraform JSON |
+`
+	assert.Equal(t, expected, markdownErroredProjects(projects, false))
+}
@@ -35,6 +35,12 @@ func formatCost2DP(currency string, d *decimal.Decimal) string {
 	return formatRoundedDecimalCurrency(currency, *d)
 }
 
+// FormatCost2DP formats the cost in the currency with 2 decimal places, for costs
+// that are shown outside of the output formats, e.g. in prompts.
+func FormatCost2DP(currency string, d *decimal.Decimal) string {
+	return formatCost2DP(currency, d)
+}
+
 func formatPrice(currency string, d decimal.Decimal) string {
 	if d.LessThan(decimal.NewFromFloat(0.1)) {
 		return formatFullDecimalCurrency(currency, d)
@@ -0,0 +1,170 @@
+package output
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/jedib0t/go-pretty/v6/table"
+	"github.com/jedib0t/go-pretty/v6/text"
+	"github.com/shopspring/decimal"
+
+	"github.com/infracost/infracost/internal/ui"
+)
+
+const (
+	tagGroupPrefix = "tag:"
+	untaggedGroup  = "untagged"
+	rootModule     = "root"
+)
+
+// CostGroup is the total cost of the resources in a group, e.g. all the resources
+// that have the same value for a tag.
+type CostGroup struct {
+	Name             string           `json:"name"`
+	ResourceCount    int              `json:"resourceCount"`
+	TotalHourlyCost  *decimal.Decimal `json:"totalHourlyCost"`
+	TotalMonthlyCost *decimal.Decimal `json:"totalMonthlyCost"`
+}
+
+// ValidGroupBy returns true if the resources can be grouped by the value,
+// one of tag:<key>, resource_type, provider or module.
+func ValidGroupBy(groupBy string) bool {
+	if strings.HasPrefix(groupBy, tagGroupPrefix) {
+		return len(groupBy) > len(tagGroupPrefix)
+	}
+
+	return groupBy == "resource_type" || groupBy == "provider" || groupBy == "module"
+}
+
+// GroupCosts adds up the costs of the resources in all the projects by the groupBy
+// value. The groups are sorted by monthly cost, with the most expensive first.
+func GroupCosts(r Root, groupBy string) []CostGroup {
+	groups := make(map[string]*CostGroup)
+
+	for _, project := range r.Projects {
+		if project.Breakdown == nil {
+			continue
+		}
+
+		for _, resource := range project.Breakdown.Resources {
+			name := groupName(resource, groupBy)
+
+			g, ok := groups[name]
+			if !ok {
+				g = &CostGroup{Name: name}
+				groups[name] = g
+			}
+
+			g.ResourceCount++
+			g.TotalHourlyCost = addDecimals(g.TotalHourlyCost, resource.HourlyCost)
+			g.TotalMonthlyCost = addDecimals(g.TotalMonthlyCost, resource.MonthlyCost)
+		}
+	}
+
+	sorted := make([]CostGroup, 0, len(groups))
+	for _, g := range groups {
+		sorted = append(sorted, *g)
+	}
+
+	sort.Slice(sorted, func(i, j int) bool {
+		a, b := decimal.Zero, decimal.Zero
+		if sorted[i].TotalMonthlyCost != nil {
+			a = *sorted[i].TotalMonthlyCost
+		}
+		if sorted[j].TotalMonthlyCost != nil {
+			b = *sorted[j].TotalMonthlyCost
+		}
+
+		if a.Equal(b) {
+			return sorted[i].Name < sorted[j].Name
+		}
+
+		return a.GreaterThan(b)
+	})
+
+	return sorted
+}
+
+func groupName(resource Resource, groupBy string) string {
+	switch {
+	case strings.HasPrefix(groupBy, tagGroupPrefix):
+		if v, ok := resource.Tags[strings.TrimPrefix(groupBy, tagGroupPrefix)]; ok && v != "" {
+			return v
+		}
+		return untaggedGroup
+	case groupBy == "resource_type":
+		return resourceType(resource.Name)
+	case groupBy == "provider":
+		return resourceProvider(resource.Name)
+	case groupBy == "module":
+		return resourceModule(resource.Name)
+	}
+
+	return ""
+}
+
+// resourceProvider returns the provider of the resource from its type,
+// e.g. aws_instance returns aws.
+func resourceProvider(name string) string {
+	return strings.SplitN(resourceType(name), "_", 2)[0]
+}
+
+// resourceModule returns the module of the resource from its address without any
+// indexes, e.g. module.app["a"].module.db.aws_instance.web returns module.app.module.db.
+// Resources in the root module return root.
+func resourceModule(name string) string {
+	parts := strings.Split(resourceIndexRegex.ReplaceAllString(name, ""), ".")
+	if len(parts) <= 2 {
+		return rootModule
+	}
+
+	return strings.Join(parts[:len(parts)-2], ".")
+}
+
+// groupByTitle returns the title for the group column, e.g. tag:team returns Tag: team.
+func groupByTitle(groupBy string) string {
+	if strings.HasPrefix(groupBy, tagGroupPrefix) {
+		return fmt.Sprintf("Tag: %s", strings.TrimPrefix(groupBy, tagGroupPrefix))
+	}
+
+	switch groupBy {
+	case "resource_type":
+		return "Resource type"
+	case "provider":
+		return "Provider"
+	case "module":
+		return "Module"
+	}
+
+	return groupBy
+}
+
+func tableForGroups(currency string, groupBy string, groups []CostGroup) string {
+	t := table.NewWriter()
+	t.Style().Options.DrawBorder = false
+	t.Style().Options.SeparateColumns = false
+	t.Style().Options.SeparateRows = false
+	t.Style().Options.SeparateHeader = false
+	t.Style().Format.Header = text.FormatDefault
+
+	t.AppendHeader(table.Row{
+		ui.UnderlineString(groupByTitle(groupBy)),
+		ui.UnderlineString("Resources"),
+		ui.UnderlineString(formatTitleWithCurrency("Monthly Cost", currency)),
+	})
+
+	t.SetColumnConfigs([]table.ColumnConfig{
+		{Number: 1, Align: text.AlignLeft, AlignHeader: text.AlignLeft},
+		{Number: 2, Align: text.AlignRight, AlignHeader: text.AlignRight},
+		{Number: 3, Align: text.AlignRight, AlignHeader: text.AlignRight},
+	})
+
+	t.AppendRow(table.Row{""})
+
+	for _, g := range groups {
+		t.AppendRow(table.Row{g.Name, g.ResourceCount, formatCost2DP(currency, g.TotalMonthlyCost)})
+	}
+
+	return t.Render()
+}
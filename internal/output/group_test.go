@@ -0,0 +1,90 @@
+package output
+
+import (
+	"testing"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGroupCosts(t *testing.T) {
+	r := Root{
+		Projects: []Project{
+			{
+				Breakdown: &Breakdown{
+					Resources: []Resource{
+						{Name: "aws_instance.web", Tags: map[string]string{"team": "web"}, MonthlyCost: decimalPtr(decimal.NewFromInt(100))},
+						{Name: "module.db.aws_db_instance.main", Tags: map[string]string{"team": "data"}, MonthlyCost: decimalPtr(decimal.NewFromInt(300))},
+						{Name: "module.db.aws_instance.backup[0]", Tags: map[string]string{"team": "data"}, MonthlyCost: decimalPtr(decimal.NewFromInt(50))},
+					},
+				},
+			},
+			{
+				Breakdown: &Breakdown{
+					Resources: []Resource{
+						{Name: "google_compute_instance.app", MonthlyCost: decimalPtr(decimal.NewFromInt(20))},
+						{Name: "aws_lambda_function.hello", Tags: map[string]string{"team": ""}},
+					},
+				},
+			},
+		},
+	}
+
+	tests := []struct {
+		groupBy  string
+		expected []CostGroup
+	}{
+		{
+			groupBy: "tag:team",
+			expected: []CostGroup{
+				{Name: "data", ResourceCount: 2, TotalMonthlyCost: decimalPtr(decimal.NewFromInt(350))},
+				{Name: "web", ResourceCount: 1, TotalMonthlyCost: decimalPtr(decimal.NewFromInt(100))},
+				{Name: "untagged", ResourceCount: 2, TotalMonthlyCost: decimalPtr(decimal.NewFromInt(20))},
+			},
+		},
+		{
+			groupBy: "resource_type",
+			expected: []CostGroup{
+				{Name: "aws_db_instance", ResourceCount: 1, TotalMonthlyCost: decimalPtr(decimal.NewFromInt(300))},
+				{Name: "aws_instance", ResourceCount: 2, TotalMonthlyCost: decimalPtr(decimal.NewFromInt(150))},
+				{Name: "google_compute_instance", ResourceCount: 1, TotalMonthlyCost: decimalPtr(decimal.NewFromInt(20))},
+				{Name: "aws_lambda_function", ResourceCount: 1},
+			},
+		},
+		{
+			groupBy: "provider",
+			expected: []CostGroup{
+				{Name: "aws", ResourceCount: 4, TotalMonthlyCost: decimalPtr(decimal.NewFromInt(450))},
+				{Name: "google", ResourceCount: 1, TotalMonthlyCost: decimalPtr(decimal.NewFromInt(20))},
+			},
+		},
+		{
+			groupBy: "module",
+			expected: []CostGroup{
+				{Name: "module.db", ResourceCount: 2, TotalMonthlyCost: decimalPtr(decimal.NewFromInt(350))},
+				{Name: "root", ResourceCount: 3, TotalMonthlyCost: decimalPtr(decimal.NewFromInt(120))},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.groupBy, func(t *testing.T) {
+			assert.Equal(t, tt.expected, GroupCosts(r, tt.groupBy))
+		})
+	}
+}
+
+func TestValidGroupBy(t *testing.T) {
+	assert.True(t, ValidGroupBy("tag:team"))
+	assert.True(t, ValidGroupBy("resource_type"))
+	assert.True(t, ValidGroupBy("provider"))
+	assert.True(t, ValidGroupBy("module"))
+	assert.False(t, ValidGroupBy("tag:"))
+	assert.False(t, ValidGroupBy("team"))
+}
+
+func TestResourceModule(t *testing.T) {
+	assert.Equal(t, "root", resourceModule("aws_instance.web"))
+	assert.Equal(t, "module.app", resourceModule(`module.app["a.b"].aws_instance.web[0]`))
+	assert.Equal(t, "module.app.module.db", resourceModule("module.app.module.db.aws_db_instance.main"))
+}
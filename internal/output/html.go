@@ -46,6 +46,10 @@ func ToHTML(out Root, opts Options) ([]byte, error) {
 		"projectLabel": func(p Project) string {
 			return p.Label(opts.DashboardEnabled)
 		},
+		"erroredProjectLabel": func(p ErroredProject) string {
+			return p.Label(opts.DashboardEnabled)
+		},
+		"erroredProjectsMessage": erroredProjectsMessage,
 	})
 	tmpl, err := tmpl.Parse(HTMLTemplate)
 	if err != nil {
@@ -0,0 +1,371 @@
+package output
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/shopspring/decimal"
+
+	"github.com/infracost/infracost/internal/ui"
+)
+
+var markdownEscaper = strings.NewReplacer("|", "\\|", "<", "&lt;", ">", "&gt;")
+
+// ToMarkdown renders the output as GitHub flavored markdown so it can be posted as
+// a pull request comment. It has a table of the monthly cost change of each project
+// and a collapsible breakdown of the resources in each project.
+func ToMarkdown(out Root, opts Options) ([]byte, error) {
+	s := "## Infracost estimate\n\n"
+
+	s += markdownTotalsTable(out, opts)
+
+	hasNilCosts := false
+
+	for _, project := range out.Projects {
+		if project.Breakdown == nil {
+			continue
+		}
+
+		if breakdownHasNilCosts(*project.Breakdown) {
+			hasNilCosts = true
+		}
+
+		s += fmt.Sprintf("\n### %s\n\n", escapeMarkdown(project.Label(opts.DashboardEnabled)))
+		s += markdownProjectSummary(out.Currency, project)
+
+		if project.Diff != nil && len(project.Diff.Resources) > 0 {
+			s += "\n<details>\n"
+			s += fmt.Sprintf("<summary>Cost changes (%s)</summary>\n\n", pluralizeResources(len(project.Diff.Resources)))
+			s += markdownDiffTable(out.Currency, project)
+			s += "\n</details>\n"
+		}
+
+		breakdownTable, resourceCount := markdownBreakdownTable(out.Currency, *project.Breakdown, opts.Fields)
+		s += "\n<details>\n"
+		s += fmt.Sprintf("<summary>Cost breakdown (%s)</summary>\n\n", pluralizeResources(resourceCount))
+		s += breakdownTable
+		s += "\n</details>\n"
+	}
+
+	if len(out.ErroredProjects) > 0 {
+		s += markdownErroredProjects(out.ErroredProjects, opts.DashboardEnabled)
+	}
+
+	var notes []string
+
+	if hasNilCosts {
+		notes = append(notes, "To estimate usage-based resources use --usage-file, see https://infracost.io/usage-file")
+	}
+
+	if unsupportedMsg := out.unsupportedResourcesMessage(opts.ShowSkipped); unsupportedMsg != "" {
+		// Keep the line breaks in the message, e.g. the list of skipped resources
+		notes = append(notes, strings.ReplaceAll(unsupportedMsg, "\n", "  \n"))
+	}
+
+	if len(notes) > 0 {
+		s += "\n---\n\n" + strings.Join(notes, "\n\n") + "\n"
+	}
+
+	return []byte(s), nil
+}
+
+// markdownTotalsTable returns a table with the previous, new and changed monthly
+// cost of each project along with the overall totals.
+func markdownTotalsTable(out Root, opts Options) string {
+	s := fmt.Sprintf("| Project | %s | %s | %s |\n",
+		formatTitleWithCurrency("Previous", out.Currency),
+		formatTitleWithCurrency("New", out.Currency),
+		formatTitleWithCurrency("Diff", out.Currency),
+	)
+	s += "| --- | ---: | ---: | ---: |\n"
+
+	var totalPast, total, totalDiff *decimal.Decimal
+
+	for _, project := range out.Projects {
+		pastCost, cost, diff := projectMonthlyCosts(project)
+
+		totalPast = addDecimals(totalPast, pastCost)
+		total = addDecimals(total, cost)
+		totalDiff = addDecimals(totalDiff, diff)
+
+		s += fmt.Sprintf("| %s | %s | %s | %s |\n",
+			escapeMarkdown(project.Label(opts.DashboardEnabled)),
+			formatCost(out.Currency, pastCost),
+			formatCost(out.Currency, cost),
+			markdownCostChange(out.Currency, pastCost, cost, diff),
+		)
+	}
+
+	if len(out.Projects) > 1 {
+		s += fmt.Sprintf("| **Total** | **%s** | **%s** | **%s** |\n",
+			formatCost(out.Currency, totalPast),
+			formatCost(out.Currency, total),
+			markdownCostChange(out.Currency, totalPast, total, totalDiff),
+		)
+	}
+
+	return s
+}
+
+func markdownProjectSummary(currency string, project Project) string {
+	pastCost, cost, diff := projectMonthlyCosts(project)
+
+	s := fmt.Sprintf("**Monthly cost:** %s", formatCost(currency, cost))
+	if diff != nil {
+		s += fmt.Sprintf(" (%s from %s)", markdownCostChange(currency, pastCost, cost, diff), formatCost(currency, pastCost))
+	}
+	s += "\n"
+
+	if project.Budget != nil {
+		s += fmt.Sprintf("\n**Budget:** %s\n", ui.StripColor(formatBudget(currency, project.Budget)))
+	}
+
+	return s
+}
+
+func markdownDiffTable(currency string, project Project) string {
+	s := fmt.Sprintf("| Resource | %s | %s | %s |\n",
+		formatTitleWithCurrency("Previous", currency),
+		formatTitleWithCurrency("New", currency),
+		formatTitleWithCurrency("Diff", currency),
+	)
+	s += "| --- | ---: | ---: | ---: |\n"
+
+	for _, diffResource := range project.Diff.Resources {
+		var oldResource, newResource *Resource
+		if project.PastBreakdown != nil {
+			oldResource = findResourceByName(project.PastBreakdown.Resources, diffResource.Name)
+		}
+		if project.Breakdown != nil {
+			newResource = findResourceByName(project.Breakdown.Resources, diffResource.Name)
+		}
+
+		op := UPDATED
+		var oldCost, newCost *decimal.Decimal
+		if oldResource == nil {
+			op = ADDED
+		} else {
+			oldCost = oldResource.MonthlyCost
+		}
+		if newResource == nil {
+			op = REMOVED
+		} else {
+			newCost = newResource.MonthlyCost
+		}
+
+		change := "Depends on usage"
+		if oldCost != nil || newCost != nil {
+			change = formatCostChange(currency, diffResource.MonthlyCost)
+		}
+
+		s += fmt.Sprintf("| %s %s | %s | %s | %s |\n",
+			markdownOpChar(op),
+			escapeMarkdown(diffResource.Name),
+			formatCost(currency, oldCost),
+			formatCost(currency, newCost),
+			change,
+		)
+	}
+
+	return s
+}
+
+// markdownBreakdownTable returns the breakdown table and the number of resources in it.
+// Resources with no usage are hidden the same as the table output.
+func markdownBreakdownTable(currency string, breakdown Breakdown, fields []string) (string, int) {
+	headers := []string{"Name"}
+	aligns := []string{"---"}
+
+	if contains(fields, "price") {
+		headers = append(headers, formatTitleWithCurrency("Price", currency))
+		aligns = append(aligns, "---:")
+	}
+	if contains(fields, "monthlyQuantity") {
+		headers = append(headers, "Monthly Qty")
+		aligns = append(aligns, "---:")
+	}
+	if contains(fields, "unit") {
+		headers = append(headers, "Unit")
+		aligns = append(aligns, "---")
+	}
+	if contains(fields, "hourlyCost") {
+		headers = append(headers, formatTitleWithCurrency("Hourly Cost", currency))
+		aligns = append(aligns, "---:")
+	}
+	if contains(fields, "monthlyCost") {
+		headers = append(headers, formatTitleWithCurrency("Monthly Cost", currency))
+		aligns = append(aligns, "---:")
+	}
+
+	s := markdownRow(headers)
+	s += markdownRow(aligns)
+
+	resourceCount := 0
+
+	for _, r := range breakdown.Resources {
+		filteredComponents := filterZeroValComponents(r.CostComponents, r.Name)
+		filteredSubResources := filterZeroValResources(r.SubResources, r.Name)
+		if len(filteredComponents) == 0 && len(filteredSubResources) == 0 {
+			continue
+		}
+
+		resourceCount++
+
+		s += markdownRow(padRow([]string{fmt.Sprintf("**%s**", escapeMarkdown(r.Name))}, len(headers)))
+		s += markdownCostComponentRows(currency, filteredComponents, "", len(filteredSubResources) > 0, fields, len(headers))
+		s += markdownSubResourceRows(currency, filteredSubResources, "", fields, len(headers))
+	}
+
+	// The monthly cost column is always the last one, so the total can only be
+	// shown when it's included
+	if contains(fields, "monthlyCost") {
+		totalRow := padRow([]string{"**Project total**"}, len(headers)-1)
+		totalRow = append(totalRow, fmt.Sprintf("**%s**", formatCost2DP(currency, breakdown.TotalMonthlyCost)))
+		s += markdownRow(totalRow)
+	}
+
+	return s, resourceCount
+}
+
+func markdownSubResourceRows(currency string, subresources []Resource, prefix string, fields []string, numColumns int) string {
+	s := ""
+
+	for i, r := range subresources {
+		filteredComponents := filterZeroValComponents(r.CostComponents, r.Name)
+		filteredSubResources := filterZeroValResources(r.SubResources, r.Name)
+		if len(filteredComponents) == 0 && len(filteredSubResources) == 0 {
+			continue
+		}
+
+		labelPrefix := prefix + "├─"
+		nextPrefix := prefix + "│ "
+		if i == len(subresources)-1 {
+			labelPrefix = prefix + "└─"
+			nextPrefix = prefix + "  "
+		}
+
+		s += markdownRow(padRow([]string{fmt.Sprintf("%s %s", labelPrefix, escapeMarkdown(r.Name))}, numColumns))
+		s += markdownCostComponentRows(currency, filteredComponents, nextPrefix, len(filteredSubResources) > 0, fields, numColumns)
+		s += markdownSubResourceRows(currency, filteredSubResources, nextPrefix, fields, numColumns)
+	}
+
+	return s
+}
+
+func markdownCostComponentRows(currency string, costComponents []CostComponent, prefix string, hasSubResources bool, fields []string, numColumns int) string {
+	s := ""
+
+	for i, c := range costComponents {
+		labelPrefix := prefix + "├─"
+		if !hasSubResources && i == len(costComponents)-1 {
+			labelPrefix = prefix + "└─"
+		}
+
+		row := []string{fmt.Sprintf("%s %s", labelPrefix, escapeMarkdown(c.Name))}
+
+		if c.MonthlyCost == nil {
+			row = padRow(row, numColumns-1)
+			row = append(row, fmt.Sprintf("Depends on usage: %s per %s", formatPrice(currency, c.Price), escapeMarkdown(c.Unit)))
+			s += markdownRow(row)
+			continue
+		}
+
+		if contains(fields, "price") {
+			row = append(row, formatPrice(currency, c.Price))
+		}
+		if contains(fields, "monthlyQuantity") {
+			row = append(row, formatQuantity(c.MonthlyQuantity))
+		}
+		if contains(fields, "unit") {
+			row = append(row, escapeMarkdown(c.Unit))
+		}
+		if contains(fields, "hourlyCost") {
+			row = append(row, formatCost2DP(currency, c.HourlyCost))
+		}
+		if contains(fields, "monthlyCost") {
+			row = append(row, formatCost2DP(currency, c.MonthlyCost))
+		}
+
+		s += markdownRow(row)
+	}
+
+	return s
+}
+
+// projectMonthlyCosts returns the previous, new and changed monthly cost of the project.
+func projectMonthlyCosts(project Project) (*decimal.Decimal, *decimal.Decimal, *decimal.Decimal) {
+	var pastCost, cost, diff *decimal.Decimal
+
+	if project.PastBreakdown != nil {
+		pastCost = project.PastBreakdown.TotalMonthlyCost
+	}
+	if project.Breakdown != nil {
+		cost = project.Breakdown.TotalMonthlyCost
+	}
+	if project.Diff != nil {
+		diff = project.Diff.TotalMonthlyCost
+	}
+
+	return pastCost, cost, diff
+}
+
+func markdownCostChange(currency string, oldCost, newCost, diff *decimal.Decimal) string {
+	if diff == nil {
+		return "-"
+	}
+
+	s := formatCostChange(currency, diff)
+	if percent := formatPercentChange(oldCost, newCost); percent != "" {
+		s += fmt.Sprintf(" (%s)", percent)
+	}
+
+	return s
+}
+
+func markdownOpChar(op int) string {
+	switch op {
+	case ADDED:
+		return "+"
+	case REMOVED:
+		return "-"
+	default:
+		return "~"
+	}
+}
+
+func markdownRow(cells []string) string {
+	return fmt.Sprintf("| %s |\n", strings.Join(cells, " | "))
+}
+
+// padRow adds empty cells to the row so it has the given number of columns.
+func padRow(row []string, numColumns int) []string {
+	for len(row) < numColumns {
+		row = append(row, "")
+	}
+
+	return row
+}
+
+func addDecimals(total *decimal.Decimal, d *decimal.Decimal) *decimal.Decimal {
+	if d == nil {
+		return total
+	}
+
+	if total == nil {
+		return decimalPtr(*d)
+	}
+
+	return decimalPtr(total.Add(*d))
+}
+
+func escapeMarkdown(s string) string {
+	return markdownEscaper.Replace(s)
+}
+
+func pluralizeResources(count int) string {
+	if count == 1 {
+		return "1 resource"
+	}
+
+	return fmt.Sprintf("%d resources", count)
+}
@@ -0,0 +1,44 @@
+package output
+
+import (
+	"testing"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMarkdownBreakdownTableTotal(t *testing.T) {
+	breakdown := Breakdown{
+		Resources: []Resource{
+			{
+				Name: "aws_instance.web",
+				CostComponents: []CostComponent{
+					{
+						Name:            "Instance usage",
+						Unit:            "hours",
+						Price:           decimal.NewFromFloat(0.1),
+						MonthlyQuantity: decimalPtr(decimal.NewFromInt(730)),
+						HourlyCost:      decimalPtr(decimal.NewFromFloat(0.1)),
+						MonthlyCost:     decimalPtr(decimal.NewFromInt(73)),
+					},
+				},
+			},
+		},
+		TotalMonthlyCost: decimalPtr(decimal.NewFromInt(73)),
+	}
+
+	s, _ := markdownBreakdownTable("USD", breakdown, []string{"unit", "monthlyCost"})
+	assert.Equal(t, `| Name | Unit | Monthly Cost |
+| --- | --- | ---: |
+| **aws_instance.web** |  |  |
+| └─ Instance usage | hours | $73.00 |
+| **Project total** |  | **$73.00** |
+`, s)
+
+	s, _ = markdownBreakdownTable("USD", breakdown, []string{"unit", "hourlyCost"})
+	assert.Equal(t, `| Name | Unit | Hourly Cost |
+| --- | --- | ---: |
+| **aws_instance.web** |  |  |
+| └─ Instance usage | hours | $0.10 |
+`, s)
+}
@@ -13,19 +13,25 @@ import (
 var outputVersion = "0.2"
 
 type Root struct {
-	Version              string           `json:"version"`
-	RunID                string           `json:"runId,omitempty"`
-	Currency             string           `json:"currency"`
-	Projects             []Project        `json:"projects"`
-	TotalHourlyCost      *decimal.Decimal `json:"totalHourlyCost"`
-	TotalMonthlyCost     *decimal.Decimal `json:"totalMonthlyCost"`
-	PastTotalHourlyCost  *decimal.Decimal `json:"pastTotalHourlyCost"`
-	PastTotalMonthlyCost *decimal.Decimal `json:"pastTotalMonthlyCost"`
-	DiffTotalHourlyCost  *decimal.Decimal `json:"diffTotalHourlyCost"`
-	DiffTotalMonthlyCost *decimal.Decimal `json:"diffTotalMonthlyCost"`
-	TimeGenerated        time.Time        `json:"timeGenerated"`
-	Summary              *Summary         `json:"summary"`
-	FullSummary          *Summary         `json:"-"`
+	Version              string               `json:"version"`
+	RunID                string               `json:"runId,omitempty"`
+	Currency             string               `json:"currency"`
+	Projects             []Project            `json:"projects"`
+	TotalHourlyCost      *decimal.Decimal     `json:"totalHourlyCost"`
+	TotalMonthlyCost     *decimal.Decimal     `json:"totalMonthlyCost"`
+	PastTotalHourlyCost  *decimal.Decimal     `json:"pastTotalHourlyCost"`
+	PastTotalMonthlyCost *decimal.Decimal     `json:"pastTotalMonthlyCost"`
+	DiffTotalHourlyCost  *decimal.Decimal     `json:"diffTotalHourlyCost"`
+	DiffTotalMonthlyCost *decimal.Decimal     `json:"diffTotalMonthlyCost"`
+	TimeGenerated        time.Time            `json:"timeGenerated"`
+	Budget               *Budget              `json:"budget,omitempty"`
+	GroupBy              string               `json:"groupBy,omitempty"`
+	Groups               []CostGroup          `json:"groups,omitempty"`
+	UsageProfiles        []UsageProfileCost   `json:"usageProfiles,omitempty"`
+	TagPolicyViolations  []TagPolicyViolation `json:"tagPolicyViolations,omitempty"`
+	ErroredProjects      []ErroredProject     `json:"erroredProjects,omitempty"`
+	Summary              *Summary             `json:"summary"`
+	FullSummary          *Summary             `json:"-"`
 }
 
 type Project struct {
@@ -34,10 +40,19 @@ type Project struct {
 	PastBreakdown *Breakdown              `json:"pastBreakdown"`
 	Breakdown     *Breakdown              `json:"breakdown"`
 	Diff          *Breakdown              `json:"diff"`
+	Budget        *Budget                 `json:"budget,omitempty"`
 	Summary       *Summary                `json:"summary"`
 	fullSummary   *Summary
 }
 
+// ErroredProject is a project that could not be loaded. It has no costs and is
+// listed separately from the projects in the output.
+type ErroredProject struct {
+	Name     string                  `json:"name"`
+	Metadata *schema.ProjectMetadata `json:"metadata"`
+	Error    string                  `json:"error"`
+}
+
 func (p *Project) Label(dashboardEnabled bool) string {
 	if !dashboardEnabled {
 		return p.Name
@@ -157,10 +172,20 @@ func ToOutputFormat(projects []*schema.Project) Root {
 		diffTotalMonthlyCost, diffTotalHourlyCost *decimal.Decimal
 
 	outProjects := make([]Project, 0, len(projects))
+	var erroredProjects []ErroredProject
 	summaries := make([]*Summary, 0, len(projects))
 	fullSummaries := make([]*Summary, 0, len(projects))
 
 	for _, project := range projects {
+		if project.Error != "" {
+			erroredProjects = append(erroredProjects, ErroredProject{
+				Name:     project.Name,
+				Metadata: project.Metadata,
+				Error:    project.Error,
+			})
+			continue
+		}
+
 		var pastBreakdown, breakdown, diff *Breakdown
 
 		breakdown = outputBreakdown(project.Resources)
@@ -232,6 +257,7 @@ func ToOutputFormat(projects []*schema.Project) Root {
 			PastBreakdown: pastBreakdown,
 			Breakdown:     breakdown,
 			Diff:          diff,
+			Budget:        projectBudget(project.Budget, breakdown),
 			Summary:       summary,
 			fullSummary:   fullSummary,
 		})
@@ -247,6 +273,8 @@ func ToOutputFormat(projects []*schema.Project) Root {
 		DiffTotalHourlyCost:  diffTotalHourlyCost,
 		DiffTotalMonthlyCost: diffTotalMonthlyCost,
 		TimeGenerated:        time.Now(),
+		Budget:               totalBudget(outProjects),
+		ErroredProjects:      erroredProjects,
 		Summary:              MergeSummaries(summaries),
 		FullSummary:          MergeSummaries(fullSummaries),
 	}
@@ -0,0 +1,156 @@
+package output
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/infracost/infracost/internal/config"
+	"github.com/infracost/infracost/internal/ui"
+	"github.com/shopspring/decimal"
+)
+
+var resourceIndexRegex = regexp.MustCompile(`\[[^\]]*\]`)
+
+// PolicyViolation is a failed check of a cost policy.
+type PolicyViolation struct {
+	Policy   string `json:"policy"`
+	Project  string `json:"project"`
+	Resource string `json:"resource,omitempty"`
+	Message  string `json:"message"`
+}
+
+// PolicyError is returned when one or more cost policies have failed. The error
+// message is the violation report.
+type PolicyError struct {
+	Violations []PolicyViolation
+}
+
+func (e *PolicyError) Error() string {
+	s := "Cost policy check failed"
+	if len(e.Violations) == 1 {
+		s += ", 1 violation found:\n"
+	} else {
+		s += fmt.Sprintf(", %d violations found:\n", len(e.Violations))
+	}
+
+	for _, v := range e.Violations {
+		s += fmt.Sprintf("\n  %s %s\n", ui.BoldString(v.Policy), ui.FaintStringf("(%s)", v.Project))
+		s += fmt.Sprintf("    %s", v.Message)
+	}
+
+	return s
+}
+
+// CheckPolicies checks the projects in the output against the cost policies and
+// returns any violations, in the order of the policies.
+func CheckPolicies(r Root, policies []*config.Policy) []PolicyViolation {
+	violations := make([]PolicyViolation, 0)
+
+	for _, policy := range policies {
+		for _, project := range r.Projects {
+			violations = append(violations, checkProjectPolicy(r.Currency, project, policy)...)
+		}
+	}
+
+	return violations
+}
+
+func checkProjectPolicy(currency string, project Project, policy *config.Policy) []PolicyViolation {
+	violations := make([]PolicyViolation, 0)
+
+	addViolation := func(resource string, msg string) {
+		violations = append(violations, PolicyViolation{
+			Policy:   policy.Name,
+			Project:  project.Name,
+			Resource: resource,
+			Message:  msg,
+		})
+	}
+
+	if policy.MaxProjectMonthlyCost != nil && project.Breakdown != nil {
+		limit := decimal.NewFromFloat(*policy.MaxProjectMonthlyCost)
+		cost := project.Breakdown.TotalMonthlyCost
+		if cost != nil && cost.GreaterThan(limit) {
+			addViolation("", fmt.Sprintf("Monthly cost of %s is over the limit of %s",
+				formatCost2DP(currency, cost),
+				formatCost2DP(currency, &limit),
+			))
+		}
+	}
+
+	if policy.MaxProjectMonthlyDiff != nil && project.Diff != nil {
+		limit := decimal.NewFromFloat(*policy.MaxProjectMonthlyDiff)
+		diff := project.Diff.TotalMonthlyCost
+		if diff != nil && diff.GreaterThan(limit) {
+			addViolation("", fmt.Sprintf("Monthly cost diff of +%s is over the limit of %s",
+				formatCost2DP(currency, diff),
+				formatCost2DP(currency, &limit),
+			))
+		}
+	}
+
+	if project.Breakdown == nil {
+		return violations
+	}
+
+	for _, resource := range project.Breakdown.Resources {
+		if len(policy.ResourceTypes) > 0 && !contains(policy.ResourceTypes, resourceType(resource.Name)) {
+			continue
+		}
+
+		if policy.MaxResourceMonthlyCost != nil {
+			limit := decimal.NewFromFloat(*policy.MaxResourceMonthlyCost)
+			if resource.MonthlyCost != nil && resource.MonthlyCost.GreaterThan(limit) {
+				addViolation(resource.Name, fmt.Sprintf("%s has a monthly cost of %s which is over the limit of %s",
+					resource.Name,
+					formatCost2DP(currency, resource.MonthlyCost),
+					formatCost2DP(currency, &limit),
+				))
+			}
+		}
+
+		for _, name := range disallowedCostComponents(resource, policy.DisallowedCostComponents) {
+			addViolation(resource.Name, fmt.Sprintf("%s has disallowed cost component %s", resource.Name, name))
+		}
+	}
+
+	return violations
+}
+
+// disallowedCostComponents returns the names of any cost components of the resource,
+// or its sub-resources, that contain one of the disallowed values.
+func disallowedCostComponents(resource Resource, disallowed []string) []string {
+	names := make([]string, 0)
+	if len(disallowed) == 0 {
+		return names
+	}
+
+	for _, c := range resource.CostComponents {
+		for _, d := range disallowed {
+			if strings.Contains(strings.ToLower(c.Name), strings.ToLower(d)) {
+				names = append(names, fmt.Sprintf("\"%s\"", c.Name))
+				break
+			}
+		}
+	}
+
+	for _, s := range resource.SubResources {
+		for _, name := range disallowedCostComponents(s, disallowed) {
+			names = append(names, fmt.Sprintf("%s in %s", name, s.Name))
+		}
+	}
+
+	return names
+}
+
+// resourceType returns the type of the resource from its address,
+// e.g. module.app.aws_instance.web[0] returns aws_instance.
+func resourceType(name string) string {
+	parts := strings.Split(resourceIndexRegex.ReplaceAllString(name, ""), ".")
+	if len(parts) < 2 {
+		return ""
+	}
+
+	return parts[len(parts)-2]
+}
@@ -0,0 +1,110 @@
+package output
+
+import (
+	"testing"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/infracost/infracost/internal/config"
+)
+
+func floatPtr(f float64) *float64 {
+	return &f
+}
+
+func TestCheckPolicies(t *testing.T) {
+	r := Root{
+		Currency: "USD",
+		Projects: []Project{
+			{
+				Name: "infracost/infracost/examples/terraform",
+				Breakdown: &Breakdown{
+					TotalMonthlyCost: decimalPtr(decimal.NewFromInt(2800)),
+					Resources: []Resource{
+						{
+							Name:        "aws_instance.web_app",
+							MonthlyCost: decimalPtr(decimal.NewFromInt(2500)),
+							SubResources: []Resource{
+								{
+									Name:           "root_block_device",
+									CostComponents: []CostComponent{{Name: "Storage (general purpose SSD, gp2)"}},
+								},
+							},
+						},
+						{
+							Name:           "module.storage.aws_ebs_volume.data[0]",
+							MonthlyCost:    decimalPtr(decimal.NewFromInt(300)),
+							CostComponents: []CostComponent{{Name: "Storage (general purpose SSD, GP2)"}},
+						},
+					},
+				},
+				Diff: &Breakdown{
+					TotalMonthlyCost: decimalPtr(decimal.NewFromInt(600)),
+				},
+			},
+		},
+	}
+
+	tests := []struct {
+		name     string
+		policy   *config.Policy
+		expected []PolicyViolation
+	}{
+		{
+			name:   "max project monthly cost",
+			policy: &config.Policy{Name: "Project cost", MaxProjectMonthlyCost: floatPtr(2000)},
+			expected: []PolicyViolation{
+				{Policy: "Project cost", Project: "infracost/infracost/examples/terraform", Message: "Monthly cost of $2,800.00 is over the limit of $2,000.00"},
+			},
+		},
+		{
+			name:   "max project monthly diff",
+			policy: &config.Policy{Name: "Project diff", MaxProjectMonthlyDiff: floatPtr(500)},
+			expected: []PolicyViolation{
+				{Policy: "Project diff", Project: "infracost/infracost/examples/terraform", Message: "Monthly cost diff of +$600.00 is over the limit of $500.00"},
+			},
+		},
+		{
+			name:     "max project monthly diff under limit",
+			policy:   &config.Policy{Name: "Project diff", MaxProjectMonthlyDiff: floatPtr(1000)},
+			expected: []PolicyViolation{},
+		},
+		{
+			name:   "max resource monthly cost",
+			policy: &config.Policy{Name: "Resource cost", MaxResourceMonthlyCost: floatPtr(2000)},
+			expected: []PolicyViolation{
+				{Policy: "Resource cost", Project: "infracost/infracost/examples/terraform", Resource: "aws_instance.web_app", Message: "aws_instance.web_app has a monthly cost of $2,500.00 which is over the limit of $2,000.00"},
+			},
+		},
+		{
+			name:   "disallowed cost components",
+			policy: &config.Policy{Name: "No gp2", DisallowedCostComponents: []string{"gp2"}},
+			expected: []PolicyViolation{
+				{Policy: "No gp2", Project: "infracost/infracost/examples/terraform", Resource: "aws_instance.web_app", Message: "aws_instance.web_app has disallowed cost component \"Storage (general purpose SSD, gp2)\" in root_block_device"},
+				{Policy: "No gp2", Project: "infracost/infracost/examples/terraform", Resource: "module.storage.aws_ebs_volume.data[0]", Message: "module.storage.aws_ebs_volume.data[0] has disallowed cost component \"Storage (general purpose SSD, GP2)\""},
+			},
+		},
+		{
+			name:   "disallowed cost components filtered by resource type",
+			policy: &config.Policy{Name: "No gp2 volumes", DisallowedCostComponents: []string{"gp2"}, ResourceTypes: []string{"aws_ebs_volume"}},
+			expected: []PolicyViolation{
+				{Policy: "No gp2 volumes", Project: "infracost/infracost/examples/terraform", Resource: "module.storage.aws_ebs_volume.data[0]", Message: "module.storage.aws_ebs_volume.data[0] has disallowed cost component \"Storage (general purpose SSD, GP2)\""},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			actual := CheckPolicies(r, []*config.Policy{tt.policy})
+			assert.Equal(t, tt.expected, actual)
+		})
+	}
+}
+
+func TestResourceType(t *testing.T) {
+	assert.Equal(t, "aws_instance", resourceType("aws_instance.web"))
+	assert.Equal(t, "aws_instance", resourceType("module.app.aws_instance.web[0]"))
+	assert.Equal(t, "aws_instance", resourceType(`aws_instance.web["a.b"]`))
+	assert.Equal(t, "", resourceType("web"))
+}
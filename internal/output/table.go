@@ -50,6 +50,10 @@ func ToTable(out Root, opts Options) ([]byte, error) {
 
 		s += tableOut
 
+		if project.Budget != nil {
+			s += fmt.Sprintf("\n\n %s %s", ui.BoldString("Budget:"), formatBudget(out.Currency, project.Budget))
+		}
+
 		s += "\n"
 
 		if i != len(out.Projects)-1 {
@@ -69,6 +73,42 @@ func ToTable(out Root, opts Options) ([]byte, error) {
 		fmt.Sprintf("%*s ", tableLen-(len(overallTitle)+1), totalOut), // pad based on the last line length
 	)
 
+	if includeProjectTotals && out.Budget != nil {
+		budgetOut := formatBudget(out.Currency, out.Budget)
+		budgetTitle := " OVERALL BUDGET"
+
+		// Pad based on the length without colors since the budget can contain colored flags
+		padding := tableLen - (len(budgetTitle) + 1) - len(ui.StripColor(budgetOut))
+		if padding < 1 {
+			padding = 1
+		}
+
+		s += fmt.Sprintf("\n%s%s%s ",
+			ui.BoldString(budgetTitle),
+			strings.Repeat(" ", padding),
+			budgetOut,
+		)
+	}
+
+	if len(out.Groups) > 0 {
+		s += "\n\n" + tableForGroups(out.Currency, out.GroupBy, out.Groups)
+	}
+
+	if len(out.UsageProfiles) > 0 {
+		s += fmt.Sprintf("\n\n%s\n\n%s",
+			ui.BoldString("Monthly cost by usage profile"),
+			tableForUsageProfiles(out.Currency, out.UsageProfiles, false),
+		)
+	}
+
+	if len(out.TagPolicyViolations) > 0 {
+		s += "\n\n" + tableForTagPolicyViolations(out.Currency, out.TagPolicyViolations)
+	}
+
+	if len(out.ErroredProjects) > 0 {
+		s += "\n\n" + textForErroredProjects(out.ErroredProjects, opts.DashboardEnabled)
+	}
+
 	unsupportedMsg := out.unsupportedResourcesMessage(opts.ShowSkipped)
 
 	if hasNilCosts || unsupportedMsg != "" {
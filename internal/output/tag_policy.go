@@ -0,0 +1,224 @@
+package output
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/jedib0t/go-pretty/v6/table"
+	"github.com/jedib0t/go-pretty/v6/text"
+	"github.com/shopspring/decimal"
+
+	"github.com/infracost/infracost/internal/config"
+	"github.com/infracost/infracost/internal/ui"
+)
+
+// TagPolicyViolation is a billable resource that is missing required tags or has
+// disallowed tag values.
+type TagPolicyViolation struct {
+	Policy           string            `json:"policy"`
+	Project          string            `json:"project"`
+	Resource         string            `json:"resource"`
+	MissingKeys      []string          `json:"missingKeys,omitempty"`
+	DisallowedValues map[string]string `json:"disallowedValues,omitempty"`
+	MonthlyCost      *decimal.Decimal  `json:"monthlyCost"`
+	Enforced         bool              `json:"enforced"`
+}
+
+// TagPolicyError is returned when resources violate tag policies that are enforced.
+type TagPolicyError struct {
+	Currency   string
+	Violations []TagPolicyViolation
+}
+
+func (e *TagPolicyError) Error() string {
+	resources := violatingResources(e.Violations)
+
+	s := "Tag policy check failed"
+	if len(resources) == 1 {
+		s += ", 1 resource"
+	} else {
+		s += fmt.Sprintf(", %d resources", len(resources))
+	}
+
+	return s + fmt.Sprintf(" with a monthly cost of %s violate enforced tag policies",
+		formatCost2DP(e.Currency, monthlyCostAtStake(e.Violations)),
+	)
+}
+
+// CheckTagPolicies checks the tags of the billable resources in the output against
+// the tag policies and returns any violations, in the order of the policies.
+func CheckTagPolicies(r Root, policies []*config.TagPolicy) []TagPolicyViolation {
+	violations := make([]TagPolicyViolation, 0)
+
+	for _, policy := range policies {
+		for _, project := range r.Projects {
+			if project.Breakdown == nil {
+				continue
+			}
+
+			for _, resource := range project.Breakdown.Resources {
+				if !isBillable(resource) {
+					continue
+				}
+
+				if len(policy.ResourceTypes) > 0 && !contains(policy.ResourceTypes, resourceType(resource.Name)) {
+					continue
+				}
+
+				missingKeys, disallowedValues := checkResourceTags(resource.Tags, policy)
+				if len(missingKeys) == 0 && len(disallowedValues) == 0 {
+					continue
+				}
+
+				violations = append(violations, TagPolicyViolation{
+					Policy:           policy.Name,
+					Project:          project.Name,
+					Resource:         resource.Name,
+					MissingKeys:      missingKeys,
+					DisallowedValues: disallowedValues,
+					MonthlyCost:      resource.MonthlyCost,
+					Enforced:         policy.Enforce,
+				})
+			}
+		}
+	}
+
+	return violations
+}
+
+// EnforcedTagPolicyViolations returns the violations of tag policies that are enforced.
+func EnforcedTagPolicyViolations(violations []TagPolicyViolation) []TagPolicyViolation {
+	enforced := make([]TagPolicyViolation, 0)
+
+	for _, v := range violations {
+		if v.Enforced {
+			enforced = append(enforced, v)
+		}
+	}
+
+	return enforced
+}
+
+func checkResourceTags(tags map[string]string, policy *config.TagPolicy) ([]string, map[string]string) {
+	var missingKeys []string
+	for _, k := range policy.RequiredKeys {
+		if v, ok := tags[k]; !ok || v == "" {
+			missingKeys = append(missingKeys, k)
+		}
+	}
+
+	var disallowedValues map[string]string
+	for k, values := range policy.DisallowedValues {
+		v, ok := tags[k]
+		if !ok || !contains(values, v) {
+			continue
+		}
+
+		if disallowedValues == nil {
+			disallowedValues = make(map[string]string)
+		}
+		disallowedValues[k] = v
+	}
+
+	return missingKeys, disallowedValues
+}
+
+// isBillable returns true if the resource, or any of its sub-resources, has cost
+// components. Resources that are free or not supported have none.
+func isBillable(r Resource) bool {
+	if len(r.CostComponents) > 0 {
+		return true
+	}
+
+	for _, s := range r.SubResources {
+		if isBillable(s) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// violatingResources returns the project and resource names of the violations
+// without duplicates, since a resource can violate multiple policies.
+func violatingResources(violations []TagPolicyViolation) map[string]TagPolicyViolation {
+	resources := make(map[string]TagPolicyViolation)
+
+	for _, v := range violations {
+		resources[v.Project+"/"+v.Resource] = v
+	}
+
+	return resources
+}
+
+// monthlyCostAtStake returns the total monthly cost of the resources that violate
+// the tag policies, only counting each resource once.
+func monthlyCostAtStake(violations []TagPolicyViolation) *decimal.Decimal {
+	total := decimalPtr(decimal.Zero)
+
+	for _, v := range violatingResources(violations) {
+		total = addDecimals(total, v.MonthlyCost)
+	}
+
+	return total
+}
+
+func tableForTagPolicyViolations(currency string, violations []TagPolicyViolation) string {
+	resources := violatingResources(violations)
+
+	resourcesLabel := "1 resource"
+	if len(resources) != 1 {
+		resourcesLabel = fmt.Sprintf("%d resources", len(resources))
+	}
+
+	s := fmt.Sprintf("%s %s with a monthly cost of %s\n\n",
+		ui.BoldString("Tag policy violations:"),
+		resourcesLabel,
+		formatCost2DP(currency, monthlyCostAtStake(violations)),
+	)
+
+	t := table.NewWriter()
+	t.Style().Options.DrawBorder = false
+	t.Style().Options.SeparateColumns = false
+	t.Style().Options.SeparateRows = false
+	t.Style().Options.SeparateHeader = false
+	t.Style().Format.Header = text.FormatDefault
+
+	t.AppendHeader(table.Row{
+		ui.UnderlineString("Resource"),
+		ui.UnderlineString("Missing tags"),
+		ui.UnderlineString("Disallowed tags"),
+		ui.UnderlineString(formatTitleWithCurrency("Monthly Cost", currency)),
+	})
+
+	t.SetColumnConfigs([]table.ColumnConfig{
+		{Number: 1, Align: text.AlignLeft, AlignHeader: text.AlignLeft},
+		{Number: 2, Align: text.AlignLeft, AlignHeader: text.AlignLeft},
+		{Number: 3, Align: text.AlignLeft, AlignHeader: text.AlignLeft},
+		{Number: 4, Align: text.AlignRight, AlignHeader: text.AlignRight},
+	})
+
+	policy := ""
+	for _, v := range violations {
+		if v.Policy != policy {
+			policy = v.Policy
+
+			label := policy
+			if v.Enforced {
+				label += " (enforced)"
+			}
+
+			t.AppendRow(table.Row{""})
+			t.AppendRow(table.Row{ui.BoldString(label)})
+		}
+
+		t.AppendRow(table.Row{
+			fmt.Sprintf("%s %s", v.Resource, ui.FaintStringf("(%s)", v.Project)),
+			strings.Join(v.MissingKeys, ", "),
+			formatTags(v.DisallowedValues),
+			formatCost2DP(currency, v.MonthlyCost),
+		})
+	}
+
+	return s + t.Render()
+}
@@ -0,0 +1,61 @@
+package output
+
+import (
+	"testing"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/infracost/infracost/internal/config"
+)
+
+func TestCheckTagPolicies(t *testing.T) {
+	costComponents := []CostComponent{{Name: "Instance usage"}}
+
+	r := Root{
+		Currency: "USD",
+		Projects: []Project{
+			{
+				Name: "infra",
+				Breakdown: &Breakdown{
+					Resources: []Resource{
+						{Name: "aws_instance.web", Tags: map[string]string{"team": "web", "env": "prod"}, CostComponents: costComponents, MonthlyCost: decimalPtr(decimal.NewFromInt(100))},
+						{Name: "aws_instance.test", Tags: map[string]string{"team": "web", "env": "test"}, CostComponents: costComponents, MonthlyCost: decimalPtr(decimal.NewFromInt(50))},
+						{Name: "aws_instance.untagged", SubResources: []Resource{{Name: "root_block_device", CostComponents: costComponents}}, MonthlyCost: decimalPtr(decimal.NewFromInt(20))},
+						{Name: "aws_db_instance.db", Tags: map[string]string{"team": ""}, CostComponents: costComponents, MonthlyCost: decimalPtr(decimal.NewFromInt(300))},
+						{Name: "aws_iam_role.free"},
+					},
+				},
+			},
+		},
+	}
+
+	policies := []*config.TagPolicy{
+		{Name: "Team tag", RequiredKeys: []string{"team"}, Enforce: true},
+		{Name: "No test instances", DisallowedValues: map[string][]string{"env": {"test", "dev"}}, ResourceTypes: []string{"aws_instance"}},
+	}
+
+	violations := CheckTagPolicies(r, policies)
+
+	assert.Equal(t, []TagPolicyViolation{
+		{Policy: "Team tag", Project: "infra", Resource: "aws_instance.untagged", MissingKeys: []string{"team"}, MonthlyCost: decimalPtr(decimal.NewFromInt(20)), Enforced: true},
+		{Policy: "Team tag", Project: "infra", Resource: "aws_db_instance.db", MissingKeys: []string{"team"}, MonthlyCost: decimalPtr(decimal.NewFromInt(300)), Enforced: true},
+		{Policy: "No test instances", Project: "infra", Resource: "aws_instance.test", DisallowedValues: map[string]string{"env": "test"}, MonthlyCost: decimalPtr(decimal.NewFromInt(50))},
+	}, violations)
+
+	enforced := EnforcedTagPolicyViolations(violations)
+	assert.Len(t, enforced, 2)
+
+	err := &TagPolicyError{Currency: "USD", Violations: violations}
+	assert.Equal(t, "Tag policy check failed, 3 resources with a monthly cost of $370.00 violate enforced tag policies", err.Error())
+}
+
+func TestMonthlyCostAtStakeCountsResourcesOnce(t *testing.T) {
+	violations := []TagPolicyViolation{
+		{Policy: "a", Project: "infra", Resource: "aws_instance.web", MonthlyCost: decimalPtr(decimal.NewFromInt(100))},
+		{Policy: "b", Project: "infra", Resource: "aws_instance.web", MonthlyCost: decimalPtr(decimal.NewFromInt(100))},
+		{Policy: "b", Project: "other", Resource: "aws_instance.web", MonthlyCost: decimalPtr(decimal.NewFromInt(10))},
+	}
+
+	assert.True(t, decimal.NewFromInt(110).Equal(*monthlyCostAtStake(violations)))
+}
@@ -100,6 +100,17 @@ table.overall-total tr.total td {
 .usage-cost {
   color: #6b7280;
 }
+{{- if .Root.ErroredProjects}}
+
+.errored-projects {
+  margin-top: 1.5rem;
+}
+
+table.errored-projects td.error {
+  color: #b91c1c;
+  font-family: monospace;
+}
+{{- end}}
 
 @media screen and (max-width: 1024px) {
   table.breakdown, table.overall-total {
@@ -247,7 +258,7 @@ iVBORw0KGgoAAAANSUhEUgAAAMAAAADACAMAAABlApw1AAAABGdBTUEAALGPC/xhBQAAAAFzUkdCAK7O
   <head>
     <title>Infracost cost report</title>
     <style>
-      {{template "style"}}
+      {{template "style" .}}
     </style>
     <link id="favicon" rel="shortcut icon" type="image/png" href="data:image/png;base64,{{template "faviconBase64"}}">
   </head>
@@ -281,6 +292,28 @@ iVBORw0KGgoAAAANSUhEUgAAAMAAAADACAMAAABlApw1AAAABGdBTUEAALGPC/xhBQAAAAFzUkdCAK7O
         </tr>
       </tbody>
     </table>
+    {{- if .Root.ErroredProjects}}
+
+      <div class="errored-projects">
+        <p class="project-name">Errored projects: {{len .Root.ErroredProjects | erroredProjectsMessage}}</p>
+        <table class="errored-projects">
+          <thead>
+            <tr>
+              <td class="name">Project</td>
+              <td class="error">Error</td>
+            </tr>
+          </thead>
+          <tbody>
+            {{range .Root.ErroredProjects}}
+              <tr>
+                <td class="name">{{. | erroredProjectLabel}}</td>
+                <td class="error">{{.Error | replaceNewLines}}</td>
+              </tr>
+            {{end}}
+          </tbody>
+        </table>
+      </div>
+    {{- end}}
 
     <div class="warnings">
       <p>{{.UnsupportedResourcesMessage | replaceNewLines}}</p>
@@ -0,0 +1,162 @@
+package output
+
+import (
+	"github.com/jedib0t/go-pretty/v6/table"
+	"github.com/jedib0t/go-pretty/v6/text"
+	"github.com/shopspring/decimal"
+
+	"github.com/infracost/infracost/internal/ui"
+)
+
+// UsageProfileCost is the total cost of the projects with the usage values of a
+// profile in the usage file, e.g. for low or peak traffic.
+type UsageProfileCost struct {
+	Name                 string                `json:"name"`
+	Projects             []UsageProfileProject `json:"projects"`
+	TotalHourlyCost      *decimal.Decimal      `json:"totalHourlyCost"`
+	TotalMonthlyCost     *decimal.Decimal      `json:"totalMonthlyCost"`
+	DiffTotalMonthlyCost *decimal.Decimal      `json:"diffTotalMonthlyCost"`
+}
+
+// UsageProfileProject is the cost of a project with the usage values of a profile.
+type UsageProfileProject struct {
+	Name                 string           `json:"name"`
+	TotalHourlyCost      *decimal.Decimal `json:"totalHourlyCost"`
+	TotalMonthlyCost     *decimal.Decimal `json:"totalMonthlyCost"`
+	DiffTotalMonthlyCost *decimal.Decimal `json:"diffTotalMonthlyCost"`
+}
+
+// UsageProfileCosts returns the totals of the output for the projects that were
+// loaded with the usage values of the profile.
+func UsageProfileCosts(name string, r Root) UsageProfileCost {
+	c := UsageProfileCost{
+		Name:                 name,
+		Projects:             make([]UsageProfileProject, 0, len(r.Projects)),
+		TotalHourlyCost:      r.TotalHourlyCost,
+		TotalMonthlyCost:     r.TotalMonthlyCost,
+		DiffTotalMonthlyCost: r.DiffTotalMonthlyCost,
+	}
+
+	for _, project := range r.Projects {
+		p := UsageProfileProject{Name: project.Name}
+
+		if project.Breakdown != nil {
+			p.TotalHourlyCost = project.Breakdown.TotalHourlyCost
+			p.TotalMonthlyCost = project.Breakdown.TotalMonthlyCost
+		}
+
+		if project.Diff != nil {
+			p.DiffTotalMonthlyCost = project.Diff.TotalMonthlyCost
+		}
+
+		c.Projects = append(c.Projects, p)
+	}
+
+	return c
+}
+
+// tableForUsageProfiles shows the costs of the projects for each profile side by
+// side, or the cost changes if diff is true.
+func tableForUsageProfiles(currency string, profiles []UsageProfileCost, diff bool) string {
+	t := table.NewWriter()
+	t.Style().Options.DrawBorder = false
+	t.Style().Options.SeparateColumns = false
+	t.Style().Options.SeparateRows = false
+	t.Style().Options.SeparateHeader = false
+	t.Style().Format.Header = text.FormatDefault
+
+	header := table.Row{ui.UnderlineString("Project")}
+	columnConfigs := []table.ColumnConfig{
+		{Number: 1, Align: text.AlignLeft, AlignHeader: text.AlignLeft},
+	}
+
+	for i, profile := range profiles {
+		header = append(header, ui.UnderlineString(formatTitleWithCurrency(profile.Name, currency)))
+		columnConfigs = append(columnConfigs, table.ColumnConfig{Number: i + 2, Align: text.AlignRight, AlignHeader: text.AlignRight})
+	}
+
+	t.AppendHeader(header)
+	t.SetColumnConfigs(columnConfigs)
+
+	t.AppendRow(table.Row{""})
+
+	format := func(monthlyCost *decimal.Decimal, diffMonthlyCost *decimal.Decimal) string {
+		if diff {
+			if diffMonthlyCost == nil {
+				return "-"
+			}
+			return formatCostChange(currency, diffMonthlyCost)
+		}
+
+		return formatCost2DP(currency, monthlyCost)
+	}
+
+	// The projects are matched by name since a project can fail to load, or load
+	// different modules, with the usage of one of the profiles
+	keys, profileProjects := usageProfileProjectsByKey(profiles)
+
+	for _, key := range keys {
+		row := table.Row{key.name}
+
+		for i := range profiles {
+			p, ok := profileProjects[i][key]
+			if !ok {
+				row = append(row, "-")
+				continue
+			}
+
+			row = append(row, format(p.TotalMonthlyCost, p.DiffTotalMonthlyCost))
+		}
+
+		t.AppendRow(row)
+	}
+
+	if len(keys) > 1 {
+		row := table.Row{ui.BoldString("Total")}
+		for _, profile := range profiles {
+			row = append(row, format(profile.TotalMonthlyCost, profile.DiffTotalMonthlyCost))
+		}
+
+		t.AppendRow(table.Row{""})
+		t.AppendRow(row)
+	}
+
+	return t.Render()
+}
+
+// usageProfileProjectKey identifies a project across the profiles. Projects can
+// have the same name, e.g. when a path is used with different workspaces, so the
+// occurrence of the name is part of the key.
+type usageProfileProjectKey struct {
+	name       string
+	occurrence int
+}
+
+// usageProfileProjectsByKey returns the keys of the projects of all the profiles
+// in the order they're first seen, and the projects of each profile by key.
+func usageProfileProjectsByKey(profiles []UsageProfileCost) ([]usageProfileProjectKey, []map[usageProfileProjectKey]UsageProfileProject) {
+	keys := make([]usageProfileProjectKey, 0)
+	seen := make(map[usageProfileProjectKey]bool)
+	profileProjects := make([]map[usageProfileProjectKey]UsageProfileProject, 0, len(profiles))
+
+	for _, profile := range profiles {
+		projects := make(map[usageProfileProjectKey]UsageProfileProject, len(profile.Projects))
+		occurrences := make(map[string]int)
+
+		for _, p := range profile.Projects {
+			key := usageProfileProjectKey{name: p.Name, occurrence: occurrences[p.Name]}
+			occurrences[p.Name]++
+
+			projects[key] = p
+
+			if !seen[key] {
+				seen[key] = true
+				keys = append(keys, key)
+			}
+		}
+
+		profileProjects = append(profileProjects, projects)
+	}
+
+	return keys, profileProjects
+}
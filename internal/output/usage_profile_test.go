@@ -0,0 +1,105 @@
+package output
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/infracost/infracost/internal/ui"
+)
+
+func TestUsageProfileCosts(t *testing.T) {
+	r := Root{
+		Projects: []Project{
+			{
+				Name: "infracost/infracost/examples/app",
+				Breakdown: &Breakdown{
+					TotalHourlyCost:  decimalPtr(decimal.NewFromFloat(0.5)),
+					TotalMonthlyCost: decimalPtr(decimal.NewFromInt(365)),
+				},
+				Diff: &Breakdown{
+					TotalMonthlyCost: decimalPtr(decimal.NewFromInt(65)),
+				},
+			},
+			{
+				Name: "infracost/infracost/examples/db",
+			},
+		},
+		TotalHourlyCost:      decimalPtr(decimal.NewFromFloat(0.5)),
+		TotalMonthlyCost:     decimalPtr(decimal.NewFromInt(365)),
+		DiffTotalMonthlyCost: decimalPtr(decimal.NewFromInt(65)),
+	}
+
+	expected := UsageProfileCost{
+		Name: "peak",
+		Projects: []UsageProfileProject{
+			{
+				Name:                 "infracost/infracost/examples/app",
+				TotalHourlyCost:      decimalPtr(decimal.NewFromFloat(0.5)),
+				TotalMonthlyCost:     decimalPtr(decimal.NewFromInt(365)),
+				DiffTotalMonthlyCost: decimalPtr(decimal.NewFromInt(65)),
+			},
+			{
+				Name: "infracost/infracost/examples/db",
+			},
+		},
+		TotalHourlyCost:      decimalPtr(decimal.NewFromFloat(0.5)),
+		TotalMonthlyCost:     decimalPtr(decimal.NewFromInt(365)),
+		DiffTotalMonthlyCost: decimalPtr(decimal.NewFromInt(65)),
+	}
+
+	assert.Equal(t, expected, UsageProfileCosts("peak", r))
+}
+
+func TestTableForUsageProfiles(t *testing.T) {
+	profiles := []UsageProfileCost{
+		{
+			Name: "low",
+			Projects: []UsageProfileProject{
+				{Name: "app", TotalMonthlyCost: decimalPtr(decimal.NewFromInt(10)), DiffTotalMonthlyCost: decimalPtr(decimal.NewFromInt(5))},
+				{Name: "db", TotalMonthlyCost: decimalPtr(decimal.NewFromInt(100)), DiffTotalMonthlyCost: decimalPtr(decimal.Zero)},
+			},
+			TotalMonthlyCost:     decimalPtr(decimal.NewFromInt(110)),
+			DiffTotalMonthlyCost: decimalPtr(decimal.NewFromInt(5)),
+		},
+		{
+			Name: "peak",
+			Projects: []UsageProfileProject{
+				{Name: "cache", TotalMonthlyCost: decimalPtr(decimal.NewFromInt(50)), DiffTotalMonthlyCost: decimalPtr(decimal.NewFromInt(-520))},
+				{Name: "app", TotalMonthlyCost: decimalPtr(decimal.NewFromInt(1000)), DiffTotalMonthlyCost: decimalPtr(decimal.NewFromInt(500))},
+			},
+			TotalMonthlyCost:     decimalPtr(decimal.NewFromInt(1050)),
+			DiffTotalMonthlyCost: decimalPtr(decimal.NewFromInt(-20)),
+		},
+	}
+
+	lines := func(s string) []string {
+		var l []string
+		for _, line := range strings.Split(ui.StripColor(s), "\n") {
+			l = append(l, strings.Join(strings.Fields(line), " "))
+		}
+		return l
+	}
+
+	assert.Equal(t, []string{
+		"Project low peak",
+		"",
+		"app $10.00 $1,000.00",
+		"db $100.00 -",
+		"cache - $50.00",
+		"",
+		"Total $110.00 $1,050.00",
+	}, lines(tableForUsageProfiles("USD", profiles, false)))
+
+	assert.Equal(t, []string{
+		"Project low (EUR) peak (EUR)",
+		"",
+		"app +€5.00 +€500",
+		"db €0.00 -",
+		"cache - -€520",
+		"",
+		"Total +€5.00 -€20.00",
+	}, lines(tableForUsageProfiles("EUR", profiles, true)))
+}
@@ -1,8 +1,6 @@
 package prices
 
 import (
-	"runtime"
-
 	"github.com/infracost/infracost/internal/apiclient"
 	"github.com/infracost/infracost/internal/config"
 	"github.com/infracost/infracost/internal/schema"
@@ -12,66 +10,38 @@ import (
 	"github.com/tidwall/gjson"
 )
 
-func PopulatePrices(cfg *config.Config, project *schema.Project) error {
-	resources := project.AllResources()
+// PopulatePrices gets the prices for the resources of all the projects. The
+// projects are priced together so queries that are shared between them are
+// only sent to the Cloud Pricing API once.
+func PopulatePrices(ctx *config.RunContext, projects []*schema.Project) error {
+	resources := make([]*schema.Resource, 0)
+	for _, project := range projects {
+		resources = append(resources, project.AllResources()...)
+	}
 
-	c := apiclient.NewPricingAPIClient(cfg)
+	c := apiclient.NewPricingAPIClient(ctx.Config)
 
-	err := GetPricesConcurrent(c, resources)
+	err := GetPrices(c, resources)
+	if c.Cache != nil {
+		trackPriceCacheStats(ctx, c.Cache)
+	}
 	if err != nil {
 		return err
 	}
 	return nil
 }
 
-// GetPricesConcurrent gets the prices of all resources concurrently.
-// Concurrency level is calculated using the following formula:
-// max(min(4, numCPU * 4), 16)
-func GetPricesConcurrent(c *apiclient.PricingAPIClient, resources []*schema.Resource) error {
-	// Set the number of workers
-	numWorkers := 4
-	numCPU := runtime.NumCPU()
-	if numCPU*4 > numWorkers {
-		numWorkers = numCPU * 4
-	}
-	if numWorkers > 16 {
-		numWorkers = 16
-	}
-	numJobs := len(resources)
-	jobs := make(chan *schema.Resource, numJobs)
-	resultErrors := make(chan error, numJobs)
-
-	// Fire up the workers
-	for i := 0; i < numWorkers; i++ {
-		go func(jobs <-chan *schema.Resource, resultErrors chan<- error) {
-			for r := range jobs {
-				err := GetPrices(c, r)
-				resultErrors <- err
-			}
-		}(jobs, resultErrors)
-	}
-
-	// Feed the workers the jobs of getting prices
-	for _, r := range resources {
-		jobs <- r
-	}
-
-	// Get the result of the jobs
-	for i := 0; i < numJobs; i++ {
-		err := <-resultErrors
-		if err != nil {
-			return err
-		}
-	}
-	return nil
+// trackPriceCacheStats adds the cache hits and misses to the run context so
+// they are included in the run event.
+func trackPriceCacheStats(ctx *config.RunContext, cache *apiclient.PriceCache) {
+	hits, misses := cache.Stats()
+	ctx.SetContextValue("priceCacheHits", hits)
+	ctx.SetContextValue("priceCacheMisses", misses)
 }
 
-func GetPrices(c *apiclient.PricingAPIClient, r *schema.Resource) error {
-	if r.IsSkipped {
-		return nil
-	}
-
-	results, err := c.RunQueries(r)
+// GetPrices gets the prices of all the resources and sets them on their cost components.
+func GetPrices(c *apiclient.PricingAPIClient, resources []*schema.Resource) error {
+	results, err := c.RunQueries(resources)
 	if err != nil {
 		return err
 	}
@@ -0,0 +1,55 @@
+package aws
+
+import (
+	"strconv"
+
+	"github.com/awslabs/goformation/v4/cloudformation/rds"
+	"github.com/infracost/infracost/internal/resources/aws"
+	"github.com/infracost/infracost/internal/schema"
+	log "github.com/sirupsen/logrus"
+)
+
+func GetDBInstanceRegistryItem() *schema.RegistryItem {
+	return &schema.RegistryItem{
+		Name:  "AWS::RDS::DBInstance",
+		RFunc: NewDBInstance,
+	}
+}
+
+func NewDBInstance(d *schema.ResourceData, u *schema.UsageData) *schema.Resource {
+	cfr, ok := d.CFResource.(*rds.DBInstance)
+	if !ok {
+		log.Warnf("Skipping resource %s as it did not have the expected type (got %T)", d.Address, d.CFResource)
+		return nil
+	}
+
+	a := &aws.DBInstance{
+		Address:       d.Address,
+		Region:        d.Get("region").String(),
+		InstanceClass: cfr.DBInstanceClass,
+		Engine:        cfr.Engine,
+		MultiAZ:       cfr.MultiAZ,
+		LicenseModel:  cfr.LicenseModel,
+		StorageType:   cfr.StorageType,
+		IOPS:          float64(cfr.Iops),
+	}
+
+	// CloudFormation defaults the storage type to io1 when Iops is set
+	if a.StorageType == "" && a.IOPS > 0 {
+		a.StorageType = "io1"
+	}
+
+	// AllocatedStorage is a string in the CloudFormation spec
+	if cfr.AllocatedStorage != "" {
+		allocatedStorage, err := strconv.ParseFloat(cfr.AllocatedStorage, 64)
+		if err != nil {
+			log.Warnf("Invalid AllocatedStorage %s for resource %s", cfr.AllocatedStorage, d.Address)
+		} else {
+			a.AllocatedStorageGB = floatPtr(allocatedStorage)
+		}
+	}
+
+	a.PopulateUsage(u)
+
+	return a.BuildResource()
+}
@@ -0,0 +1,94 @@
+package aws
+
+import (
+	"fmt"
+
+	"github.com/awslabs/goformation/v4/cloudformation/ec2"
+	"github.com/infracost/infracost/internal/resources/aws"
+	"github.com/infracost/infracost/internal/schema"
+	log "github.com/sirupsen/logrus"
+)
+
+// rootDeviceNames are the root device names of the Amazon Linux, Ubuntu and
+// Windows AMIs. The root device of other AMIs can't be worked out from the
+// template, so their block device mappings are treated as additional volumes.
+var rootDeviceNames = map[string]bool{
+	"/dev/xvda": true,
+	"/dev/sda1": true,
+}
+
+func GetInstanceRegistryItem() *schema.RegistryItem {
+	return &schema.RegistryItem{
+		Name: "AWS::EC2::Instance",
+		Notes: []string{
+			"Costs associated with marketplace AMIs are not supported.",
+			"For non-standard Linux AMIs such as Windows and RHEL, the operating system should be specified in usage file.",
+			"EC2 detailed monitoring assumes the standard 7 metrics and the lowest tier of prices for CloudWatch.",
+			"If a root volume is not specified then an 8Gi gp2 volume is assumed.",
+		},
+		RFunc: NewInstance,
+	}
+}
+
+func NewInstance(d *schema.ResourceData, u *schema.UsageData) *schema.Resource {
+	cfr, ok := d.CFResource.(*ec2.Instance)
+	if !ok {
+		log.Warnf("Skipping resource %s as it did not have the expected type (got %T)", d.Address, d.CFResource)
+		return nil
+	}
+
+	region := d.Get("region").String()
+
+	var cpuCredits string
+	if cfr.CreditSpecification != nil {
+		cpuCredits = cfr.CreditSpecification.CPUCredits
+	}
+
+	a := &aws.Instance{
+		Address:          d.Address,
+		Region:           region,
+		Tenancy:          cfr.Tenancy,
+		PurchaseOption:   "on_demand",
+		AMI:              cfr.ImageId,
+		InstanceType:     cfr.InstanceType,
+		EBSOptimized:     cfr.EbsOptimized,
+		EnableMonitoring: cfr.Monitoring,
+		CPUCredits:       cpuCredits,
+		RootBlockDevice: &aws.EBSVolume{
+			Address: "root_block_device",
+			Region:  region,
+		},
+	}
+
+	for _, m := range cfr.BlockDeviceMappings {
+		if m.Ebs == nil {
+			continue
+		}
+
+		if rootDeviceNames[m.DeviceName] {
+			a.RootBlockDevice = newInstanceVolume("root_block_device", region, m.Ebs)
+			continue
+		}
+
+		a.EBSBlockDevices = append(a.EBSBlockDevices, newInstanceVolume(fmt.Sprintf("ebs_block_device[%d]", len(a.EBSBlockDevices)), region, m.Ebs))
+	}
+
+	a.PopulateUsage(u)
+
+	return a.BuildResource()
+}
+
+func newInstanceVolume(address string, region string, ebs *ec2.Instance_Ebs) *aws.EBSVolume {
+	v := &aws.EBSVolume{
+		Address: address,
+		Region:  region,
+		Type:    ebs.VolumeType,
+		IOPS:    int64(ebs.Iops),
+	}
+
+	if ebs.VolumeSize != 0 {
+		v.Size = intPtr(int64(ebs.VolumeSize))
+	}
+
+	return v
+}
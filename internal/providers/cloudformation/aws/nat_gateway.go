@@ -0,0 +1,31 @@
+package aws
+
+import (
+	"github.com/awslabs/goformation/v4/cloudformation/ec2"
+	"github.com/infracost/infracost/internal/resources/aws"
+	"github.com/infracost/infracost/internal/schema"
+	log "github.com/sirupsen/logrus"
+)
+
+func GetNATGatewayRegistryItem() *schema.RegistryItem {
+	return &schema.RegistryItem{
+		Name:  "AWS::EC2::NatGateway",
+		RFunc: NewNATGateway,
+	}
+}
+
+func NewNATGateway(d *schema.ResourceData, u *schema.UsageData) *schema.Resource {
+	_, ok := d.CFResource.(*ec2.NatGateway)
+	if !ok {
+		log.Warnf("Skipping resource %s as it did not have the expected type (got %T)", d.Address, d.CFResource)
+		return nil
+	}
+
+	a := &aws.NATGateway{
+		Address: d.Address,
+		Region:  d.Get("region").String(),
+	}
+	a.PopulateUsage(u)
+
+	return a.BuildResource()
+}
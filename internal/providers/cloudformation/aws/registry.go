@@ -20,7 +20,7 @@ var ResourceRegistry []*schema.RegistryItem = []*schema.RegistryItem{
 	// GetConfigOrganizationCustomRuleItem(),
 	// GetConfigOrganizationManagedRuleItem(),
 	// GetDataTransferRegistryItem(),
-	// GetDBInstanceRegistryItem(),
+	GetDBInstanceRegistryItem(),
 	// GetDMSRegistryItem(),
 	// GetDocDBClusterInstanceRegistryItem(),
 	// GetDocDBClusterRegistryItem(),
@@ -30,7 +30,7 @@ var ResourceRegistry []*schema.RegistryItem = []*schema.RegistryItem{
 	GetDynamoDBTableRegistryItem(),
 	// GetEBSSnapshotCopyRegistryItem(),
 	// GetEBSSnapshotRegistryItem(),
-	// GetEBSVolumeRegistryItem(),
+	GetEBSVolumeRegistryItem(),
 	// GetEC2ClientVPNEndpointRegistryItem(),
 	// GetEC2ClientVPNNetworkAssociationRegistryItem(),
 	// GetEC2TrafficMirroSessionRegistryItem(),
@@ -43,16 +43,16 @@ var ResourceRegistry []*schema.RegistryItem = []*schema.RegistryItem{
 	// GetElastiCacheClusterItem(),
 	// GetElastiCacheReplicationGroupItem(),
 	// GetElasticsearchDomainRegistryItem(),
-	// GetELBRegistryItem(),
+	GetELBRegistryItem(),
 	// GetFSXWindowsFSRegistryItem(),
-	// GetInstanceRegistryItem(),
-	// GetLambdaFunctionRegistryItem(),
-	// GetLBRegistryItem(),
+	GetInstanceRegistryItem(),
+	GetLambdaFunctionRegistryItem(),
+	GetLBRegistryItem(),
 	// GetLightsailInstanceRegistryItem(),
 	// GetMSKClusterRegistryItem(),
 	// GetALBRegistryItem(),
 	// GetMQBrokerRegistryItem(),
-	// GetNATGatewayRegistryItem(),
+	GetNATGatewayRegistryItem(),
 	// GetRDSClusterRegistryItem(),
 	// GetRDSClusterInstanceRegistryItem(),
 	// GetRedshiftClusterRegistryItem(),
@@ -60,7 +60,7 @@ var ResourceRegistry []*schema.RegistryItem = []*schema.RegistryItem{
 	// GetRoute53ResolverEndpointRegistryItem(),
 	// GetRoute53RecordRegistryItem(),
 	// GetRoute53ZoneRegistryItem(),
-	// GetS3BucketRegistryItem(),
+	GetS3BucketRegistryItem(),
 	// GetS3BucketAnalyticsConfigurationRegistryItem(),
 	// GetS3BucketInventoryRegistryItem(),
 	// GetSecretsManagerSecret(),
@@ -363,6 +363,31 @@ var FreeResources = []string{
 	"aws_vpn_gateway_attachment",
 	"aws_vpn_gateway_route_propagation",
 
+	// CloudFormation
+	"AWS::CloudFormation::Stack",
+	"AWS::CloudFormation::WaitConditionHandle",
+	"AWS::EC2::InternetGateway",
+	"AWS::EC2::Route",
+	"AWS::EC2::RouteTable",
+	"AWS::EC2::SecurityGroup",
+	"AWS::EC2::SecurityGroupIngress",
+	"AWS::EC2::SecurityGroupEgress",
+	"AWS::EC2::Subnet",
+	"AWS::EC2::SubnetRouteTableAssociation",
+	"AWS::EC2::VolumeAttachment",
+	"AWS::EC2::VPC",
+	"AWS::EC2::VPCGatewayAttachment",
+	"AWS::ElasticLoadBalancingV2::Listener",
+	"AWS::ElasticLoadBalancingV2::ListenerRule",
+	"AWS::ElasticLoadBalancingV2::TargetGroup",
+	"AWS::IAM::InstanceProfile",
+	"AWS::IAM::Policy",
+	"AWS::IAM::Role",
+	"AWS::Lambda::Permission",
+	"AWS::RDS::DBParameterGroup",
+	"AWS::RDS::DBSubnetGroup",
+	"AWS::S3::BucketPolicy",
+
 	// Hashicorp
 	"null_resource",
 	"local_file",
@@ -0,0 +1,190 @@
+package cloudformation
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/awslabs/goformation/v4/cloudformation"
+	cfnstack "github.com/awslabs/goformation/v4/cloudformation/cloudformation"
+	"github.com/pkg/errors"
+)
+
+const cloudAssemblyManifest = "manifest.json"
+
+// assetBucketPlaceholder is used for the S3 bucket parameters of assets, since the
+// bucket is only known when the stack is deployed.
+const assetBucketPlaceholder = "cdk-assets"
+
+// cloudAssembly is the manifest of a cloud assembly synthesized by the AWS CDK,
+// e.g. cdk.out/manifest.json.
+type cloudAssembly struct {
+	Artifacts map[string]artifact `json:"artifacts"`
+}
+
+type artifact struct {
+	Type        string `json:"type"`
+	Environment string `json:"environment"`
+	Properties  struct {
+		TemplateFile  string            `json:"templateFile"`
+		Parameters    map[string]string `json:"parameters"`
+		DirectoryName string            `json:"directoryName"`
+	} `json:"properties"`
+	Metadata map[string][]struct {
+		Type string          `json:"type"`
+		Data json.RawMessage `json:"data"`
+	} `json:"metadata"`
+}
+
+// assetMetadata is the metadata of an asset that's referenced by template
+// parameters, which is added by the legacy stack synthesizer.
+type assetMetadata struct {
+	Path                  string `json:"path"`
+	SourceHash            string `json:"sourceHash"`
+	S3BucketParameter     string `json:"s3BucketParameter"`
+	S3KeyParameter        string `json:"s3KeyParameter"`
+	ArtifactHashParameter string `json:"artifactHashParameter"`
+}
+
+// cdkStack is a stack in a cloud assembly.
+type cdkStack struct {
+	id       string
+	dir      string
+	artifact artifact
+}
+
+// IsCloudAssembly returns true if the path is a cloud assembly directory that
+// contains stacks, e.g. cdk.out.
+func IsCloudAssembly(path string) bool {
+	stacks, err := loadCloudAssembly(path)
+	return err == nil && len(stacks) > 0
+}
+
+// loadCloudAssembly returns the stacks of the cloud assembly in the directory,
+// including the stacks of nested assemblies, e.g. for CDK stages.
+func loadCloudAssembly(dir string) ([]cdkStack, error) {
+	data, err := ioutil.ReadFile(filepath.Join(dir, cloudAssemblyManifest))
+	if err != nil {
+		return nil, err
+	}
+
+	var assembly cloudAssembly
+	if err := json.Unmarshal(data, &assembly); err != nil {
+		return nil, errors.Wrap(err, "Error parsing cloud assembly manifest")
+	}
+
+	ids := make([]string, 0, len(assembly.Artifacts))
+	for id := range assembly.Artifacts {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	var stacks []cdkStack
+
+	for _, id := range ids {
+		a := assembly.Artifacts[id]
+
+		switch a.Type {
+		case "aws:cloudformation:stack":
+			stacks = append(stacks, cdkStack{id: id, dir: dir, artifact: a})
+		case "cdk:cloud-assembly":
+			nested, err := loadCloudAssembly(filepath.Join(dir, a.Properties.DirectoryName))
+			if err != nil {
+				return nil, errors.Wrapf(err, "Error reading nested cloud assembly %s", id)
+			}
+			stacks = append(stacks, nested...)
+		}
+	}
+
+	return stacks, nil
+}
+
+func (s cdkStack) templatePath() string {
+	return filepath.Join(s.dir, s.artifact.Properties.TemplateFile)
+}
+
+// region returns the region of the stack environment, or an empty string if the
+// stack is environment-agnostic.
+func (s cdkStack) region() string {
+	// The environment is in the format aws://account/region
+	parts := strings.Split(strings.TrimPrefix(s.artifact.Environment, "aws://"), "/")
+	if len(parts) != 2 || parts[1] == "unknown-region" {
+		return ""
+	}
+
+	return parts[1]
+}
+
+// parameters returns the parameter values of the stack template. The values of
+// the asset parameters aren't known until the assets are published, so they're
+// set to placeholders that resolve in the same way.
+func (s cdkStack) parameters() map[string]string {
+	parameters := make(map[string]string)
+
+	for _, entries := range s.artifact.Metadata {
+		for _, e := range entries {
+			if e.Type != "aws:cdk:asset" {
+				continue
+			}
+
+			var asset assetMetadata
+			if err := json.Unmarshal(e.Data, &asset); err != nil {
+				continue
+			}
+
+			if asset.S3BucketParameter != "" {
+				parameters[asset.S3BucketParameter] = assetBucketPlaceholder
+			}
+			// The key is split on || into the prefix and the object key
+			if asset.S3KeyParameter != "" {
+				parameters[asset.S3KeyParameter] = "assets/||" + asset.Path
+			}
+			if asset.ArtifactHashParameter != "" {
+				parameters[asset.ArtifactHashParameter] = asset.SourceHash
+			}
+		}
+	}
+
+	for k, v := range s.artifact.Properties.Parameters {
+		parameters[k] = v
+	}
+
+	return parameters
+}
+
+// loadCDKTemplate loads a template synthesized by the CDK, and adds the resources
+// of its nested stacks prefixed with the logical ID of the nested stack.
+func loadCDKTemplate(dir string, file string, parameters map[string]string, region string) (*cloudformation.Template, error) {
+	t, err := LoadTemplate(filepath.Join(dir, file), parameters, region)
+	if err != nil {
+		return nil, err
+	}
+
+	nested := make(map[string]*cfnstack.Stack)
+	for name, r := range t.Resources {
+		if s, ok := r.(*cfnstack.Stack); ok {
+			nested[name] = s
+		}
+	}
+
+	for name, s := range nested {
+		// The nested stack template is an asset of the parent stack
+		path, _ := s.AWSCloudFormationMetadata["aws:asset:path"].(string)
+		if !strings.HasSuffix(path, ".json") {
+			continue
+		}
+
+		n, err := loadCDKTemplate(dir, path, s.Parameters, region)
+		if err != nil {
+			return nil, errors.Wrapf(err, "Error reading nested stack %s", name)
+		}
+
+		for k, r := range n.Resources {
+			t.Resources[name+"."+k] = r
+		}
+	}
+
+	return t, nil
+}
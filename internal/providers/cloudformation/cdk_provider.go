@@ -0,0 +1,93 @@
+package cloudformation
+
+import (
+	"github.com/infracost/infracost/internal/config"
+	"github.com/infracost/infracost/internal/schema"
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+)
+
+type CDKProvider struct {
+	ctx  *config.ProjectContext
+	Path string
+}
+
+func NewCDKProvider(ctx *config.ProjectContext) schema.Provider {
+	return &CDKProvider{
+		ctx:  ctx,
+		Path: ctx.ProjectConfig.Path,
+	}
+}
+
+func (p *CDKProvider) Type() string {
+	return "cdk_cloud_assembly"
+}
+
+func (p *CDKProvider) DisplayType() string {
+	return "AWS CDK cloud assembly"
+}
+
+func (p *CDKProvider) AddMetadata(metadata *schema.ProjectMetadata) {
+	// no op
+}
+
+// LoadResources returns a project for each stack in the cloud assembly, named
+// after the stack ID. A stack that fails to load is still added with its error so
+// the other stacks are costed.
+func (p *CDKProvider) LoadResources(usage map[string]*schema.UsageData) ([]*schema.Project, error) {
+	stacks, err := loadCloudAssembly(p.Path)
+	if err != nil {
+		return []*schema.Project{}, errors.Wrap(err, "Error reading CDK cloud assembly")
+	}
+
+	var parameters map[string]string
+	if p.ctx.ProjectConfig.CloudFormationParametersFile != "" {
+		parameters, err = LoadParameters(p.ctx.ProjectConfig.CloudFormationParametersFile)
+		if err != nil {
+			return []*schema.Project{}, errors.Wrap(err, "Error reading CloudFormation parameters file")
+		}
+	}
+
+	projects := make([]*schema.Project, 0, len(stacks))
+
+	for _, s := range stacks {
+		metadata := config.DetectProjectMetadata(s.templatePath())
+		metadata.Type = p.Type()
+		p.AddMetadata(metadata)
+
+		project := schema.NewProject(s.id, metadata)
+
+		stackParameters := s.parameters()
+		for k, v := range parameters {
+			stackParameters[k] = v
+		}
+
+		parser := NewParser(p.ctx)
+		parser.stackRegion = s.region()
+
+		template, err := loadCDKTemplate(s.dir, s.artifact.Properties.TemplateFile, stackParameters, parser.region())
+		if err != nil {
+			err = errors.Wrap(err, "Error reading CDK stack template")
+			log.Warnf("%sSkipping CDK stack %s: %s", p.ctx.LogPrefix, s.id, err)
+			project.Error = err.Error()
+			projects = append(projects, project)
+			continue
+		}
+
+		pastResources, resources, err := parser.parseTemplate(template, nil, usage)
+		if err != nil {
+			err = errors.Wrap(err, "Error parsing CDK stack template")
+			log.Warnf("%sSkipping CDK stack %s: %s", p.ctx.LogPrefix, s.id, err)
+			project.Error = err.Error()
+			projects = append(projects, project)
+			continue
+		}
+
+		project.PastResources = pastResources
+		project.Resources = resources
+
+		projects = append(projects, project)
+	}
+
+	return projects, nil
+}
@@ -0,0 +1,87 @@
+package cloudformation
+
+import (
+	"testing"
+
+	"github.com/awslabs/goformation/v4/cloudformation/lambda"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/infracost/infracost/internal/config"
+	"github.com/infracost/infracost/internal/schema"
+)
+
+const nestedStackID = "DatabaseNestedStackDatabaseNestedStackResource1A2B3C4D"
+
+func TestLoadCloudAssembly(t *testing.T) {
+	assert.True(t, IsCloudAssembly("testdata/cdk.out"))
+	assert.False(t, IsCloudAssembly("testdata"))
+
+	stacks, err := loadCloudAssembly("testdata/cdk.out")
+	require.NoError(t, err)
+	require.Len(t, stacks, 2)
+
+	assert.Equal(t, "ServiceStack", stacks[0].id)
+	assert.Equal(t, "", stacks[0].region())
+	assert.Equal(t, "testdata/cdk.out/ServiceStack.template.json", stacks[0].templatePath())
+	assert.Equal(t, "assets/||asset.3f4c2a", stacks[0].parameters()["AssetParameters3f4c2aS3VersionKey5E6F7A8B"])
+
+	assert.Equal(t, "ProdWebStack2C9B8F3A", stacks[1].id)
+	assert.Equal(t, "eu-west-1", stacks[1].region())
+	assert.Equal(t, "testdata/cdk.out/assembly-Prod/ProdWebStack2C9B8F3A.template.json", stacks[1].templatePath())
+}
+
+func TestLoadCDKTemplate(t *testing.T) {
+	stacks, err := loadCloudAssembly("testdata/cdk.out")
+	require.NoError(t, err)
+
+	template, err := loadCDKTemplate("testdata/cdk.out", "ServiceStack.template.json", stacks[0].parameters(), "us-east-1")
+	require.NoError(t, err)
+
+	assert.ElementsMatch(t, []string{
+		"Handler886CB40B",
+		"HandlerServiceRoleFCDC14AE",
+		nestedStackID,
+		nestedStackID + ".DatabaseB269D8BB",
+	}, resourceNames(template.Resources))
+
+	fn, ok := template.Resources["Handler886CB40B"].(*lambda.Function)
+	require.True(t, ok)
+	assert.Equal(t, "cdk-assets", fn.Code.S3Bucket)
+	assert.Equal(t, "assets/asset.3f4c2a", fn.Code.S3Key)
+
+	db, err := template.GetRDSDBInstanceWithName(nestedStackID + ".DatabaseB269D8BB")
+	require.NoError(t, err)
+	assert.Equal(t, "db.t3.large", db.DBInstanceClass)
+}
+
+func TestCDKProvider(t *testing.T) {
+	t.Setenv("AWS_REGION", "")
+	t.Setenv("AWS_DEFAULT_REGION", "")
+
+	ctx := config.NewProjectContext(config.EmptyRunContext(), &config.Project{Path: "testdata/cdk.out"})
+
+	projects, err := NewCDKProvider(ctx).LoadResources(map[string]*schema.UsageData{})
+	require.NoError(t, err)
+	require.Len(t, projects, 2)
+
+	service := projects[0]
+	assert.Equal(t, "ServiceStack", service.Name)
+	assert.Empty(t, service.Error)
+	byName := make(map[string]*schema.Resource)
+	for _, r := range service.Resources {
+		byName[r.Name] = r
+	}
+	assert.Equal(t, []string{"Requests", "Duration"}, costComponentNames(byName["Handler886CB40B"]))
+	assert.True(t, byName[nestedStackID].NoPrice)
+	db := byName[nestedStackID+".DatabaseB269D8BB"]
+	require.NotNil(t, db)
+	assert.Equal(t, "Database instance (on-demand, Single-AZ, db.t3.large)", db.CostComponents[0].Name)
+	assert.Equal(t, "us-east-1", *db.CostComponents[0].ProductFilter.Region)
+
+	web := projects[1]
+	assert.Equal(t, "ProdWebStack2C9B8F3A", web.Name)
+	require.Len(t, web.Resources, 1)
+	assert.Equal(t, "eu-west-1", *web.Resources[0].CostComponents[0].ProductFilter.Region)
+	assert.Equal(t, map[string]string{"Name": "Prod/WebStack/WebServer"}, web.Resources[0].Tags)
+}
@@ -0,0 +1,115 @@
+package cloudformation
+
+import (
+	"encoding/json"
+	"io/ioutil"
+
+	"github.com/awslabs/goformation/v4/cloudformation"
+	log "github.com/sirupsen/logrus"
+)
+
+// changeSet is the output of aws cloudformation describe-change-set.
+type changeSet struct {
+	ChangeSetName string      `json:"ChangeSetName"`
+	StackName     string      `json:"StackName"`
+	Parameters    []parameter `json:"Parameters"`
+	Changes       []struct {
+		Type           string         `json:"Type"`
+		ResourceChange resourceChange `json:"ResourceChange"`
+	} `json:"Changes"`
+}
+
+type resourceChange struct {
+	Action            string `json:"Action"`
+	LogicalResourceID string `json:"LogicalResourceId"`
+	ResourceType      string `json:"ResourceType"`
+	// BeforeContext is a JSON string with the properties of the resource before the
+	// change. It's only set when the change set is described with
+	// --include-property-values.
+	BeforeContext string `json:"BeforeContext"`
+}
+
+// loadChangeSet reads a change set from a JSON file. It returns nil if the file
+// isn't a change set, e.g. if it's a template.
+func loadChangeSet(path string) (*changeSet, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var cs changeSet
+	if err := json.Unmarshal(data, &cs); err != nil {
+		return nil, nil
+	}
+
+	if cs.ChangeSetName == "" && cs.Changes == nil {
+		return nil, nil
+	}
+
+	return &cs, nil
+}
+
+// loadPastTemplate returns the template of the stack before the change set is
+// executed, by reverting the resource changes of the change set from the template
+// that the change set was created with.
+func loadPastTemplate(path string, cs *changeSet, parameters map[string]string, region string) (*cloudformation.Template, error) {
+	t, err := readTemplate(path)
+	if err != nil {
+		return nil, err
+	}
+
+	resources := mapValue(t["Resources"])
+	if resources == nil {
+		resources = make(map[string]interface{})
+		t["Resources"] = resources
+	}
+
+	for _, c := range cs.Changes {
+		if c.Type != "Resource" {
+			continue
+		}
+
+		rc := c.ResourceChange
+		before := rc.beforeResource()
+
+		switch rc.Action {
+		case "Add", "Import":
+			delete(resources, rc.LogicalResourceID)
+		case "Modify", "Dynamic":
+			if before == nil {
+				log.Warnf("Using the new template properties for modified CloudFormation resource %s since the change set has no property values, so its cost change is not shown. Use --include-property-values with aws cloudformation describe-change-set to include it", rc.LogicalResourceID)
+				continue
+			}
+			resources[rc.LogicalResourceID] = before
+		case "Remove":
+			if before == nil {
+				log.Warnf("Skipping removed CloudFormation resource %s since the change set has no property values. Use --include-property-values with aws cloudformation describe-change-set to include its cost", rc.LogicalResourceID)
+				continue
+			}
+			resources[rc.LogicalResourceID] = before
+		}
+	}
+
+	return parseTemplateMap(t, parameters, region)
+}
+
+// beforeResource returns the resource before the change, or nil if the change
+// doesn't have the property values.
+func (rc resourceChange) beforeResource() map[string]interface{} {
+	if rc.BeforeContext == "" {
+		return nil
+	}
+
+	var before map[string]interface{}
+	if err := json.Unmarshal([]byte(rc.BeforeContext), &before); err != nil {
+		log.Debugf("Could not parse the before context of CloudFormation resource %s: %s", rc.LogicalResourceID, err)
+		return nil
+	}
+
+	resource := map[string]interface{}{"Type": rc.ResourceType}
+	if props, ok := before["Properties"]; ok {
+		resource["Properties"] = props
+	}
+
+	return resource
+}
@@ -0,0 +1,55 @@
+package cloudformation
+
+import (
+	"testing"
+
+	"github.com/awslabs/goformation/v4/cloudformation/ec2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoadChangeSet(t *testing.T) {
+	cs, err := loadChangeSet("testdata/change_set.json")
+	require.NoError(t, err)
+	require.NotNil(t, cs)
+	assert.Equal(t, "web", cs.StackName)
+	assert.Len(t, cs.Changes, 5)
+
+	parameters, err := parameterList(cs.Parameters)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"Environment": "prod", "InstanceType": "m5.xlarge"}, parameters)
+
+	cs, err = loadChangeSet("testdata/template.yml")
+	require.NoError(t, err)
+	assert.Nil(t, cs)
+
+	cs, err = loadChangeSet("testdata/parameters.json")
+	require.NoError(t, err)
+	assert.Nil(t, cs)
+}
+
+func TestLoadPastTemplate(t *testing.T) {
+	cs, err := loadChangeSet("testdata/change_set.json")
+	require.NoError(t, err)
+
+	parameters, err := parameterList(cs.Parameters)
+	require.NoError(t, err)
+
+	template, err := loadPastTemplate("testdata/parameterized.yml", cs, parameters, "eu-west-2")
+	require.NoError(t, err)
+
+	assert.ElementsMatch(t, []string{"WebServer", "Database", "LegacyServer"}, resourceNames(template.Resources))
+
+	web, ok := template.Resources["WebServer"].(*ec2.Instance)
+	require.True(t, ok)
+	assert.Equal(t, "t3.large", web.InstanceType)
+	assert.False(t, web.EbsOptimized)
+
+	legacy, ok := template.Resources["LegacyServer"].(*ec2.Instance)
+	require.True(t, ok)
+	assert.Equal(t, "t3.small", legacy.InstanceType)
+
+	db, err := template.GetRDSDBInstanceWithName("Database")
+	require.NoError(t, err)
+	assert.Equal(t, "db.r5.large", db.DBInstanceClass)
+}
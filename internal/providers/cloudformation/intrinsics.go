@@ -0,0 +1,467 @@
+package cloudformation
+
+import (
+	"encoding/base64"
+	"regexp"
+	"strconv"
+	"strings"
+
+	log "github.com/sirupsen/logrus"
+)
+
+var subVariableRegex = regexp.MustCompile(`\$\{([^}]+)\}`)
+
+// noValue is returned for Ref AWS::NoValue, which removes the property it's used in.
+type noValue struct{}
+
+// resolver resolves the intrinsic functions and conditions of a template. Values
+// that are only known once the stack is deployed, e.g. Fn::GetAtt, resolve to nil.
+type resolver struct {
+	template   map[string]interface{}
+	parameters map[string]interface{}
+	region     string
+
+	conditions map[string]bool
+	evaluating map[string]bool
+}
+
+func newResolver(template map[string]interface{}, parameterValues map[string]string, region string) *resolver {
+	return &resolver{
+		template:   template,
+		parameters: resolveParameters(mapValue(template["Parameters"]), parameterValues),
+		region:     region,
+		conditions: make(map[string]bool),
+		evaluating: make(map[string]bool),
+	}
+}
+
+// resolveParameters returns the values of the template parameters, using the
+// given values and falling back to the defaults in the template.
+func resolveParameters(definitions map[string]interface{}, values map[string]string) map[string]interface{} {
+	parameters := make(map[string]interface{})
+
+	for name, d := range definitions {
+		definition := mapValue(d)
+		t, _ := definition["Type"].(string)
+
+		var v interface{}
+		if s, ok := values[name]; ok {
+			v = s
+		} else if def, ok := definition["Default"]; ok {
+			v = def
+		} else {
+			log.Debugf("No value for CloudFormation parameter %s", name)
+			continue
+		}
+
+		if strings.HasPrefix(t, "AWS::SSM::Parameter::") {
+			log.Debugf("Skipping CloudFormation parameter %s since SSM parameter values are not supported", name)
+			continue
+		}
+
+		switch {
+		case t == "Number":
+			if s, ok := v.(string); ok {
+				if f, err := strconv.ParseFloat(s, 64); err == nil {
+					v = f
+				}
+			}
+		case t == "CommaDelimitedList" || strings.HasPrefix(t, "List<"):
+			if s, ok := v.(string); ok {
+				var l []interface{}
+				for _, item := range strings.Split(s, ",") {
+					l = append(l, strings.TrimSpace(item))
+				}
+				v = l
+			}
+		}
+
+		parameters[name] = v
+	}
+
+	return parameters
+}
+
+// resolveTemplate resolves the properties of the resources and removes the
+// resources whose condition is false.
+func (r *resolver) resolveTemplate() {
+	resources := mapValue(r.template["Resources"])
+
+	for name, res := range resources {
+		m := mapValue(res)
+
+		if c, ok := m["Condition"].(string); ok {
+			if v, ok := r.condition(c); ok && !v {
+				log.Debugf("Skipping CloudFormation resource %s since condition %s is false", name, c)
+				delete(resources, name)
+				continue
+			}
+		}
+
+		if props, ok := m["Properties"]; ok {
+			if v := r.resolve(props); v != (noValue{}) {
+				m["Properties"] = v
+			} else {
+				delete(m, "Properties")
+			}
+		}
+	}
+}
+
+func (r *resolver) resolve(v interface{}) interface{} {
+	switch val := v.(type) {
+	case map[string]interface{}:
+		if len(val) == 1 {
+			for k, arg := range val {
+				if f, ok := r.function(k); ok {
+					return f(arg)
+				}
+			}
+		}
+
+		resolved := make(map[string]interface{}, len(val))
+		for k, child := range val {
+			if c := r.resolve(child); c != (noValue{}) {
+				resolved[k] = c
+			}
+		}
+		return resolved
+	case []interface{}:
+		resolved := make([]interface{}, 0, len(val))
+		for _, child := range val {
+			if c := r.resolve(child); c != (noValue{}) {
+				resolved = append(resolved, c)
+			}
+		}
+		return resolved
+	}
+
+	return v
+}
+
+func (r *resolver) function(name string) (func(interface{}) interface{}, bool) {
+	switch name {
+	case "Ref":
+		return r.ref, true
+	case "Fn::GetAtt", "Fn::ImportValue", "Fn::Cidr", "Fn::Transform":
+		return func(interface{}) interface{} { return nil }, true
+	case "Fn::Sub":
+		return r.sub, true
+	case "Fn::If":
+		return r.fnIf, true
+	case "Fn::FindInMap":
+		return r.findInMap, true
+	case "Fn::Join":
+		return r.join, true
+	case "Fn::Select":
+		return r.selectItem, true
+	case "Fn::Split":
+		return r.split, true
+	case "Fn::Base64":
+		return r.base64, true
+	case "Fn::GetAZs":
+		return r.getAZs, true
+	case "Fn::Equals", "Fn::And", "Fn::Or", "Fn::Not":
+		return func(arg interface{}) interface{} {
+			v, ok := r.evaluateCondition(map[string]interface{}{name: arg})
+			if !ok {
+				return nil
+			}
+			return v
+		}, true
+	}
+
+	return nil, false
+}
+
+func (r *resolver) ref(arg interface{}) interface{} {
+	name, _ := r.resolve(arg).(string)
+
+	switch name {
+	case "AWS::Region":
+		return r.region
+	case "AWS::Partition":
+		return partition(r.region)
+	case "AWS::URLSuffix":
+		if partition(r.region) == "aws-cn" {
+			return "amazonaws.com.cn"
+		}
+		return "amazonaws.com"
+	case "AWS::NoValue":
+		return noValue{}
+	}
+
+	if v, ok := r.parameters[name]; ok {
+		return v
+	}
+
+	// The physical ID of a resource isn't known until it's created, so use the
+	// logical ID instead, e.g. for the names of resources.
+	if _, ok := mapValue(r.template["Resources"])[name]; ok {
+		return name
+	}
+
+	return nil
+}
+
+// sub resolves Fn::Sub. Variables that can't be resolved, e.g. attributes of
+// other resources, are left as they are.
+func (r *resolver) sub(arg interface{}) interface{} {
+	var s string
+	vars := map[string]interface{}{}
+
+	switch val := arg.(type) {
+	case string:
+		s = val
+	case []interface{}:
+		if len(val) != 2 {
+			return nil
+		}
+		s, _ = val[0].(string)
+		for k, v := range mapValue(val[1]) {
+			vars[k] = r.resolve(v)
+		}
+	default:
+		return nil
+	}
+
+	return subVariableRegex.ReplaceAllStringFunc(s, func(m string) string {
+		name := m[2 : len(m)-1]
+
+		if strings.HasPrefix(name, "!") {
+			return "${" + name[1:] + "}"
+		}
+
+		v, ok := vars[name]
+		if !ok && !strings.Contains(name, ".") {
+			v = r.ref(name)
+		}
+
+		if str, ok := stringValue(v); ok {
+			return str
+		}
+
+		return m
+	})
+}
+
+func (r *resolver) fnIf(arg interface{}) interface{} {
+	args, ok := arg.([]interface{})
+	if !ok || len(args) != 3 {
+		return nil
+	}
+
+	name, _ := args[0].(string)
+	v, ok := r.condition(name)
+	if !ok {
+		return nil
+	}
+
+	if v {
+		return r.resolve(args[1])
+	}
+	return r.resolve(args[2])
+}
+
+func (r *resolver) findInMap(arg interface{}) interface{} {
+	args, ok := r.resolve(arg).([]interface{})
+	if !ok || len(args) != 3 {
+		return nil
+	}
+
+	var keys []string
+	for _, a := range args {
+		k, ok := stringValue(a)
+		if !ok {
+			return nil
+		}
+		keys = append(keys, k)
+	}
+
+	return mapValue(mapValue(mapValue(r.template["Mappings"])[keys[0]])[keys[1]])[keys[2]]
+}
+
+func (r *resolver) join(arg interface{}) interface{} {
+	args, ok := r.resolve(arg).([]interface{})
+	if !ok || len(args) != 2 {
+		return nil
+	}
+
+	delimiter, _ := args[0].(string)
+	items, _ := args[1].([]interface{})
+
+	var s []string
+	for _, item := range items {
+		str, ok := stringValue(item)
+		if !ok {
+			return nil
+		}
+		s = append(s, str)
+	}
+
+	return strings.Join(s, delimiter)
+}
+
+func (r *resolver) selectItem(arg interface{}) interface{} {
+	args, ok := r.resolve(arg).([]interface{})
+	if !ok || len(args) != 2 {
+		return nil
+	}
+
+	str, _ := stringValue(args[0])
+	i, err := strconv.Atoi(str)
+	items, _ := args[1].([]interface{})
+	if err != nil || i < 0 || i >= len(items) {
+		return nil
+	}
+
+	return items[i]
+}
+
+func (r *resolver) split(arg interface{}) interface{} {
+	args, ok := r.resolve(arg).([]interface{})
+	if !ok || len(args) != 2 {
+		return nil
+	}
+
+	delimiter, _ := args[0].(string)
+	s, ok := args[1].(string)
+	if !ok {
+		return nil
+	}
+
+	var items []interface{}
+	for _, item := range strings.Split(s, delimiter) {
+		items = append(items, item)
+	}
+
+	return items
+}
+
+func (r *resolver) base64(arg interface{}) interface{} {
+	s, ok := stringValue(r.resolve(arg))
+	if !ok {
+		return nil
+	}
+
+	return base64.StdEncoding.EncodeToString([]byte(s))
+}
+
+func (r *resolver) getAZs(arg interface{}) interface{} {
+	region, _ := r.resolve(arg).(string)
+	if region == "" {
+		region = r.region
+	}
+
+	return []interface{}{region + "a", region + "b", region + "c"}
+}
+
+// condition returns the value of the named condition and whether it could be
+// evaluated.
+func (r *resolver) condition(name string) (bool, bool) {
+	if v, ok := r.conditions[name]; ok {
+		return v, true
+	}
+
+	expr, ok := mapValue(r.template["Conditions"])[name]
+	if !ok || r.evaluating[name] {
+		log.Debugf("Could not evaluate CloudFormation condition %s", name)
+		return false, false
+	}
+
+	r.evaluating[name] = true
+	v, ok := r.evaluateCondition(expr)
+	delete(r.evaluating, name)
+
+	if ok {
+		r.conditions[name] = v
+	}
+
+	return v, ok
+}
+
+func (r *resolver) evaluateCondition(expr interface{}) (bool, bool) {
+	switch val := expr.(type) {
+	case bool:
+		return val, true
+	case string:
+		// The short form !Condition tag is parsed as the name of the condition
+		return r.condition(val)
+	case map[string]interface{}:
+		if len(val) != 1 {
+			return false, false
+		}
+
+		for k, arg := range val {
+			args, _ := arg.([]interface{})
+
+			switch k {
+			case "Condition":
+				name, _ := arg.(string)
+				return r.condition(name)
+			case "Fn::Equals":
+				if len(args) != 2 {
+					return false, false
+				}
+				a, aok := stringValue(r.resolve(args[0]))
+				b, bok := stringValue(r.resolve(args[1]))
+				if !aok || !bok {
+					return false, false
+				}
+				return a == b, true
+			case "Fn::And", "Fn::Or":
+				result := k == "Fn::And"
+				for _, c := range args {
+					v, ok := r.evaluateCondition(c)
+					if !ok {
+						return false, false
+					}
+					if k == "Fn::And" {
+						result = result && v
+					} else {
+						result = result || v
+					}
+				}
+				return result, true
+			case "Fn::Not":
+				if len(args) != 1 {
+					return false, false
+				}
+				v, ok := r.evaluateCondition(args[0])
+				return !v, ok
+			}
+		}
+	}
+
+	return false, false
+}
+
+func partition(region string) string {
+	switch {
+	case strings.HasPrefix(region, "cn-"):
+		return "aws-cn"
+	case strings.HasPrefix(region, "us-gov-"):
+		return "aws-us-gov"
+	}
+
+	return "aws"
+}
+
+// stringValue returns the value as a string if it's a string, number or bool.
+func stringValue(v interface{}) (string, bool) {
+	switch val := v.(type) {
+	case string:
+		return val, true
+	case float64:
+		return strconv.FormatFloat(val, 'f', -1, 64), true
+	case bool:
+		return strconv.FormatBool(val), true
+	}
+
+	return "", false
+}
+
+func mapValue(v interface{}) map[string]interface{} {
+	m, _ := v.(map[string]interface{})
+	return m
+}
@@ -0,0 +1,96 @@
+package cloudformation
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestResolve(t *testing.T) {
+	template := map[string]interface{}{
+		"Parameters": map[string]interface{}{
+			"Environment": map[string]interface{}{"Type": "String", "Default": "dev"},
+			"Size":        map[string]interface{}{"Type": "Number", "Default": "10"},
+			"Zones":       map[string]interface{}{"Type": "List<AWS::EC2::AvailabilityZone::Name>"},
+			"Secret":      map[string]interface{}{"Type": "AWS::SSM::Parameter::Value<String>", "Default": "/secret"},
+		},
+		"Conditions": map[string]interface{}{
+			"IsProd":  map[string]interface{}{"Fn::Equals": []interface{}{map[string]interface{}{"Ref": "Environment"}, "prod"}},
+			"IsLarge": map[string]interface{}{"Fn::Or": []interface{}{map[string]interface{}{"Condition": "IsProd"}, map[string]interface{}{"Fn::Equals": []interface{}{map[string]interface{}{"Ref": "Size"}, "100"}}}},
+			"Loop":    map[string]interface{}{"Fn::Not": []interface{}{"Loop"}},
+		},
+		"Mappings": map[string]interface{}{
+			"Sizes": map[string]interface{}{
+				"dev": map[string]interface{}{"Instance": "t3.micro"},
+			},
+		},
+		"Resources": map[string]interface{}{
+			"Bucket": map[string]interface{}{"Type": "AWS::S3::Bucket"},
+		},
+	}
+
+	r := newResolver(template, map[string]string{"Size": "100", "Zones": "eu-west-2a, eu-west-2b"}, "cn-north-1")
+
+	tests := []struct {
+		name     string
+		input    interface{}
+		expected interface{}
+	}{
+		{"ref parameter", map[string]interface{}{"Ref": "Environment"}, "dev"},
+		{"ref number parameter", map[string]interface{}{"Ref": "Size"}, float64(100)},
+		{"ref list parameter", map[string]interface{}{"Ref": "Zones"}, []interface{}{"eu-west-2a", "eu-west-2b"}},
+		{"ref ssm parameter", map[string]interface{}{"Ref": "Secret"}, nil},
+		{"ref resource", map[string]interface{}{"Ref": "Bucket"}, "Bucket"},
+		{"ref region", map[string]interface{}{"Ref": "AWS::Region"}, "cn-north-1"},
+		{"ref partition", map[string]interface{}{"Ref": "AWS::Partition"}, "aws-cn"},
+		{"get att", map[string]interface{}{"Fn::GetAtt": []interface{}{"Bucket", "Arn"}}, nil},
+		{"sub", map[string]interface{}{"Fn::Sub": "${Environment}-${Bucket.Arn}-${!Literal}"}, "dev-${Bucket.Arn}-${Literal}"},
+		{"sub with variables", map[string]interface{}{"Fn::Sub": []interface{}{"${Name}.${AWS::URLSuffix}", map[string]interface{}{"Name": map[string]interface{}{"Ref": "Environment"}}}}, "dev.amazonaws.com.cn"},
+		{"if", map[string]interface{}{"Fn::If": []interface{}{"IsLarge", "large", "small"}}, "large"},
+		{"if unknown condition", map[string]interface{}{"Fn::If": []interface{}{"Missing", "a", "b"}}, nil},
+		{"if cyclic condition", map[string]interface{}{"Fn::If": []interface{}{"Loop", "a", "b"}}, nil},
+		{"find in map", map[string]interface{}{"Fn::FindInMap": []interface{}{"Sizes", map[string]interface{}{"Ref": "Environment"}, "Instance"}}, "t3.micro"},
+		{"join", map[string]interface{}{"Fn::Join": []interface{}{",", []interface{}{"a", float64(1), true}}}, "a,1,true"},
+		{"select", map[string]interface{}{"Fn::Select": []interface{}{"1", map[string]interface{}{"Fn::GetAZs": ""}}}, "cn-north-1b"},
+		{"split", map[string]interface{}{"Fn::Split": []interface{}{"-", "a-b"}}, []interface{}{"a", "b"}},
+		{"base64", map[string]interface{}{"Fn::Base64": "abc"}, "YWJj"},
+		{
+			"no value",
+			map[string]interface{}{
+				"A": map[string]interface{}{"Fn::If": []interface{}{"IsProd", "a", map[string]interface{}{"Ref": "AWS::NoValue"}}},
+				"B": []interface{}{"b", map[string]interface{}{"Ref": "AWS::NoValue"}},
+			},
+			map[string]interface{}{"B": []interface{}{"b"}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, r.resolve(tt.input))
+		})
+	}
+}
+
+func TestResolveTemplateConditions(t *testing.T) {
+	template := map[string]interface{}{
+		"Conditions": map[string]interface{}{
+			"IsProd": map[string]interface{}{"Fn::Equals": []interface{}{map[string]interface{}{"Ref": "AWS::Region"}, "us-west-2"}},
+		},
+		"Resources": map[string]interface{}{
+			"Always": map[string]interface{}{"Type": "AWS::S3::Bucket"},
+			"Prod":   map[string]interface{}{"Type": "AWS::S3::Bucket", "Condition": "IsProd"},
+			"Unknown": map[string]interface{}{
+				"Type":       "AWS::S3::Bucket",
+				"Condition":  "Missing",
+				"Properties": map[string]interface{}{"BucketName": map[string]interface{}{"Ref": "AWS::Region"}},
+			},
+		},
+	}
+
+	newResolver(template, nil, "us-east-1").resolveTemplate()
+
+	resources := mapValue(template["Resources"])
+	assert.Contains(t, resources, "Always")
+	assert.NotContains(t, resources, "Prod")
+	assert.Equal(t, map[string]interface{}{"BucketName": "us-east-1"}, mapValue(resources["Unknown"])["Properties"])
+}
@@ -2,21 +2,32 @@ package cloudformation
 
 import (
 	"fmt"
+	"os"
+	"reflect"
 	"strings"
 
 	"github.com/awslabs/goformation/v4/cloudformation"
+	"github.com/awslabs/goformation/v4/cloudformation/tags"
 
 	"github.com/infracost/infracost/internal/config"
 	"github.com/infracost/infracost/internal/schema"
 	"github.com/tidwall/gjson"
 )
 
+// defaultRegion is used when the region isn't set in the project config or the
+// environment, since templates aren't tied to the region that they're deployed to.
+const defaultRegion = "us-east-1"
+
 type Parser struct {
 	ctx *config.ProjectContext
+
+	// stackRegion is the region of the stack if it's known from the template source,
+	// e.g. the environment of a CDK stack.
+	stackRegion string
 }
 
 func NewParser(ctx *config.ProjectContext) *Parser {
-	return &Parser{ctx}
+	return &Parser{ctx: ctx}
 }
 
 func (p *Parser) createResource(d *schema.ResourceData, u *schema.UsageData) *schema.Resource {
@@ -41,8 +52,7 @@ func (p *Parser) createResource(d *schema.ResourceData, u *schema.UsageData) *sc
 		res := registryItem.RFunc(d, u)
 		if res != nil {
 			res.ResourceType = d.Type
-			// TODO: Figure out how to set tags.  For now, have the RFunc set them.
-			// res.Tags = d.Tags
+			res.Tags = d.Tags
 			if u != nil {
 				res.EstimationSummary = u.CalcEstimationSummary()
 			}
@@ -59,14 +69,28 @@ func (p *Parser) createResource(d *schema.ResourceData, u *schema.UsageData) *sc
 	}
 }
 
-func (p *Parser) parseTemplate(t *cloudformation.Template, usage map[string]*schema.UsageData) ([]*schema.Resource, []*schema.Resource, error) {
+// parseTemplate returns the resources of the template. If a past template is
+// given, e.g. of the deployed stack, its resources are returned as the past
+// resources, otherwise the resources are assumed to be unchanged.
+func (p *Parser) parseTemplate(t *cloudformation.Template, past *cloudformation.Template, usage map[string]*schema.UsageData) ([]*schema.Resource, []*schema.Resource, error) {
+	resources := p.parseResources(t, usage)
+
+	if past == nil {
+		return resources, resources, nil
+	}
+
+	return p.parseResources(past, usage), resources, nil
+}
+
+func (p *Parser) parseResources(t *cloudformation.Template, usage map[string]*schema.UsageData) []*schema.Resource {
 	baseResources := p.loadUsageFileResources(usage)
 
 	var resources []*schema.Resource
 	resources = append(resources, baseResources...)
 
+	region := p.region()
+
 	for name, d := range t.Resources {
-		tags := map[string]string{} // TODO: Where do I get tags?
 		var usageData *schema.UsageData
 
 		if ud := usage[name]; ud != nil {
@@ -78,14 +102,15 @@ func (p *Parser) parseTemplate(t *cloudformation.Template, usage map[string]*sch
 				usageData = arrayUsageData
 			}
 		}
-		resourceData := schema.NewCFResourceData(d.AWSCloudFormationType(), "aws", name, tags, d)
+		resourceData := schema.NewCFResourceData(d.AWSCloudFormationType(), "aws", name, resourceTags(d), d)
+		resourceData.RawValues = schema.AddRawValue(resourceData.RawValues, "region", region)
 
 		if r := p.createResource(resourceData, usageData); r != nil {
 			resources = append(resources, r)
 		}
 	}
 
-	return resources, resources, nil
+	return resources
 }
 
 func (p *Parser) loadUsageFileResources(u map[string]*schema.UsageData) []*schema.Resource {
@@ -105,6 +130,64 @@ func (p *Parser) loadUsageFileResources(u map[string]*schema.UsageData) []*schem
 	return resources
 }
 
+// region returns the region from the project config or the stack, or else the
+// region that the AWS CLI would deploy the stack to.
+func (p *Parser) region() string {
+	if p.ctx.ProjectConfig.CloudFormationRegion != "" {
+		return p.ctx.ProjectConfig.CloudFormationRegion
+	}
+
+	if p.stackRegion != "" {
+		return p.stackRegion
+	}
+
+	for _, k := range []string{"AWS_REGION", "AWS_DEFAULT_REGION"} {
+		if v := os.Getenv(k); v != "" {
+			return v
+		}
+	}
+
+	return defaultRegion
+}
+
+// resourceTags returns the tags of the resource. Most resources have a list of
+// key-value tags, and some, e.g. SSM parameters and SAM functions, have a map.
+func resourceTags(r cloudformation.Resource) map[string]string {
+	m := make(map[string]string)
+
+	v := reflect.ValueOf(r)
+	if v.Kind() == reflect.Ptr {
+		v = v.Elem()
+	}
+	if v.Kind() != reflect.Struct {
+		return m
+	}
+
+	f := v.FieldByName("Tags")
+	if !f.IsValid() {
+		return m
+	}
+
+	switch t := f.Interface().(type) {
+	case []tags.Tag:
+		for _, tag := range t {
+			m[tag.Key] = tag.Value
+		}
+	case map[string]string:
+		for k, v := range t {
+			m[k] = v
+		}
+	case map[string]interface{}:
+		for k, v := range t {
+			if s, ok := stringValue(v); ok {
+				m[k] = s
+			}
+		}
+	}
+
+	return m
+}
+
 func isAwsChina(d *schema.ResourceData) bool {
-	return strings.HasPrefix(d.Type, "aws_") && strings.HasPrefix(d.Get("region").String(), "cn-")
+	return (strings.HasPrefix(d.Type, "AWS::") || strings.HasPrefix(d.Type, "aws_")) && strings.HasPrefix(d.Get("region").String(), "cn-")
 }
@@ -0,0 +1,106 @@
+package cloudformation
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/infracost/infracost/internal/config"
+	"github.com/infracost/infracost/internal/schema"
+)
+
+func TestParseTemplate(t *testing.T) {
+	t.Setenv("AWS_REGION", "eu-west-1")
+
+	template, err := LoadTemplate("testdata/template.yml", nil, "")
+	require.NoError(t, err)
+
+	p := NewParser(config.EmptyProjectContext())
+	_, resources, err := p.parseTemplate(template, nil, map[string]*schema.UsageData{})
+	require.NoError(t, err)
+
+	byName := make(map[string]*schema.Resource)
+	for _, r := range resources {
+		byName[r.Name] = r
+	}
+	require.Len(t, byName, 9)
+
+	web := byName["WebServer"]
+	assert.Equal(t, "AWS::EC2::Instance", web.ResourceType)
+	assert.Equal(t, map[string]string{"Environment": "prod"}, web.Tags)
+	assert.Equal(t, []string{"Instance usage (Linux/UNIX, on-demand, t3.large)", "EBS-optimized usage", "CPU credits"}, costComponentNames(web))
+	assert.Equal(t, "eu-west-1", *web.CostComponents[0].ProductFilter.Region)
+	require.Len(t, web.SubResources, 2)
+	assert.Equal(t, "root_block_device", web.SubResources[0].Name)
+	assert.Equal(t, "50", web.SubResources[0].CostComponents[0].MonthlyQuantity.String())
+	assert.Equal(t, "ebs_block_device[0]", web.SubResources[1].Name)
+	assert.Equal(t, "100", web.SubResources[1].CostComponents[0].MonthlyQuantity.String())
+
+	db := byName["Database"]
+	assert.Equal(t, []string{"Database instance (on-demand, Multi-AZ, db.t3.medium)", "Storage (general purpose SSD, gp2)"}, costComponentNames(db))
+	assert.Equal(t, "50", db.CostComponents[1].MonthlyQuantity.String())
+
+	assert.Equal(t, []string{"Requests", "Duration"}, costComponentNames(byName["Worker"]))
+	assert.Equal(t, []string{"Classic load balancer", "Data processed"}, costComponentNames(byName["ClassicLoadBalancer"]))
+	assert.Equal(t, []string{"Network load balancer", "Load balancer capacity units"}, costComponentNames(byName["LoadBalancer"]))
+	assert.Equal(t, []string{"NAT gateway", "Data processed"}, costComponentNames(byName["NatGateway"]))
+
+	assets := byName["Assets"]
+	var storageClasses []string
+	for _, r := range assets.SubResources {
+		storageClasses = append(storageClasses, r.Name)
+	}
+	assert.ElementsMatch(t, []string{"Standard", "Glacier"}, storageClasses)
+
+	assert.True(t, byName["Vpc"].NoPrice)
+	assert.True(t, byName["Queue"].IsSkipped)
+	assert.Equal(t, "This resource is not currently supported", byName["Queue"].SkipMessage)
+}
+
+func TestParseTemplateParameters(t *testing.T) {
+	t.Setenv("AWS_REGION", "eu-west-1")
+
+	parameters, err := LoadParameters("testdata/parameters.json")
+	require.NoError(t, err)
+
+	ctx := config.EmptyProjectContext()
+	ctx.ProjectConfig.CloudFormationRegion = "eu-central-1"
+	p := NewParser(ctx)
+
+	template, err := LoadTemplate("testdata/parameterized.yml", parameters, p.region())
+	require.NoError(t, err)
+
+	_, resources, err := p.parseTemplate(template, nil, map[string]*schema.UsageData{})
+	require.NoError(t, err)
+
+	byName := make(map[string]*schema.Resource)
+	for _, r := range resources {
+		byName[r.Name] = r
+	}
+	require.Len(t, byName, 3)
+
+	web := byName["WebServer"]
+	assert.Equal(t, "Instance usage (Linux/UNIX, on-demand, m5.xlarge)", web.CostComponents[0].Name)
+	assert.Equal(t, "eu-central-1", *web.CostComponents[0].ProductFilter.Region)
+	assert.Equal(t, map[string]string{
+		"Name":        "${AWS::StackName}-web-prod",
+		"Environment": "prod",
+		"Region":      "eu-central-1",
+	}, web.Tags)
+
+	db := byName["Database"]
+	assert.Equal(t, []string{"Database instance (on-demand, Multi-AZ, db.r5.large)", "Storage (provisioned IOPS SSD, io1)", "Provisioned IOPS"}, costComponentNames(db))
+	assert.Equal(t, "200", db.CostComponents[1].MonthlyQuantity.String())
+
+	assert.Equal(t, "Network load balancer", byName["ProdLoadBalancer"].CostComponents[0].Name)
+}
+
+func costComponentNames(r *schema.Resource) []string {
+	names := make([]string, 0, len(r.CostComponents))
+	for _, c := range r.CostComponents {
+		names = append(names, c.Name)
+	}
+
+	return names
+}
@@ -0,0 +1,298 @@
+package cloudformation
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/awslabs/goformation/v4"
+	"github.com/awslabs/goformation/v4/cloudformation"
+	"github.com/awslabs/goformation/v4/intrinsics"
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+)
+
+var (
+	resourceTypes     map[string]reflect.Type
+	resourceTypesOnce sync.Once
+)
+
+// LoadTemplate reads a CloudFormation template, resolves its intrinsic functions
+// and conditions using the parameter values and region, and parses it.
+func LoadTemplate(path string, parameters map[string]string, region string) (*cloudformation.Template, error) {
+	t, err := readTemplate(path)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(mapValue(t["Resources"])) == 0 {
+		return nil, errors.New("Template has no resources")
+	}
+
+	return parseTemplateMap(t, parameters, region)
+}
+
+// readTemplate reads a JSON or YAML template into a map without resolving it.
+func readTemplate(path string) (map[string]interface{}, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	// Only apply the SAM globals and convert the YAML short form functions, since
+	// goformation can't resolve parameter values or conditions
+	opts := &intrinsics.ProcessorOptions{ProcessOnlyGlobals: true}
+	if strings.HasSuffix(path, ".json") {
+		data, err = intrinsics.ProcessJSON(data, opts)
+	} else {
+		data, err = intrinsics.ProcessYAML(data, opts)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var t map[string]interface{}
+	if err := json.Unmarshal(data, &t); err != nil {
+		return nil, err
+	}
+
+	return t, nil
+}
+
+// parseTemplateMap resolves the template and parses it into the goformation types.
+func parseTemplateMap(t map[string]interface{}, parameters map[string]string, region string) (*cloudformation.Template, error) {
+	if region == "" {
+		region = defaultRegion
+	}
+
+	newResolver(t, parameters, region).resolveTemplate()
+	coerceResources(mapValue(t["Resources"]))
+
+	b, err := json.Marshal(t)
+	if err != nil {
+		return nil, err
+	}
+
+	return goformation.ParseJSONWithOptions(b, &intrinsics.ProcessorOptions{NoProcess: true})
+}
+
+// LoadParameters reads the parameter values from a JSON file in the format used by
+// aws cloudformation create-stack, i.e. a list of ParameterKey and ParameterValue
+// objects, or the template configuration format used by CodePipeline, i.e. an
+// object with a Parameters object.
+func LoadParameters(path string) (map[string]string, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var list []parameter
+
+	var config struct {
+		Parameters map[string]interface{} `json:"Parameters"`
+	}
+
+	if err := json.Unmarshal(data, &list); err == nil {
+		return parameterList(list)
+	}
+
+	if err := json.Unmarshal(data, &config); err != nil || config.Parameters == nil {
+		return nil, errors.New("Expected a list of ParameterKey and ParameterValue objects or an object with Parameters")
+	}
+
+	return parameterValues(config.Parameters)
+}
+
+// parameter is a parameter value in the format used by the AWS CLI.
+type parameter struct {
+	ParameterKey     string      `json:"ParameterKey"`
+	ParameterValue   interface{} `json:"ParameterValue"`
+	UsePreviousValue bool        `json:"UsePreviousValue"`
+}
+
+func parameterList(list []parameter) (map[string]string, error) {
+	values := make(map[string]interface{}, len(list))
+	for _, p := range list {
+		// The previous value isn't known, so fall back to the default
+		if p.UsePreviousValue && p.ParameterValue == nil {
+			continue
+		}
+		values[p.ParameterKey] = p.ParameterValue
+	}
+
+	return parameterValues(values)
+}
+
+func parameterValues(values map[string]interface{}) (map[string]string, error) {
+	parameters := make(map[string]string, len(values))
+	for k, v := range values {
+		if l, ok := v.([]interface{}); ok {
+			var items []string
+			for _, item := range l {
+				s, _ := stringValue(item)
+				items = append(items, s)
+			}
+			parameters[k] = strings.Join(items, ",")
+			continue
+		}
+
+		s, ok := stringValue(v)
+		if !ok {
+			return nil, fmt.Errorf("Invalid value for parameter %s", k)
+		}
+		parameters[k] = s
+	}
+
+	return parameters, nil
+}
+
+// coerceResources converts the property values of the resources to the types that
+// goformation expects, since CloudFormation accepts e.g. numbers for string
+// properties and parameter values are always strings.
+func coerceResources(resources map[string]interface{}) {
+	resourceTypesOnce.Do(func() {
+		resourceTypes = make(map[string]reflect.Type)
+		for k, r := range cloudformation.AllResources() {
+			resourceTypes[k] = reflect.TypeOf(r)
+		}
+	})
+
+	for name, res := range resources {
+		m := mapValue(res)
+
+		// DependsOn can be a single resource
+		if s, ok := m["DependsOn"].(string); ok {
+			m["DependsOn"] = []interface{}{s}
+		}
+
+		t, _ := m["Type"].(string)
+		rt, ok := resourceTypes[t]
+		if !ok {
+			// goformation can't parse types that it doesn't know, e.g. AWS::CDK::Metadata
+			if !strings.HasPrefix(t, "Custom::") {
+				log.Debugf("Skipping CloudFormation resource %s since its type %s is not known", name, t)
+				delete(resources, name)
+			}
+			continue
+		}
+
+		if props, ok := m["Properties"]; ok {
+			if v, ok := coerceValue(props, rt, name); ok {
+				m["Properties"] = v
+			} else {
+				delete(m, "Properties")
+			}
+		}
+	}
+}
+
+// coerceValue converts the value to match the type, or returns false if it can't,
+// e.g. for values of functions that couldn't be resolved.
+func coerceValue(v interface{}, t reflect.Type, path string) (interface{}, bool) {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+
+	if v == nil {
+		return nil, true
+	}
+
+	switch t.Kind() {
+	case reflect.Struct:
+		m, ok := v.(map[string]interface{})
+		if !ok {
+			return nil, false
+		}
+
+		fields := jsonFields(t)
+		coerced := make(map[string]interface{}, len(m))
+		for k, child := range m {
+			ft, ok := fields[k]
+			if !ok {
+				coerced[k] = child
+				continue
+			}
+
+			if c, ok := coerceValue(child, ft, path+"."+k); ok {
+				coerced[k] = c
+			} else {
+				log.Debugf("Ignoring CloudFormation property %s.%s since its value could not be resolved", path, k)
+			}
+		}
+		return coerced, true
+	case reflect.Slice:
+		l, ok := v.([]interface{})
+		if !ok {
+			return nil, false
+		}
+
+		coerced := make([]interface{}, 0, len(l))
+		for i, item := range l {
+			if c, ok := coerceValue(item, t.Elem(), fmt.Sprintf("%s[%d]", path, i)); ok {
+				coerced = append(coerced, c)
+			}
+		}
+		return coerced, true
+	case reflect.Map:
+		m, ok := v.(map[string]interface{})
+		if !ok {
+			return nil, false
+		}
+
+		coerced := make(map[string]interface{}, len(m))
+		for k, child := range m {
+			if c, ok := coerceValue(child, t.Elem(), path+"."+k); ok {
+				coerced[k] = c
+			}
+		}
+		return coerced, true
+	case reflect.String:
+		return stringValue(v)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64, reflect.Float32, reflect.Float64:
+		s, ok := stringValue(v)
+		if !ok {
+			return nil, false
+		}
+
+		f, err := strconv.ParseFloat(s, 64)
+		if err != nil || (t.Kind() != reflect.Float32 && t.Kind() != reflect.Float64 && f != float64(int64(f))) {
+			return nil, false
+		}
+		return f, true
+	case reflect.Bool:
+		s, ok := stringValue(v)
+		if !ok {
+			return nil, false
+		}
+
+		b, err := strconv.ParseBool(s)
+		if err != nil {
+			return nil, false
+		}
+		return b, true
+	}
+
+	return v, true
+}
+
+// jsonFields returns the types of the struct fields by their JSON names.
+func jsonFields(t reflect.Type) map[string]reflect.Type {
+	fields := make(map[string]reflect.Type)
+
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+
+		name := strings.Split(f.Tag.Get("json"), ",")[0]
+		if name == "" || name == "-" {
+			continue
+		}
+
+		fields[name] = f.Type
+	}
+
+	return fields
+}
@@ -1,7 +1,7 @@
 package cloudformation
 
 import (
-	"github.com/awslabs/goformation/v4"
+	"github.com/awslabs/goformation/v4/cloudformation"
 	"github.com/infracost/infracost/internal/config"
 	"github.com/infracost/infracost/internal/schema"
 	"github.com/pkg/errors"
@@ -32,19 +32,61 @@ func (p *TemplateProvider) AddMetadata(metadata *schema.ProjectMetadata) {
 }
 
 func (p *TemplateProvider) LoadResources(usage map[string]*schema.UsageData) ([]*schema.Project, error) {
-	template, err := goformation.Open(p.Path)
+	var parameters map[string]string
+	if p.ctx.ProjectConfig.CloudFormationParametersFile != "" {
+		var err error
+		parameters, err = LoadParameters(p.ctx.ProjectConfig.CloudFormationParametersFile)
+		if err != nil {
+			return []*schema.Project{}, errors.Wrap(err, "Error reading CloudFormation parameters file")
+		}
+	}
+
+	var cs *changeSet
+	compareTo := p.ctx.ProjectConfig.CloudFormationCompareTo
+	if compareTo != "" {
+		var err error
+		cs, err = loadChangeSet(compareTo)
+		if err != nil {
+			return []*schema.Project{}, errors.Wrap(err, "Error reading CloudFormation change set file")
+		}
+
+		// Use the parameter values that the change set was created with
+		if cs != nil && parameters == nil {
+			parameters, err = parameterList(cs.Parameters)
+			if err != nil {
+				return []*schema.Project{}, errors.Wrap(err, "Error reading CloudFormation change set parameters")
+			}
+		}
+	}
+
+	parser := NewParser(p.ctx)
+	region := parser.region()
+
+	template, err := LoadTemplate(p.Path, parameters, region)
 	if err != nil {
 		return []*schema.Project{}, errors.Wrap(err, "Error reading Cloudformation template file")
 	}
 
+	var pastTemplate *cloudformation.Template
+	if cs != nil {
+		pastTemplate, err = loadPastTemplate(p.Path, cs, parameters, region)
+		if err != nil {
+			return []*schema.Project{}, errors.Wrap(err, "Error applying CloudFormation change set")
+		}
+	} else if compareTo != "" {
+		pastTemplate, err = LoadTemplate(compareTo, parameters, region)
+		if err != nil {
+			return []*schema.Project{}, errors.Wrap(err, "Error reading CloudFormation template file to compare to")
+		}
+	}
+
 	metadata := config.DetectProjectMetadata(p.ctx.ProjectConfig.Path)
 	metadata.Type = p.Type()
 	p.AddMetadata(metadata)
 	name := schema.GenerateProjectName(metadata, p.ctx.RunContext.Config.EnableDashboard)
 
 	project := schema.NewProject(name, metadata)
-	parser := NewParser(p.ctx)
-	pastResources, resources, err := parser.parseTemplate(template, usage)
+	pastResources, resources, err := parser.parseTemplate(template, pastTemplate, usage)
 	if err != nil {
 		return []*schema.Project{project}, errors.Wrap(err, "Error parsing Cloudformation template file")
 	}
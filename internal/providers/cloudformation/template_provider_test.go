@@ -0,0 +1,64 @@
+package cloudformation
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/infracost/infracost/internal/config"
+	"github.com/infracost/infracost/internal/schema"
+)
+
+func TestTemplateProviderCompareTo(t *testing.T) {
+	tests := []struct {
+		name          string
+		compareTo     string
+		pastResources []string
+		resources     []string
+	}{
+		{
+			name:          "no compare to",
+			pastResources: []string{"WebServer", "Database", "DevBucket"},
+			resources:     []string{"WebServer", "Database", "DevBucket"},
+		},
+		{
+			name:          "template",
+			compareTo:     "testdata/template.yml",
+			pastResources: []string{"WebServer", "Database", "Worker", "Assets", "ClassicLoadBalancer", "LoadBalancer", "NatGateway", "Vpc", "Queue"},
+			resources:     []string{"WebServer", "Database", "DevBucket"},
+		},
+		{
+			name:          "change set",
+			compareTo:     "testdata/change_set.json",
+			pastResources: []string{"WebServer", "Database", "LegacyServer"},
+			resources:     []string{"WebServer", "Database", "ProdLoadBalancer"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := config.NewProjectContext(config.EmptyRunContext(), &config.Project{
+				Path:                    "testdata/parameterized.yml",
+				CloudFormationRegion:    "eu-west-2",
+				CloudFormationCompareTo: tt.compareTo,
+			})
+
+			projects, err := NewTemplateProvider(ctx).LoadResources(map[string]*schema.UsageData{})
+			require.NoError(t, err)
+			require.Len(t, projects, 1)
+
+			assert.ElementsMatch(t, tt.pastResources, names(projects[0].PastResources))
+			assert.ElementsMatch(t, tt.resources, names(projects[0].Resources))
+		})
+	}
+}
+
+func names(resources []*schema.Resource) []string {
+	n := make([]string, 0, len(resources))
+	for _, r := range resources {
+		n = append(n, r.Name)
+	}
+
+	return n
+}
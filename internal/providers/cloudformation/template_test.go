@@ -0,0 +1,97 @@
+package cloudformation
+
+import (
+	"testing"
+
+	"github.com/awslabs/goformation/v4/cloudformation"
+	"github.com/awslabs/goformation/v4/cloudformation/ec2"
+	"github.com/awslabs/goformation/v4/cloudformation/rds"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoadTemplateDefaults(t *testing.T) {
+	template, err := LoadTemplate("testdata/parameterized.yml", nil, "")
+	require.NoError(t, err)
+
+	assert.ElementsMatch(t, []string{"WebServer", "Database", "DevBucket"}, resourceNames(template.Resources))
+
+	web, err := template.GetEC2InstanceWithName("WebServer")
+	require.NoError(t, err)
+	assert.Equal(t, "t3.micro", web.InstanceType)
+	assert.Equal(t, "subnet-a", web.SubnetId)
+	assert.False(t, web.EbsOptimized)
+	assert.Equal(t, map[string]string{
+		"Name":        "${AWS::StackName}-web-dev",
+		"Environment": "dev",
+		"Region":      "us-east-1",
+	}, resourceTags(web))
+
+	db, err := template.GetRDSDBInstanceWithName("Database")
+	require.NoError(t, err)
+	assert.Equal(t, "db.t3.micro", db.DBInstanceClass)
+	assert.Equal(t, "20", db.AllocatedStorage)
+	assert.False(t, db.MultiAZ)
+	assert.Equal(t, 0, db.Iops)
+	assert.Empty(t, db.DBSubnetGroupName)
+
+	bucket, err := template.GetS3BucketWithName("DevBucket")
+	require.NoError(t, err)
+	assert.Equal(t, "dev-assets", bucket.BucketName)
+}
+
+func TestLoadTemplateParameters(t *testing.T) {
+	parameters, err := LoadParameters("testdata/parameters.json")
+	require.NoError(t, err)
+
+	template, err := LoadTemplate("testdata/parameterized.yml", parameters, "eu-west-2")
+	require.NoError(t, err)
+
+	assert.ElementsMatch(t, []string{"WebServer", "Database", "ProdLoadBalancer"}, resourceNames(template.Resources))
+
+	web, ok := template.Resources["WebServer"].(*ec2.Instance)
+	require.True(t, ok)
+	assert.Equal(t, "m5.xlarge", web.InstanceType)
+	assert.True(t, web.EbsOptimized)
+	assert.Equal(t, "eu-west-2", resourceTags(web)["Region"])
+
+	db, ok := template.Resources["Database"].(*rds.DBInstance)
+	require.True(t, ok)
+	assert.Equal(t, "db.r5.large", db.DBInstanceClass)
+	assert.Equal(t, "200", db.AllocatedStorage)
+	assert.True(t, db.MultiAZ)
+	assert.Equal(t, 1000, db.Iops)
+}
+
+func TestLoadTemplateNoResources(t *testing.T) {
+	_, err := LoadTemplate("testdata/template_configuration.json", nil, "")
+	assert.EqualError(t, err, "Template has no resources")
+}
+
+func TestLoadParameters(t *testing.T) {
+	expected := map[string]string{
+		"Environment":     "prod",
+		"InstanceType":    "m5.xlarge",
+		"DatabaseStorage": "200",
+	}
+
+	parameters, err := LoadParameters("testdata/parameters.json")
+	require.NoError(t, err)
+	assert.Equal(t, expected, parameters)
+
+	parameters, err = LoadParameters("testdata/template_configuration.json")
+	require.NoError(t, err)
+	assert.Equal(t, expected, parameters)
+
+	_, err = LoadParameters("testdata/template.yml")
+	assert.Error(t, err)
+}
+
+func resourceNames(resources cloudformation.Resources) []string {
+	names := make([]string, 0, len(resources))
+	for name := range resources {
+		names = append(names, name)
+	}
+
+	return names
+}
@@ -6,11 +6,13 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"strings"
 
-	"github.com/awslabs/goformation/v4"
 	"github.com/infracost/infracost/internal/providers/cloudformation"
 
 	"github.com/infracost/infracost/internal/config"
+	"github.com/infracost/infracost/internal/providers/kubernetes"
+	"github.com/infracost/infracost/internal/providers/pulumi"
 	"github.com/infracost/infracost/internal/providers/terraform"
 	"github.com/infracost/infracost/internal/schema"
 )
@@ -18,6 +20,10 @@ import (
 func Detect(ctx *config.ProjectContext) (schema.Provider, error) {
 	path := ctx.ProjectConfig.Path
 
+	if terraform.IsCloudPath(path) {
+		return terraform.NewCloudProvider(ctx), nil
+	}
+
 	if _, err := os.Stat(path); os.IsNotExist(err) {
 		return nil, fmt.Errorf("No such file or directory %s", path)
 	}
@@ -26,6 +32,18 @@ func Detect(ctx *config.ProjectContext) (schema.Provider, error) {
 		return cloudformation.NewTemplateProvider(ctx), nil
 	}
 
+	if isCDKCloudAssembly(path) {
+		return cloudformation.NewCDKProvider(ctx), nil
+	}
+
+	if isPulumiPreviewJSON(path) {
+		return pulumi.NewPreviewJSONProvider(ctx), nil
+	}
+
+	if isPulumiStateJSON(path) {
+		return pulumi.NewStateJSONProvider(ctx), nil
+	}
+
 	if isTerraformPlanJSON(path) {
 		return terraform.NewPlanJSONProvider(ctx), nil
 	}
@@ -38,6 +56,10 @@ func Detect(ctx *config.ProjectContext) (schema.Provider, error) {
 		return terraform.NewPlanProvider(ctx), nil
 	}
 
+	if isTerraformDir(path) && ctx.ProjectConfig.TerraformParseHCL {
+		return terraform.NewHCLProvider(ctx), nil
+	}
+
 	if isTerragruntDir(path) {
 		return terraform.NewTerragruntProvider(ctx), nil
 	}
@@ -50,6 +72,10 @@ func Detect(ctx *config.ProjectContext) (schema.Provider, error) {
 		return terraform.NewTerragruntProvider(ctx), nil
 	}
 
+	if isKubernetesManifest(path) {
+		return kubernetes.NewManifestProvider(ctx), nil
+	}
+
 	return nil, fmt.Errorf("Could not detect path type for %s", path)
 }
 
@@ -91,6 +117,55 @@ func isTerraformStateJSON(path string) bool {
 	return jsonFormat.FormatVersion != "" && jsonFormat.Values != nil
 }
 
+// isPulumiPreviewJSON checks for the output of pulumi preview --json, which has the
+// steps that the update would run on each resource.
+func isPulumiPreviewJSON(path string) bool {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return false
+	}
+
+	var jsonFormat struct {
+		Steps []struct {
+			URN string `json:"urn"`
+		} `json:"steps"`
+		ChangeSummary interface{} `json:"changeSummary"`
+	}
+
+	err = json.Unmarshal(b, &jsonFormat)
+	if err != nil {
+		return false
+	}
+
+	if len(jsonFormat.Steps) > 0 {
+		return strings.HasPrefix(jsonFormat.Steps[0].URN, "urn:pulumi:")
+	}
+
+	return jsonFormat.Steps != nil && jsonFormat.ChangeSummary != nil
+}
+
+// isPulumiStateJSON checks for the output of pulumi stack export.
+func isPulumiStateJSON(path string) bool {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return false
+	}
+
+	var jsonFormat struct {
+		Version    int `json:"version"`
+		Deployment struct {
+			Manifest interface{} `json:"manifest"`
+		} `json:"deployment"`
+	}
+
+	err = json.Unmarshal(b, &jsonFormat)
+	if err != nil {
+		return false
+	}
+
+	return jsonFormat.Version > 0 && jsonFormat.Deployment.Manifest != nil
+}
+
 func isTerraformPlan(path string) bool {
 	r, err := zip.OpenReader(path)
 	if err != nil {
@@ -144,15 +219,16 @@ func isTerraformDir(path string) bool {
 	return terraform.IsTerraformDir(path)
 }
 
-func isCloudFormationTemplate(path string) bool {
-	template, err := goformation.Open(path)
-	if err != nil {
-		return false
-	}
+func isCDKCloudAssembly(path string) bool {
+	return cloudformation.IsCloudAssembly(path)
+}
 
-	if len(template.Resources) > 0 {
-		return true
-	}
+func isKubernetesManifest(path string) bool {
+	return kubernetes.IsManifest(path)
+}
 
-	return false
+func isCloudFormationTemplate(path string) bool {
+	// LoadTemplate returns an error if the template has no resources
+	_, err := cloudformation.LoadTemplate(path, nil, "")
+	return err == nil
 }
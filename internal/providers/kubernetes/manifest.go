@@ -0,0 +1,120 @@
+package kubernetes
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/tidwall/gjson"
+	"gopkg.in/yaml.v3"
+)
+
+// IsManifest returns true if the path is a Kubernetes manifest file, or a
+// directory that contains manifest files.
+func IsManifest(path string) bool {
+	objects, err := loadManifests(path)
+	return err == nil && len(objects) > 0
+}
+
+// loadManifests returns the Kubernetes objects in a manifest file, or in the
+// manifest files of a directory and its subdirectories.
+func loadManifests(path string) ([]gjson.Result, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, err
+	}
+
+	if !info.IsDir() {
+		return loadManifest(path)
+	}
+
+	var objects []gjson.Result
+
+	err = filepath.WalkDir(path, func(p string, d os.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+
+		if d.IsDir() {
+			if p != path && strings.HasPrefix(d.Name(), ".") {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+
+		if !isYAMLFile(p) {
+			return nil
+		}
+
+		// Other YAML files can be in the directory, e.g. Helm templates, so files
+		// that can't be parsed are skipped
+		o, err := loadManifest(p)
+		if err != nil {
+			log.Debugf("Skipping %s since it is not a Kubernetes manifest: %s", p, err)
+			return nil
+		}
+		objects = append(objects, o...)
+
+		return nil
+	})
+
+	return objects, err
+}
+
+// loadManifest returns the Kubernetes objects in a YAML file, which can have
+// multiple documents and List objects. Documents that aren't Kubernetes objects
+// are ignored.
+func loadManifest(path string) ([]gjson.Result, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var objects []gjson.Result
+
+	dec := yaml.NewDecoder(bytes.NewReader(data))
+	for {
+		var doc map[string]interface{}
+		err := dec.Decode(&doc)
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		b, err := json.Marshal(doc)
+		if err != nil {
+			return nil, err
+		}
+
+		o := gjson.ParseBytes(b)
+		if o.Get("kind").String() == "List" {
+			for _, item := range o.Get("items").Array() {
+				if isObject(item) {
+					objects = append(objects, item)
+				}
+			}
+			continue
+		}
+
+		if isObject(o) {
+			objects = append(objects, o)
+		}
+	}
+
+	return objects, nil
+}
+
+func isObject(o gjson.Result) bool {
+	return o.Get("apiVersion").String() != "" && o.Get("kind").String() != "" && o.Get("metadata.name").String() != ""
+}
+
+func isYAMLFile(path string) bool {
+	ext := filepath.Ext(path)
+	return ext == ".yaml" || ext == ".yml"
+}
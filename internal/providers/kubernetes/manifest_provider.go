@@ -0,0 +1,55 @@
+package kubernetes
+
+import (
+	"github.com/pkg/errors"
+
+	"github.com/infracost/infracost/internal/config"
+	"github.com/infracost/infracost/internal/schema"
+)
+
+type ManifestProvider struct {
+	ctx  *config.ProjectContext
+	Path string
+}
+
+func NewManifestProvider(ctx *config.ProjectContext) schema.Provider {
+	return &ManifestProvider{
+		ctx:  ctx,
+		Path: ctx.ProjectConfig.Path,
+	}
+}
+
+func (p *ManifestProvider) Type() string {
+	return "kubernetes_manifest"
+}
+
+func (p *ManifestProvider) DisplayType() string {
+	return "Kubernetes manifest"
+}
+
+func (p *ManifestProvider) AddMetadata(metadata *schema.ProjectMetadata) {
+	// no op
+}
+
+func (p *ManifestProvider) LoadResources(usage map[string]*schema.UsageData) ([]*schema.Project, error) {
+	objects, err := loadManifests(p.Path)
+	if err != nil {
+		return []*schema.Project{}, errors.Wrap(err, "Error reading Kubernetes manifests")
+	}
+
+	metadata := config.DetectProjectMetadata(p.ctx.ProjectConfig.Path)
+	metadata.Type = p.Type()
+	p.AddMetadata(metadata)
+	name := schema.GenerateProjectName(metadata, p.ctx.RunContext.Config.EnableDashboard)
+
+	project := schema.NewProject(name, metadata)
+	parser := NewParser(p.ctx)
+
+	resources := parser.parseManifests(objects, usage)
+
+	// The manifests only have the desired state so there's nothing to diff against
+	project.HasDiff = false
+	project.Resources = resources
+
+	return []*schema.Project{project}, nil
+}
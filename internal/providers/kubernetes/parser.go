@@ -0,0 +1,183 @@
+package kubernetes
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/tidwall/gjson"
+
+	"github.com/infracost/infracost/internal/config"
+	"github.com/infracost/infracost/internal/schema"
+)
+
+// NodeProfileUsageKey is the usage file entry for the nodes that the workloads
+// run on, e.g.
+//
+//	kubernetes_node_profile:
+//	  cloud: aws
+//	  region: us-east-1
+//	  instance_type: m5.large
+//	  vcpu: 2
+//	  memory_gb: 8
+const NodeProfileUsageKey = "kubernetes_node_profile"
+
+var defaultCloudRegions = map[string]string{
+	"aws":    "us-east-1",
+	"google": "us-central1",
+	"azure":  "eastus",
+}
+
+var kindWordBoundary = regexp.MustCompile(`([a-z0-9])([A-Z])`)
+
+type Parser struct {
+	ctx *config.ProjectContext
+}
+
+func NewParser(ctx *config.ProjectContext) *Parser {
+	return &Parser{ctx}
+}
+
+func (p *Parser) createResource(d *schema.ResourceData, u *schema.UsageData) *schema.Resource {
+	registryMap := GetResourceRegistryMap()
+
+	if registryItem, ok := (*registryMap)[d.Type]; ok {
+		if registryItem.NoPrice {
+			return &schema.Resource{
+				Name:         d.Address,
+				ResourceType: d.Type,
+				Tags:         d.Tags,
+				IsSkipped:    true,
+				NoPrice:      true,
+				SkipMessage:  "Free resource.",
+			}
+		}
+
+		res := registryItem.RFunc(d, u)
+		if res != nil {
+			res.ResourceType = d.Type
+			res.Tags = d.Tags
+			if u != nil {
+				res.EstimationSummary = u.CalcEstimationSummary()
+			}
+			return res
+		}
+	}
+
+	return &schema.Resource{
+		Name:         d.Address,
+		ResourceType: d.Type,
+		Tags:         d.Tags,
+		IsSkipped:    true,
+		SkipMessage:  "This resource is not currently supported",
+	}
+}
+
+// parseManifests returns the resources for the Kubernetes objects. The cloud and
+// region of the cluster are taken from the node profile in the usage file.
+func (p *Parser) parseManifests(objects []gjson.Result, usage map[string]*schema.UsageData) []*schema.Resource {
+	profile := nodeProfileValues(usage[NodeProfileUsageKey])
+
+	data := make([]*schema.ResourceData, 0, len(objects))
+	var storageClasses []*schema.ResourceData
+
+	for _, o := range objects {
+		d := parseResourceData(o, profile)
+		data = append(data, d)
+
+		if d.Type == "kubernetes_storage_class" {
+			storageClasses = append(storageClasses, d)
+		}
+	}
+
+	resources := make([]*schema.Resource, 0, len(data))
+
+	for _, d := range data {
+		// Volume claims reference their storage class by name, so the storage
+		// classes are added as references to resolve the disk type
+		if d.Type == "kubernetes_persistent_volume_claim" || d.Type == "kubernetes_stateful_set" {
+			for _, sc := range storageClasses {
+				d.AddReference("storage_classes", sc)
+			}
+		}
+
+		if r := p.createResource(d, usage[d.Address]); r != nil {
+			resources = append(resources, r)
+		}
+	}
+
+	return resources
+}
+
+// parseResourceData converts a Kubernetes object to resource data. The resource
+// type is the snake case of the kind with a kubernetes_ prefix, as it is in the
+// Terraform Kubernetes provider, and the address includes the namespace of
+// namespaced objects, e.g. kubernetes_deployment.default.web.
+func parseResourceData(o gjson.Result, profile gjson.Result) *schema.ResourceData {
+	t := resourceType(o.Get("kind").String())
+
+	addr := fmt.Sprintf("%s.%s", t, o.Get("metadata.name").String())
+	if isNamespaced(t) {
+		namespace := o.Get("metadata.namespace").String()
+		if namespace == "" {
+			namespace = "default"
+		}
+		addr = fmt.Sprintf("%s.%s.%s", t, namespace, o.Get("metadata.name").String())
+	}
+
+	tags := make(map[string]string)
+	for k, v := range o.Get("metadata.labels").Map() {
+		tags[k] = v.String()
+	}
+
+	v := schema.AddRawValue(o, "cloud", profile.Get("cloud").String())
+	v = schema.AddRawValue(v, "region", profile.Get("region").String())
+	v = schema.AddRawValue(v, "node_profile", profile.Value())
+
+	return schema.NewResourceData(t, "kubernetes", addr, tags, v)
+}
+
+// nodeProfileValues returns the node profile from the usage file with the
+// defaults for the cloud and region.
+func nodeProfileValues(u *schema.UsageData) gjson.Result {
+	profile := map[string]interface{}{}
+	if u != nil {
+		for k, v := range u.Attributes {
+			profile[k] = v.Value()
+		}
+	}
+
+	cloud, _ := profile["cloud"].(string)
+	if cloud == "" {
+		cloud = "aws"
+	}
+	if _, ok := defaultCloudRegions[cloud]; !ok {
+		log.Warnf("Unknown cloud %s in %s, expected aws, google or azure. Using aws", cloud, NodeProfileUsageKey)
+		cloud = "aws"
+	}
+	profile["cloud"] = cloud
+
+	if region, _ := profile["region"].(string); region == "" {
+		profile["region"] = defaultCloudRegions[cloud]
+		log.Debugf("Falling back to default region (%s) for Kubernetes resources", defaultCloudRegions[cloud])
+	}
+
+	b, _ := json.Marshal(profile)
+	return gjson.ParseBytes(b)
+}
+
+func resourceType(kind string) string {
+	return "kubernetes_" + strings.ToLower(kindWordBoundary.ReplaceAllString(kind, "${1}_${2}"))
+}
+
+func isNamespaced(resourceType string) bool {
+	switch resourceType {
+	case "kubernetes_cluster_role", "kubernetes_cluster_role_binding", "kubernetes_namespace",
+		"kubernetes_persistent_volume", "kubernetes_storage_class":
+		return false
+	}
+
+	return true
+}
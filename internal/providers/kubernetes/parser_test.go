@@ -0,0 +1,176 @@
+package kubernetes
+
+import (
+	"testing"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tidwall/gjson"
+
+	"github.com/infracost/infracost/internal/config"
+	"github.com/infracost/infracost/internal/schema"
+)
+
+func nodeProfileUsage(cloud string) map[string]*schema.UsageData {
+	return map[string]*schema.UsageData{
+		NodeProfileUsageKey: schema.NewUsageData(NodeProfileUsageKey, schema.ParseAttributes(map[string]interface{}{
+			"cloud":         cloud,
+			"region":        "eu-west-1",
+			"instance_type": "m5.large",
+			"vcpu":          2,
+			"memory_gb":     8,
+		})),
+	}
+}
+
+func parseTestManifests(t *testing.T, path string, usage map[string]*schema.UsageData) map[string]*schema.Resource {
+	objects, err := loadManifests(path)
+	require.NoError(t, err)
+
+	p := NewParser(config.EmptyProjectContext())
+
+	byName := make(map[string]*schema.Resource)
+	for _, r := range p.parseManifests(objects, usage) {
+		byName[r.Name] = r
+	}
+
+	return byName
+}
+
+// requestQuantity returns the requested quantity of a workload cost component,
+// e.g. the vCPUs for the CPU requests.
+func requestQuantity(c *schema.CostComponent) string {
+	return c.HourlyQuantity.Div(c.UnitMultiplier).Round(6).String()
+}
+
+func TestParseManifests(t *testing.T) {
+	byName := parseTestManifests(t, "testdata/manifests", nodeProfileUsage("aws"))
+
+	assert.ElementsMatch(t, []string{
+		"kubernetes_deployment.default.web",
+		"kubernetes_stateful_set.data.db",
+		"kubernetes_service.default.web",
+		"kubernetes_service.data.db",
+		"kubernetes_config_map.default.web",
+		"kubernetes_widget.default.gadget",
+		"kubernetes_storage_class.fast",
+		"kubernetes_storage_class.standard",
+		"kubernetes_persistent_volume_claim.default.uploads",
+	}, resourceNames(byName))
+
+	web := byName["kubernetes_deployment.default.web"]
+	assert.Equal(t, "kubernetes_deployment", web.ResourceType)
+	assert.Equal(t, map[string]string{"app": "web"}, web.Tags)
+	assert.Equal(t, []string{"CPU requests (m5.large)", "Memory requests (m5.large)"}, costComponentNames(web))
+	// The init container requests more CPU than the containers
+	assert.Equal(t, "3", requestQuantity(web.CostComponents[0]))
+	assert.Equal(t, "3", requestQuantity(web.CostComponents[1]))
+	assert.Equal(t, "m5.large", *web.CostComponents[0].ProductFilter.AttributeFilters[0].Value)
+	assert.Equal(t, "eu-west-1", *web.CostComponents[0].ProductFilter.Region)
+
+	// The CPU and memory of the node add up to the price of the node
+	node := web.CostComponents[0].UnitMultiplier.Mul(decimal.NewFromInt(2)).Add(web.CostComponents[1].UnitMultiplier.Mul(decimal.NewFromInt(8)))
+	assert.Equal(t, "1", node.Round(6).String())
+
+	db := byName["kubernetes_stateful_set.data.db"]
+	assert.Equal(t, "4", requestQuantity(db.CostComponents[0]))
+	assert.Equal(t, "8", requestQuantity(db.CostComponents[1]))
+	require.Len(t, db.SubResources, 1)
+	data := db.SubResources[0]
+	assert.Equal(t, "volume_claim_template.data", data.Name)
+	assert.Equal(t, []string{"Storage (provisioned IOPS SSD, io1)", "Provisioned IOPS"}, costComponentNames(data))
+	assert.Equal(t, "200", data.CostComponents[0].MonthlyQuantity.String())
+	assert.Equal(t, "2000", data.CostComponents[1].MonthlyQuantity.String())
+
+	// The claim uses the default storage class
+	uploads := byName["kubernetes_persistent_volume_claim.default.uploads"]
+	assert.Equal(t, "kubernetes_persistent_volume_claim", uploads.ResourceType)
+	assert.Equal(t, []string{"Storage (general purpose SSD, gp3)"}, costComponentNames(uploads))
+	assert.Equal(t, "20", uploads.CostComponents[0].MonthlyQuantity.String())
+
+	assert.Equal(t, []string{"Network load balancer", "Load balancer capacity units"}, costComponentNames(byName["kubernetes_service.default.web"]))
+	assert.True(t, byName["kubernetes_service.data.db"].NoPrice)
+	assert.True(t, byName["kubernetes_config_map.default.web"].NoPrice)
+	assert.True(t, byName["kubernetes_storage_class.fast"].NoPrice)
+
+	assert.True(t, byName["kubernetes_widget.default.gadget"].IsSkipped)
+	assert.Equal(t, "This resource is not currently supported", byName["kubernetes_widget.default.gadget"].SkipMessage)
+}
+
+func TestParseManifestsWithoutNodeProfile(t *testing.T) {
+	byName := parseTestManifests(t, "testdata/manifests", map[string]*schema.UsageData{})
+
+	web := byName["kubernetes_deployment.default.web"]
+	assert.True(t, web.IsSkipped)
+	assert.Equal(t, "Set instance_type, vcpu and memory_gb for kubernetes_node_profile in the usage file to price workloads", web.SkipMessage)
+
+	// The volumes of stateful sets are still priced
+	db := byName["kubernetes_stateful_set.data.db"]
+	assert.False(t, db.IsSkipped)
+	assert.Empty(t, db.CostComponents)
+	require.Len(t, db.SubResources, 1)
+	assert.Equal(t, "us-east-1", *db.SubResources[0].CostComponents[0].ProductFilter.Region)
+}
+
+func TestParseManifestsReplicasUsage(t *testing.T) {
+	usage := nodeProfileUsage("aws")
+	usage["kubernetes_stateful_set.data.db"] = schema.NewUsageData("kubernetes_stateful_set.data.db", schema.ParseAttributes(map[string]interface{}{
+		"replicas": 5,
+	}))
+
+	db := parseTestManifests(t, "testdata/manifests", usage)["kubernetes_stateful_set.data.db"]
+	assert.Equal(t, "10", requestQuantity(db.CostComponents[0]))
+	assert.Equal(t, "500", db.SubResources[0].CostComponents[0].MonthlyQuantity.String())
+}
+
+func TestParseManifestsClouds(t *testing.T) {
+	google := parseTestManifests(t, "testdata/manifests/app.yaml", nodeProfileUsage("google"))
+	assert.Equal(t, "gcp", *google["kubernetes_deployment.default.web"].CostComponents[0].ProductFilter.VendorName)
+	assert.Equal(t, []string{"Forwarding rules", "Ingress data"}, costComponentNames(google["kubernetes_service.default.web"]))
+	assert.Equal(t, []string{"Balanced provisioned storage (pd-balanced)"}, costComponentNames(google["kubernetes_stateful_set.data.db"].SubResources[0]))
+
+	azure := parseTestManifests(t, "testdata/manifests/app.yaml", nodeProfileUsage("azure"))
+	assert.Equal(t, "azure", *azure["kubernetes_deployment.default.web"].CostComponents[0].ProductFilter.VendorName)
+	assert.Equal(t, []string{"Rule usage"}, costComponentNames(azure["kubernetes_service.default.web"]))
+	assert.Equal(t, []string{"Storage (E10)", "Disk operations"}, costComponentNames(azure["kubernetes_stateful_set.data.db"].SubResources[0]))
+}
+
+func TestParseResourceData(t *testing.T) {
+	o := gjson.Parse(`{"apiVersion": "storage.k8s.io/v1", "kind": "StorageClass", "metadata": {"name": "fast", "namespace": "ignored"}}`)
+
+	d := parseResourceData(o, nodeProfileValues(nil))
+	assert.Equal(t, "kubernetes_storage_class", d.Type)
+	assert.Equal(t, "kubernetes_storage_class.fast", d.Address)
+	assert.Equal(t, "aws", d.Get("cloud").String())
+	assert.Equal(t, "us-east-1", d.Get("region").String())
+	assert.Equal(t, "fast", d.Get("metadata.name").String())
+
+	o = gjson.Parse(`{"apiVersion": "autoscaling/v2", "kind": "HorizontalPodAutoscaler", "metadata": {"name": "web", "namespace": "prod"}}`)
+
+	d = parseResourceData(o, nodeProfileValues(nil))
+	assert.Equal(t, "kubernetes_horizontal_pod_autoscaler.prod.web", d.Address)
+}
+
+func TestIsManifest(t *testing.T) {
+	assert.True(t, IsManifest("testdata/manifests"))
+	assert.True(t, IsManifest("testdata/manifests/app.yaml"))
+	assert.False(t, IsManifest("testdata/manifests/storage/invalid.yaml"))
+	assert.False(t, IsManifest("testdata/manifests/missing.yaml"))
+}
+
+func resourceNames(byName map[string]*schema.Resource) []string {
+	names := make([]string, 0, len(byName))
+	for name := range byName {
+		names = append(names, name)
+	}
+	return names
+}
+
+func costComponentNames(r *schema.Resource) []string {
+	names := make([]string, 0, len(r.CostComponents))
+	for _, c := range r.CostComponents {
+		names = append(names, c.Name)
+	}
+	return names
+}
@@ -0,0 +1,191 @@
+package kubernetes
+
+import (
+	"math"
+	"strings"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/tidwall/gjson"
+
+	"github.com/infracost/infracost/internal/schema"
+)
+
+const defaultStorageClassAnnotation = "storageclass.kubernetes.io/is-default-class"
+
+// defaultStorageClasses are the default storage classes of EKS, GKE and AKS
+// clusters, which are used when a claim doesn't set the storage class and the
+// manifests don't have a default storage class.
+var defaultStorageClasses = map[string]string{
+	"aws":    "gp2",
+	"google": "standard-rwo",
+	"azure":  "default",
+}
+
+// knownDiskTypes are the disk types of the storage classes that EKS, GKE and AKS
+// clusters are created with.
+var knownDiskTypes = map[string]map[string]string{
+	"aws": {
+		"gp2": "gp2",
+		"gp3": "gp3",
+	},
+	"google": {
+		"standard":     "pd-standard",
+		"standard-rwo": "pd-balanced",
+		"premium-rwo":  "pd-ssd",
+	},
+	"azure": {
+		"default":             "StandardSSD_LRS",
+		"managed-csi":         "StandardSSD_LRS",
+		"managed-premium":     "Premium_LRS",
+		"managed-csi-premium": "Premium_LRS",
+	},
+}
+
+func GetPersistentVolumeClaimRegistryItem() *schema.RegistryItem {
+	return &schema.RegistryItem{
+		Name:  "kubernetes_persistent_volume_claim",
+		RFunc: NewPersistentVolumeClaim,
+	}
+}
+
+func NewPersistentVolumeClaim(d *schema.ResourceData, u *schema.UsageData) *schema.Resource {
+	return volumeResource(d, d.Address, d.Get("spec"), u)
+}
+
+// volumeResource returns the disk resource that's provisioned for a volume claim.
+// The disk is priced as the disk resource of the cloud, with the disk type of the
+// storage class of the claim.
+func volumeResource(d *schema.ResourceData, address string, spec gjson.Result, u *schema.UsageData) *schema.Resource {
+	cloud := d.Get("cloud").String()
+	region := d.Get("region").String()
+
+	storage := spec.Get("resources.requests.storage").String()
+	sizeGB, err := parseGB(storage)
+	if err != nil {
+		log.Warnf("Skipping volume %s since its storage request %s is invalid", address, storage)
+		return nil
+	}
+	size := int64(math.Ceil(sizeGB))
+
+	diskType := storageClassDiskType(cloud, d.References("storage_classes"), spec.Get("storageClassName").String())
+
+	switch cloud {
+	case "google":
+		return terraformResource("google_compute_disk", address, map[string]interface{}{
+			"region": region,
+			"type":   diskType,
+			"size":   size,
+		}, u)
+	case "azure":
+		return terraformResource("azurerm_managed_disk", address, map[string]interface{}{
+			"location":             region,
+			"storage_account_type": diskType,
+			"disk_size_gb":         size,
+		}, u)
+	default:
+		values := map[string]interface{}{
+			"region": region,
+			"type":   diskType,
+			"size":   size,
+		}
+		for k, v := range awsVolumeParameters(d.References("storage_classes"), spec.Get("storageClassName").String(), size) {
+			values[k] = v
+		}
+		return terraformResource("aws_ebs_volume", address, values, u)
+	}
+}
+
+// storageClass returns the storage class with the name, or the default storage
+// class if the name is empty.
+func storageClass(classes []*schema.ResourceData, name string) *schema.ResourceData {
+	for _, sc := range classes {
+		if name == "" && annotation(sc.RawValues, defaultStorageClassAnnotation) == "true" {
+			return sc
+		}
+		if name != "" && sc.Get("metadata.name").String() == name {
+			return sc
+		}
+	}
+
+	return nil
+}
+
+// storageClassDiskType returns the disk type of the storage class, which is
+// either in the manifests or is one of the storage classes that the cluster is
+// created with.
+func storageClassDiskType(cloud string, classes []*schema.ResourceData, name string) string {
+	if sc := storageClass(classes, name); sc != nil {
+		return provisionerDiskType(cloud, sc)
+	}
+
+	if name == "" {
+		name = defaultStorageClasses[cloud]
+	}
+
+	if t, ok := knownDiskTypes[cloud][name]; ok {
+		return t
+	}
+
+	defaultType := knownDiskTypes[cloud][defaultStorageClasses[cloud]]
+	log.Debugf("Using the %s disk type for unknown storage class %s", defaultType, name)
+
+	return defaultType
+}
+
+// provisionerDiskType returns the disk type from the parameters of a storage
+// class, or the default disk type of its provisioner.
+func provisionerDiskType(cloud string, sc *schema.ResourceData) string {
+	provisioner := sc.Get("provisioner").String()
+	parameters := sc.Get("parameters").Map()
+
+	switch cloud {
+	case "google":
+		if t := parameters["type"].String(); t != "" {
+			return t
+		}
+		return "pd-standard"
+	case "azure":
+		// The Azure parameter names are case insensitive
+		for k, v := range parameters {
+			switch strings.ToLower(k) {
+			case "skuname", "storageaccounttype":
+				return v.String()
+			}
+		}
+		if provisioner == "kubernetes.io/azure-disk" {
+			return "Standard_LRS"
+		}
+		return "StandardSSD_LRS"
+	default:
+		if t := parameters["type"].String(); t != "" {
+			return t
+		}
+		if provisioner == "ebs.csi.aws.com" {
+			return "gp3"
+		}
+		return "gp2"
+	}
+}
+
+// awsVolumeParameters returns the IOPS and throughput of EBS volumes from the
+// parameters of the storage class.
+func awsVolumeParameters(classes []*schema.ResourceData, name string, size int64) map[string]interface{} {
+	values := make(map[string]interface{})
+
+	sc := storageClass(classes, name)
+	if sc == nil {
+		return values
+	}
+
+	if sc.Get("parameters.iops").Exists() {
+		values["iops"] = sc.Get("parameters.iops").Int()
+	} else if sc.Get("parameters.iopsPerGB").Exists() {
+		values["iops"] = sc.Get("parameters.iopsPerGB").Int() * size
+	}
+
+	if sc.Get("parameters.throughput").Exists() {
+		values["throughput"] = sc.Get("parameters.throughput").Int()
+	}
+
+	return values
+}
@@ -0,0 +1,63 @@
+package kubernetes
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+var quantitySuffixes = []struct {
+	suffix     string
+	multiplier float64
+}{
+	// The binary suffixes are checked first since they end with the decimal ones
+	{"Ki", 1 << 10},
+	{"Mi", 1 << 20},
+	{"Gi", 1 << 30},
+	{"Ti", 1 << 40},
+	{"Pi", 1 << 50},
+	{"Ei", 1 << 60},
+	{"n", 1e-9},
+	{"u", 1e-6},
+	{"m", 1e-3},
+	{"k", 1e3},
+	{"M", 1e6},
+	{"G", 1e9},
+	{"T", 1e12},
+	{"P", 1e15},
+	{"E", 1e18},
+}
+
+// parseQuantity parses a Kubernetes resource quantity, e.g. 500m CPU or 1.5Gi
+// of memory.
+func parseQuantity(s string) (float64, error) {
+	v := strings.TrimSpace(s)
+
+	multiplier := 1.0
+	for _, q := range quantitySuffixes {
+		if strings.HasSuffix(v, q.suffix) {
+			v = strings.TrimSuffix(v, q.suffix)
+			multiplier = q.multiplier
+			break
+		}
+	}
+
+	// Quantities can also use exponents, e.g. 1e3, which ParseFloat handles
+	f, err := strconv.ParseFloat(v, 64)
+	if err != nil {
+		return 0, fmt.Errorf("Invalid quantity %s", s)
+	}
+
+	return f * multiplier, nil
+}
+
+// parseGB parses a Kubernetes quantity of bytes to GB, where a GB is 2^30 bytes
+// as it is for cloud disks and instance memory.
+func parseGB(s string) (float64, error) {
+	b, err := parseQuantity(s)
+	if err != nil {
+		return 0, err
+	}
+
+	return b / (1 << 30), nil
+}
@@ -0,0 +1,43 @@
+package kubernetes
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseQuantity(t *testing.T) {
+	tests := []struct {
+		quantity string
+		expected float64
+	}{
+		{"2", 2},
+		{"500m", 0.5},
+		{"1.5", 1.5},
+		{"1k", 1000},
+		{"1Ki", 1024},
+		{"128Mi", 128 * 1024 * 1024},
+		{"1G", 1e9},
+		{"1e3", 1000},
+	}
+
+	for _, test := range tests {
+		actual, err := parseQuantity(test.quantity)
+		require.NoError(t, err, test.quantity)
+		assert.Equal(t, test.expected, actual, test.quantity)
+	}
+
+	_, err := parseQuantity("lots")
+	assert.EqualError(t, err, "Invalid quantity lots")
+}
+
+func TestParseGB(t *testing.T) {
+	actual, err := parseGB("512Mi")
+	require.NoError(t, err)
+	assert.Equal(t, 0.5, actual)
+
+	actual, err = parseGB("100Gi")
+	require.NoError(t, err)
+	assert.Equal(t, 100.0, actual)
+}
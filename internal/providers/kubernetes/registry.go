@@ -0,0 +1,66 @@
+package kubernetes
+
+import (
+	"sync"
+
+	"github.com/infracost/infracost/internal/schema"
+)
+
+type ResourceRegistryMap map[string]*schema.RegistryItem
+
+var (
+	resourceRegistryMap ResourceRegistryMap
+	once                sync.Once
+)
+
+var ResourceRegistry []*schema.RegistryItem = []*schema.RegistryItem{
+	GetDeploymentRegistryItem(),
+	GetStatefulSetRegistryItem(),
+	GetPersistentVolumeClaimRegistryItem(),
+	GetServiceRegistryItem(),
+}
+
+// FreeResources grouped alphabetically
+var FreeResources = []string{
+	"kubernetes_cluster_role",
+	"kubernetes_cluster_role_binding",
+	"kubernetes_config_map",
+	"kubernetes_horizontal_pod_autoscaler",
+	"kubernetes_limit_range",
+	"kubernetes_namespace",
+	"kubernetes_network_policy",
+	"kubernetes_pod_disruption_budget",
+	"kubernetes_resource_quota",
+	"kubernetes_role",
+	"kubernetes_role_binding",
+	"kubernetes_secret",
+	"kubernetes_service_account",
+	"kubernetes_storage_class",
+}
+
+func GetResourceRegistryMap() *ResourceRegistryMap {
+	once.Do(func() {
+		resourceRegistryMap = make(ResourceRegistryMap)
+
+		for _, registryItem := range ResourceRegistry {
+			resourceRegistryMap[registryItem.Name] = registryItem
+		}
+		for _, registryItem := range createFreeResources(FreeResources) {
+			resourceRegistryMap[registryItem.Name] = registryItem
+		}
+	})
+
+	return &resourceRegistryMap
+}
+
+func createFreeResources(l []string) []*schema.RegistryItem {
+	freeResources := make([]*schema.RegistryItem, 0)
+	for _, resourceName := range l {
+		freeResources = append(freeResources, &schema.RegistryItem{
+			Name:    resourceName,
+			NoPrice: true,
+			Notes:   []string{"Free resource."},
+		})
+	}
+	return freeResources
+}
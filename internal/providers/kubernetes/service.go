@@ -0,0 +1,56 @@
+package kubernetes
+
+import (
+	"github.com/infracost/infracost/internal/schema"
+)
+
+const awsLoadBalancerTypeAnnotation = "service.beta.kubernetes.io/aws-load-balancer-type"
+
+func GetServiceRegistryItem() *schema.RegistryItem {
+	return &schema.RegistryItem{
+		Name:  "kubernetes_service",
+		RFunc: NewService,
+	}
+}
+
+// NewService returns the cloud load balancer that's created for a service of type
+// LoadBalancer. Other types of services are free.
+func NewService(d *schema.ResourceData, u *schema.UsageData) *schema.Resource {
+	if d.Get("spec.type").String() != "LoadBalancer" {
+		return &schema.Resource{
+			Name:        d.Address,
+			IsSkipped:   true,
+			NoPrice:     true,
+			SkipMessage: "Free resource.",
+		}
+	}
+
+	region := d.Get("region").String()
+
+	switch d.Get("cloud").String() {
+	case "google":
+		return terraformResource("google_compute_forwarding_rule", d.Address, map[string]interface{}{
+			"region": region,
+		}, u)
+	case "azure":
+		// AKS adds a rule to the load balancer of the cluster for each service
+		return terraformResource("azurerm_lb_rule", d.Address, map[string]interface{}{
+			"location": region,
+			"region":   region,
+		}, u)
+	default:
+		// The AWS Load Balancer Controller creates a network load balancer, otherwise
+		// the in-tree controller creates a classic load balancer
+		switch annotation(d.RawValues, awsLoadBalancerTypeAnnotation) {
+		case "nlb", "nlb-ip", "external":
+			return terraformResource("aws_lb", d.Address, map[string]interface{}{
+				"region":             region,
+				"load_balancer_type": "network",
+			}, u)
+		}
+
+		return terraformResource("aws_elb", d.Address, map[string]interface{}{
+			"region": region,
+		}, u)
+	}
+}
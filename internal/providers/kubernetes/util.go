@@ -0,0 +1,32 @@
+package kubernetes
+
+import (
+	"encoding/json"
+	"strings"
+
+	"github.com/tidwall/gjson"
+
+	"github.com/infracost/infracost/internal/providers/terraform"
+	"github.com/infracost/infracost/internal/schema"
+)
+
+// terraformResource returns the resource for a Terraform resource type with the
+// values, so that the cloud resources that Kubernetes creates, e.g. disks and
+// load balancers, are priced the same as they are in Terraform.
+func terraformResource(resourceType string, address string, values map[string]interface{}, u *schema.UsageData) *schema.Resource {
+	registryItem, ok := (*terraform.GetResourceRegistryMap())[resourceType]
+	if !ok || registryItem.RFunc == nil {
+		return nil
+	}
+
+	b, _ := json.Marshal(values)
+	d := schema.NewResourceData(resourceType, strings.Split(resourceType, "_")[0], address, map[string]string{}, gjson.ParseBytes(b))
+
+	return registryItem.RFunc(d, u)
+}
+
+// annotation returns the value of an annotation, since annotation names contain
+// dots that can't be used in gjson paths.
+func annotation(o gjson.Result, name string) string {
+	return o.Get("metadata.annotations").Map()[name].String()
+}
@@ -0,0 +1,166 @@
+package kubernetes
+
+import (
+	"fmt"
+
+	"github.com/shopspring/decimal"
+	log "github.com/sirupsen/logrus"
+	"github.com/tidwall/gjson"
+
+	"github.com/infracost/infracost/internal/resources/kubernetes"
+	"github.com/infracost/infracost/internal/schema"
+)
+
+func GetDeploymentRegistryItem() *schema.RegistryItem {
+	return &schema.RegistryItem{
+		Name:  "kubernetes_deployment",
+		RFunc: NewDeployment,
+	}
+}
+
+func GetStatefulSetRegistryItem() *schema.RegistryItem {
+	return &schema.RegistryItem{
+		Name:  "kubernetes_stateful_set",
+		RFunc: NewStatefulSet,
+	}
+}
+
+func NewDeployment(d *schema.ResourceData, u *schema.UsageData) *schema.Resource {
+	a := newWorkload(d, u)
+	if a == nil {
+		return missingNodeProfileResource(d)
+	}
+
+	return a.BuildResource()
+}
+
+// NewStatefulSet returns the workload with a disk for each volume claim template
+// and replica. The disks are still priced if there's no node profile.
+func NewStatefulSet(d *schema.ResourceData, u *schema.UsageData) *schema.Resource {
+	templates := d.Get("spec.volumeClaimTemplates").Array()
+
+	a := newWorkload(d, u)
+	if a == nil && len(templates) == 0 {
+		return missingNodeProfileResource(d)
+	}
+
+	replicas := workloadReplicas(d)
+	r := &schema.Resource{
+		Name:        d.Address,
+		UsageSchema: kubernetes.WorkloadUsageSchema,
+	}
+
+	if a != nil {
+		r = a.BuildResource()
+		replicas = *a.Replicas
+	} else if u != nil && u.GetInt("replicas") != nil {
+		replicas = *u.GetInt("replicas")
+	}
+
+	for _, t := range templates {
+		name := fmt.Sprintf("volume_claim_template.%s", t.Get("metadata.name").String())
+
+		v := volumeResource(d, name, t.Get("spec"), nil)
+		if v == nil {
+			continue
+		}
+
+		schema.MultiplyQuantities(v, decimal.NewFromInt(replicas))
+		r.SubResources = append(r.SubResources, v)
+	}
+
+	return r
+}
+
+// newWorkload returns the workload with the container requests of its pod
+// template, or nil if the node profile isn't set in the usage file.
+func newWorkload(d *schema.ResourceData, u *schema.UsageData) *kubernetes.Workload {
+	profile := d.Get("node_profile")
+
+	p := kubernetes.NodeProfile{
+		Cloud:        d.Get("cloud").String(),
+		Region:       d.Get("region").String(),
+		InstanceType: profile.Get("instance_type").String(),
+		VCPU:         profile.Get("vcpu").Float(),
+		MemoryGB:     profile.Get("memory_gb").Float(),
+	}
+
+	if p.InstanceType == "" || p.VCPU <= 0 || p.MemoryGB <= 0 {
+		return nil
+	}
+
+	cpu, memoryGB := podRequests(d.Address, d.Get("spec.template.spec"))
+	replicas := workloadReplicas(d)
+
+	a := &kubernetes.Workload{
+		Address:     d.Address,
+		NodeProfile: p,
+		CPU:         cpu,
+		MemoryGB:    memoryGB,
+		Replicas:    &replicas,
+	}
+	a.PopulateUsage(u)
+
+	return a
+}
+
+func missingNodeProfileResource(d *schema.ResourceData) *schema.Resource {
+	return &schema.Resource{
+		Name:        d.Address,
+		IsSkipped:   true,
+		SkipMessage: fmt.Sprintf("Set instance_type, vcpu and memory_gb for %s in the usage file to price workloads", NodeProfileUsageKey),
+	}
+}
+
+// workloadReplicas returns the replicas of the workload, which default to 1 if
+// they're not set, e.g. when they're managed by a horizontal pod autoscaler.
+func workloadReplicas(d *schema.ResourceData) int64 {
+	if d.Get("spec.replicas").Exists() {
+		return d.Get("spec.replicas").Int()
+	}
+
+	return 1
+}
+
+// podRequests returns the CPU and memory requests of a pod. Init containers run
+// before the other containers, so the pod requests the larger of the highest
+// init container request and the sum of the container requests.
+func podRequests(address string, spec gjson.Result) (float64, float64) {
+	var cpu, memoryGB float64
+
+	for _, c := range spec.Get("containers").Array() {
+		cpu += containerRequest(address, c, "cpu", parseQuantity)
+		memoryGB += containerRequest(address, c, "memory", parseGB)
+	}
+
+	for _, c := range spec.Get("initContainers").Array() {
+		if v := containerRequest(address, c, "cpu", parseQuantity); v > cpu {
+			cpu = v
+		}
+		if v := containerRequest(address, c, "memory", parseGB); v > memoryGB {
+			memoryGB = v
+		}
+	}
+
+	return cpu, memoryGB
+}
+
+// containerRequest returns the request of a container, which defaults to the
+// limit if it's not set.
+func containerRequest(address string, c gjson.Result, name string, parse func(string) (float64, error)) float64 {
+	q := c.Get(fmt.Sprintf("resources.requests.%s", name))
+	if !q.Exists() {
+		q = c.Get(fmt.Sprintf("resources.limits.%s", name))
+	}
+	if !q.Exists() {
+		return 0
+	}
+
+	v, err := parse(q.String())
+	if err != nil {
+		log.Debugf("Ignoring the %s request of container %s in %s: %s", name, c.Get("name").String(), address, err)
+		return 0
+	}
+
+	return v
+}
@@ -0,0 +1,406 @@
+package pulumi
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+	"github.com/tidwall/gjson"
+
+	"github.com/infracost/infracost/internal/config"
+	"github.com/infracost/infracost/internal/providers/terraform"
+	"github.com/infracost/infracost/internal/schema"
+)
+
+var defaultProviderRegions = map[string]string{
+	"aws":     "us-east-1",
+	"google":  "us-central1",
+	"azurerm": "eastus",
+}
+
+// skippedOps are the preview steps for resources that aren't managed by the stack,
+// e.g. resources that are read with get.
+var skippedOps = map[string]bool{
+	"read":             true,
+	"read-replacement": true,
+	"read-discard":     true,
+	"discard":          true,
+	"refresh":          true,
+}
+
+type Parser struct {
+	ctx *config.ProjectContext
+}
+
+func NewParser(ctx *config.ProjectContext) *Parser {
+	return &Parser{ctx}
+}
+
+func (p *Parser) createResource(d *schema.ResourceData, u *schema.UsageData) *schema.Resource {
+	registryMap := terraform.GetResourceRegistryMap()
+
+	if isAwsChina(d) {
+		p.ctx.SetContextValue("isAWSChina", true)
+	}
+
+	if registryItem, ok := (*registryMap)[d.Type]; ok {
+		if registryItem.NoPrice {
+			return &schema.Resource{
+				Name:         d.Address,
+				ResourceType: d.Type,
+				Tags:         d.Tags,
+				IsSkipped:    true,
+				NoPrice:      true,
+				SkipMessage:  "Free resource.",
+			}
+		}
+
+		res := registryItem.RFunc(d, u)
+		if res != nil {
+			res.ResourceType = d.Type
+			res.Tags = d.Tags
+			res.CloudResourceIDs = cloudResourceIDs(d)
+			if u != nil {
+				res.EstimationSummary = u.CalcEstimationSummary()
+			}
+			return res
+		}
+	}
+
+	return &schema.Resource{
+		Name:         d.Address,
+		ResourceType: d.Type,
+		Tags:         d.Tags,
+		IsSkipped:    true,
+		SkipMessage:  "This resource is not currently supported",
+	}
+}
+
+// parsePreviewJSON returns the past and planned resources from the output of
+// pulumi preview --json. The past resources are the old state of each step and the
+// planned resources are the new state, except for resources that are deleted.
+func (p *Parser) parsePreviewJSON(j []byte, usage map[string]*schema.UsageData) ([]*schema.Resource, []*schema.Resource, error) {
+	if !gjson.ValidBytes(j) {
+		baseResources := p.loadUsageFileResources(usage)
+		return baseResources, baseResources, errors.New("invalid JSON")
+	}
+
+	parsed := gjson.ParseBytes(j)
+	stackConfig := parsed.Get("config")
+
+	var oldStates, newStates []gjson.Result
+
+	for _, step := range parsed.Get("steps").Array() {
+		op := step.Get("op").String()
+		if skippedOps[op] {
+			continue
+		}
+
+		oldState := step.Get("oldState")
+		newState := step.Get("newState")
+
+		if oldState.Exists() {
+			oldStates = append(oldStates, oldState)
+		}
+
+		if op == "delete" || op == "delete-replaced" {
+			continue
+		}
+
+		if newState.Exists() {
+			newStates = append(newStates, newState)
+		} else if oldState.Exists() {
+			newStates = append(newStates, oldState)
+		}
+	}
+
+	pastResources := p.parseResources(oldStates, stackConfig, usage)
+	resources := p.parseResources(newStates, stackConfig, usage)
+
+	return pastResources, resources, nil
+}
+
+// parseStateJSON returns the resources from the output of pulumi stack export.
+func (p *Parser) parseStateJSON(j []byte, usage map[string]*schema.UsageData) ([]*schema.Resource, error) {
+	if !gjson.ValidBytes(j) {
+		return p.loadUsageFileResources(usage), errors.New("invalid JSON")
+	}
+
+	parsed := gjson.ParseBytes(j)
+
+	var states []gjson.Result
+	for _, r := range parsed.Get("deployment.resources").Array() {
+		// Resources that are pending deletion after a replacement are still in the state
+		if r.Get("delete").Bool() {
+			continue
+		}
+		states = append(states, r)
+	}
+
+	return p.parseResources(states, gjson.Result{}, usage), nil
+}
+
+func (p *Parser) parseResources(states []gjson.Result, stackConfig gjson.Result, usage map[string]*schema.UsageData) []*schema.Resource {
+	var resources []*schema.Resource
+	resources = append(resources, p.loadUsageFileResources(usage)...)
+
+	for _, d := range parseResourceData(states, stackConfig) {
+		var usageData *schema.UsageData
+
+		if ud := usage[d.Address]; ud != nil {
+			usageData = ud
+		} else if strings.HasSuffix(d.Address, "]") {
+			lastIndexOfOpenBracket := strings.LastIndex(d.Address, "[")
+
+			if arrayUsageData := usage[fmt.Sprintf("%s[*]", d.Address[:lastIndexOfOpenBracket])]; arrayUsageData != nil {
+				usageData = arrayUsageData
+			}
+		}
+
+		if r := p.createResource(d, usageData); r != nil {
+			resources = append(resources, r)
+		}
+	}
+
+	return resources
+}
+
+func (p *Parser) loadUsageFileResources(u map[string]*schema.UsageData) []*schema.Resource {
+	resources := make([]*schema.Resource, 0)
+
+	for k, v := range u {
+		for _, t := range terraform.GetUsageOnlyResources() {
+			if strings.HasPrefix(k, fmt.Sprintf("%s.", t)) {
+				d := schema.NewResourceData(t, "global", k, map[string]string{}, gjson.Result{})
+				if r := p.createResource(d, v); r != nil {
+					resources = append(resources, r)
+				}
+			}
+		}
+	}
+
+	return resources
+}
+
+// parseResourceData converts the Pulumi resource states to resource data keyed by
+// address. The address is the Terraform resource type and the Pulumi resource name,
+// e.g. aws_instance.web, so usage files work the same as they do for Terraform.
+func parseResourceData(states []gjson.Result, stackConfig gjson.Result) map[string]*schema.ResourceData {
+	addrs := resourceAddresses(states)
+
+	providerRegions := make(map[string]string)
+
+	for _, s := range states {
+		if pkg := strings.TrimPrefix(s.Get("type").String(), "pulumi:providers:"); pkg != s.Get("type").String() {
+			providerRegions[s.Get("urn").String()] = regionFromValues(s.Get("inputs"))
+		}
+	}
+
+	resources := make(map[string]*schema.ResourceData)
+
+	for _, s := range states {
+		addr, ok := addrs[s.Get("urn").String()]
+		if !ok {
+			continue
+		}
+
+		pulumiType := s.Get("type").String()
+		t := terraformType(pulumiType)
+		providerPrefix := strings.Split(t, "_")[0]
+
+		v := resourceValues(s.Get("inputs"), s.Get("outputs"))
+		if id := s.Get("id").String(); id != "" && !v.Get("id").Exists() {
+			v = schema.AddRawValue(v, "id", id)
+		}
+
+		region := resourceRegion(t, v)
+		if region == "" {
+			region = providerRegions[providerURN(s.Get("provider").String())]
+		}
+		if region == "" {
+			pkg := strings.Split(pulumiType, ":")[0]
+			region = stackConfig.Get(fmt.Sprintf("%s:region.value", pkg)).String()
+			if region == "" {
+				region = stackConfig.Get(fmt.Sprintf("%s:region", pkg)).String()
+			}
+		}
+		if region == "" {
+			region = defaultProviderRegions[providerPrefix]
+
+			if region != "" {
+				log.Debugf("Falling back to default region (%s) for %s", region, addr)
+			}
+		}
+
+		v = schema.AddRawValue(v, "region", region)
+
+		resources[addr] = schema.NewResourceData(t, providerPrefix, addr, parseTags(t, v), v)
+	}
+
+	return resources
+}
+
+// resourceAddresses returns the addresses of the resources that can be costed by
+// their URN. Resources with the same type and name under different parents, e.g.
+// in two component resources, have the types of their parents added as modules,
+// e.g. module.vpc.aws_subnet.private, so that neither of them is dropped.
+func resourceAddresses(states []gjson.Result) map[string]string {
+	addrs := make(map[string]string)
+	counts := make(map[string]int)
+
+	for _, s := range states {
+		pulumiType := s.Get("type").String()
+		if !s.Get("custom").Bool() || strings.HasPrefix(pulumiType, "pulumi:") {
+			continue
+		}
+
+		urn := s.Get("urn").String()
+
+		t := terraformType(pulumiType)
+		if t == "" {
+			log.Debugf("Skipping Pulumi resource %s with unknown type %s", urn, pulumiType)
+			continue
+		}
+
+		addrs[urn] = fmt.Sprintf("%s.%s", t, resourceName(urn))
+		counts[addrs[urn]]++
+	}
+
+	used := make(map[string]bool, len(addrs))
+
+	// Go through the states in order so the addresses are the same on every run
+	for _, s := range states {
+		urn := s.Get("urn").String()
+
+		addr, ok := addrs[urn]
+		if !ok {
+			continue
+		}
+
+		if counts[addr] > 1 {
+			addr = parentModules(urn) + addr
+		}
+
+		// Resources can still clash if their parents have the same type names, or
+		// two Pulumi types map to the same Terraform type
+		unique := addr
+		for i := 2; used[unique]; i++ {
+			unique = fmt.Sprintf("%s_%d", addr, i)
+		}
+
+		used[unique] = true
+		addrs[urn] = unique
+	}
+
+	return addrs
+}
+
+// parentModules returns the types of the parents in the URN as module prefixes,
+// e.g. urn:pulumi:dev::app::acme:index:Vpc$aws:ec2/subnet:Subnet::private returns
+// module.vpc.
+func parentModules(urn string) string {
+	parts := strings.Split(urn, "::")
+	if len(parts) < 4 {
+		return ""
+	}
+
+	types := strings.Split(parts[2], "$")
+
+	prefix := ""
+	for _, t := range types[:len(types)-1] {
+		if t == "pulumi:pulumi:Stack" {
+			continue
+		}
+
+		prefix += fmt.Sprintf("module.%s.", snakeCase(t[strings.LastIndex(t, ":")+1:]))
+	}
+
+	return prefix
+}
+
+// resourceRegion returns the region from the values of the resource, e.g. the
+// location of Azure resources or the region in the ARN of AWS resources.
+func resourceRegion(resourceType string, v gjson.Result) string {
+	switch strings.Split(resourceType, "_")[0] {
+	case "aws":
+		if v.Get("region").String() != "" {
+			return v.Get("region").String()
+		}
+
+		p := strings.Split(v.Get("arn").String(), ":")
+		if len(p) >= 4 {
+			return p[3]
+		}
+	case "google":
+		return regionFromValues(v)
+	case "azurerm":
+		return v.Get("location").String()
+	}
+
+	return ""
+}
+
+// regionFromValues returns the region, or the region of the zone, from the
+// values of a provider or resource.
+func regionFromValues(v gjson.Result) string {
+	if v.Get("region").String() != "" {
+		return v.Get("region").String()
+	}
+
+	zone := v.Get("zone").String()
+	if i := strings.LastIndex(zone, "-"); i != -1 {
+		return zone[:i]
+	}
+
+	return ""
+}
+
+func parseTags(resourceType string, v gjson.Result) map[string]string {
+	tags := make(map[string]string)
+
+	a := "tags"
+	if strings.HasPrefix(resourceType, "google_") {
+		a = "labels"
+	}
+
+	for k, v := range v.Get(a).Map() {
+		tags[k] = v.String()
+	}
+
+	return tags
+}
+
+// resourceName returns the name of the resource from its URN, which has the format
+// urn:pulumi:<stack>::<project>::<parent types>$<type>::<name>.
+func resourceName(urn string) string {
+	return urn[strings.LastIndex(urn, "::")+2:]
+}
+
+// providerURN returns the URN of the provider from a provider reference, which is
+// the URN followed by the ID of the provider.
+func providerURN(ref string) string {
+	if i := strings.LastIndex(ref, "::"); i != -1 {
+		return ref[:i]
+	}
+
+	return ref
+}
+
+func isAwsChina(d *schema.ResourceData) bool {
+	return strings.HasPrefix(d.Type, "aws_") && strings.HasPrefix(d.Get("region").String(), "cn-")
+}
+
+// cloudResourceIDs returns the IDs of the resource from the state, which are
+// only known once the resource is deployed.
+func cloudResourceIDs(d *schema.ResourceData) []string {
+	var ids []string
+
+	for _, key := range []string{"id", "arn", "self_link"} {
+		if id := d.Get(key).String(); id != "" {
+			ids = append(ids, id)
+		}
+	}
+
+	return ids
+}
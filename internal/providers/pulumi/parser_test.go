@@ -0,0 +1,230 @@
+package pulumi
+
+import (
+	"fmt"
+	"io/ioutil"
+	"sort"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tidwall/gjson"
+
+	"github.com/infracost/infracost/internal/config"
+	"github.com/infracost/infracost/internal/schema"
+)
+
+func TestParsePreviewJSON(t *testing.T) {
+	j, err := ioutil.ReadFile("testdata/preview.json")
+	require.NoError(t, err)
+
+	p := NewParser(config.EmptyProjectContext())
+	pastResources, resources, err := p.parsePreviewJSON(j, map[string]*schema.UsageData{})
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"aws_instance.web", "aws_lambda_function.cron"}, resourceNames(pastResources))
+	assert.Equal(t, []string{"aws_db_instance.db", "aws_instance.web"}, resourceNames(resources))
+
+	for _, r := range resources {
+		if r.Name == "aws_instance.web" {
+			assert.Equal(t, []string{"i-0a1b2c3d4e5f67890", "arn:aws:ec2:eu-west-1:123456789012:instance/i-0a1b2c3d4e5f67890"}, r.CloudResourceIDs)
+		} else {
+			assert.Empty(t, r.CloudResourceIDs, r.Name)
+		}
+	}
+}
+
+func TestParseStateJSON(t *testing.T) {
+	j, err := ioutil.ReadFile("testdata/stack_export.json")
+	require.NoError(t, err)
+
+	p := NewParser(config.EmptyProjectContext())
+	resources, err := p.parseStateJSON(j, map[string]*schema.UsageData{})
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"azurerm_linux_virtual_machine.api", "google_compute_instance.worker"}, resourceNames(resources))
+}
+
+func TestParseResourceData(t *testing.T) {
+	j, err := ioutil.ReadFile("testdata/preview.json")
+	require.NoError(t, err)
+
+	parsed := gjson.ParseBytes(j)
+
+	var states []gjson.Result
+	for _, step := range parsed.Get("steps").Array() {
+		if step.Get("newState").Exists() && step.Get("op").String() != "read" {
+			states = append(states, step.Get("newState"))
+		}
+	}
+
+	actual := parseResourceData(states, parsed.Get("config"))
+	require.Len(t, actual, 2)
+
+	web := actual["aws_instance.web"]
+	require.NotNil(t, web)
+	assert.Equal(t, "aws_instance", web.Type)
+	assert.Equal(t, "aws", web.ProviderName)
+	assert.Equal(t, "eu-west-1", web.Get("region").String())
+	assert.Equal(t, "t3.large", web.Get("instance_type").String())
+	assert.Equal(t, int64(50), web.Get("root_block_device.0.volume_size").Int())
+	assert.Equal(t, "gp3", web.Get("root_block_device.0.volume_type").String())
+	assert.Equal(t, int64(1000), web.Get("ebs_block_device.0.iops").Int())
+	assert.Equal(t, "sg-0123456789abcdef0", web.Get("vpc_security_group_ids.0").String())
+	assert.False(t, web.Get("public_ip").Exists())
+	assert.False(t, web.Get("__defaults").Exists())
+	assert.Equal(t, map[string]string{"Environment": "dev", "Name": "web"}, web.Tags)
+
+	db := actual["aws_db_instance.db"]
+	require.NotNil(t, db)
+	assert.Equal(t, "eu-west-1", db.Get("region").String())
+	assert.Equal(t, "db.t3.micro", db.Get("instance_class").String())
+	assert.Equal(t, "hunter2", db.Get("password").String())
+	assert.False(t, db.Get("endpoint").Exists())
+}
+
+func TestParseResourceDataRegions(t *testing.T) {
+	j, err := ioutil.ReadFile("testdata/stack_export.json")
+	require.NoError(t, err)
+
+	states := gjson.GetBytes(j, "deployment.resources").Array()
+	actual := parseResourceData(states, gjson.Result{})
+
+	worker := actual["google_compute_instance.worker"]
+	require.NotNil(t, worker)
+	assert.Equal(t, "europe-west1", worker.Get("region").String())
+	assert.Equal(t, int64(50), worker.Get("boot_disk.0.initialize_params.0.size").Int())
+	assert.Equal(t, map[string]string{"team": "platform"}, worker.Tags)
+
+	api := actual["azurerm_linux_virtual_machine.api"]
+	require.NotNil(t, api)
+	assert.Equal(t, "westeurope", api.Get("region").String())
+	assert.Equal(t, "Premium_LRS", api.Get("os_disk.0.storage_account_type").String())
+
+	stackConfig := gjson.Parse(`{"aws:region": {"value": "ap-southeast-2"}}`)
+	bucket := gjson.Parse(`{
+		"urn": "urn:pulumi:dev::app::aws:s3/bucket:Bucket::assets",
+		"custom": true,
+		"type": "aws:s3/bucket:Bucket",
+		"inputs": {}
+	}`)
+
+	actual = parseResourceData([]gjson.Result{bucket}, stackConfig)
+	assert.Equal(t, "ap-southeast-2", actual["aws_s3_bucket.assets"].Get("region").String())
+
+	actual = parseResourceData([]gjson.Result{bucket}, gjson.Result{})
+	assert.Equal(t, "us-east-1", actual["aws_s3_bucket.assets"].Get("region").String())
+}
+
+func TestParseResourceDataDuplicateNames(t *testing.T) {
+	state := func(urn string, t string) gjson.Result {
+		return gjson.Parse(fmt.Sprintf(`{"urn": %q, "custom": true, "type": %q, "inputs": {"region": "eu-west-1"}}`, urn, t))
+	}
+
+	states := []gjson.Result{
+		state("urn:pulumi:dev::app::acme:index:Vpc$aws:ec2/natGateway:NatGateway::nat", "aws:ec2/natGateway:NatGateway"),
+		state("urn:pulumi:dev::app::acme:index:SharedVpc$aws:ec2/natGateway:NatGateway::nat", "aws:ec2/natGateway:NatGateway"),
+		state("urn:pulumi:dev::app::acme:index:Network$acme:index:Vpc$aws:ec2/natGateway:NatGateway::nat", "aws:ec2/natGateway:NatGateway"),
+		state("urn:pulumi:dev::app::other:index:Vpc$aws:ec2/natGateway:NatGateway::nat", "aws:ec2/natGateway:NatGateway"),
+		state("urn:pulumi:dev::app::aws:ec2/instance:Instance::web", "aws:ec2/instance:Instance"),
+	}
+
+	actual := parseResourceData(states, gjson.Result{})
+
+	addrs := make([]string, 0, len(actual))
+	for addr := range actual {
+		addrs = append(addrs, addr)
+	}
+	sort.Strings(addrs)
+
+	assert.Equal(t, []string{
+		"aws_instance.web",
+		"module.network.module.vpc.aws_nat_gateway.nat",
+		"module.shared_vpc.aws_nat_gateway.nat",
+		"module.vpc.aws_nat_gateway.nat",
+		"module.vpc.aws_nat_gateway.nat_2",
+	}, addrs)
+}
+
+func TestTerraformType(t *testing.T) {
+	tests := []struct {
+		pulumiType string
+		expected   string
+	}{
+		{"aws:ec2/instance:Instance", "aws_instance"},
+		{"aws:ec2/natGateway:NatGateway", "aws_nat_gateway"},
+		{"aws:lambda/function:Function", "aws_lambda_function"},
+		{"aws:rds/instance:Instance", "aws_db_instance"},
+		{"aws:cloudwatch/logGroup:LogGroup", "aws_cloudwatch_log_group"},
+		{"gcp:compute/instance:Instance", "google_compute_instance"},
+		{"gcp:storage/bucket:Bucket", "google_storage_bucket"},
+		{"azure:compute/linuxVirtualMachine:LinuxVirtualMachine", "azurerm_linux_virtual_machine"},
+		{"azure:appservice/plan:Plan", "azurerm_app_service_plan"},
+		{"invalid", ""},
+	}
+
+	for _, test := range tests {
+		assert.Equal(t, test.expected, terraformType(test.pulumiType), test.pulumiType)
+	}
+}
+
+func TestSnakeCase(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"instanceType", "instance_type"},
+		{"ipv6CidrBlock", "ipv6_cidr_block"},
+		{"LinuxVirtualMachine", "linux_virtual_machine"},
+		{"enableHTTPEndpoint", "enable_http_endpoint"},
+		{"tags", "tags"},
+	}
+
+	for _, test := range tests {
+		assert.Equal(t, test.expected, snakeCase(test.input), test.input)
+	}
+}
+
+func TestSingular(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"ebs_block_devices", "ebs_block_device"},
+		{"policies", "policy"},
+		{"ingress", "ingress"},
+		{"addresses", "address"},
+		{"boxes", "box"},
+		{"setting", "setting"},
+	}
+
+	for _, test := range tests {
+		assert.Equal(t, test.expected, singular(test.input), test.input)
+	}
+}
+
+func TestResourceValuesSecrets(t *testing.T) {
+	inputs := gjson.Parse(`{
+		"tags": {
+			"Owner": {"4dabf18193072939515e22adb298388d": "1b47061264138c4ac30d75fd1eb44270", "plaintext": "\"platform\""}
+		},
+		"settings": {"4dabf18193072939515e22adb298388d": "1b47061264138c4ac30d75fd1eb44270", "plaintext": "{\"tier\":\"db-f1-micro\"}"},
+		"masterPassword": {"4dabf18193072939515e22adb298388d": "1b47061264138c4ac30d75fd1eb44270", "ciphertext": "v1:abc"}
+	}`)
+
+	v := resourceValues(inputs, gjson.Result{})
+
+	assert.Equal(t, "platform", v.Get("tags.Owner").String())
+	assert.Equal(t, "db-f1-micro", v.Get("settings.0.tier").String())
+	assert.False(t, v.Get("master_password").Exists())
+}
+
+func resourceNames(resources []*schema.Resource) []string {
+	names := make([]string, 0, len(resources))
+	for _, r := range resources {
+		names = append(names, r.Name)
+	}
+	sort.Strings(names)
+
+	return names
+}
@@ -0,0 +1,59 @@
+package pulumi
+
+import (
+	"os"
+
+	"github.com/pkg/errors"
+
+	"github.com/infracost/infracost/internal/config"
+	"github.com/infracost/infracost/internal/schema"
+)
+
+type PreviewJSONProvider struct {
+	ctx  *config.ProjectContext
+	Path string
+}
+
+func NewPreviewJSONProvider(ctx *config.ProjectContext) schema.Provider {
+	return &PreviewJSONProvider{
+		ctx:  ctx,
+		Path: ctx.ProjectConfig.Path,
+	}
+}
+
+func (p *PreviewJSONProvider) Type() string {
+	return "pulumi_preview_json"
+}
+
+func (p *PreviewJSONProvider) DisplayType() string {
+	return "Pulumi preview JSON file"
+}
+
+func (p *PreviewJSONProvider) AddMetadata(metadata *schema.ProjectMetadata) {
+	// no op
+}
+
+func (p *PreviewJSONProvider) LoadResources(usage map[string]*schema.UsageData) ([]*schema.Project, error) {
+	j, err := os.ReadFile(p.Path)
+	if err != nil {
+		return []*schema.Project{}, errors.Wrap(err, "Error reading Pulumi preview JSON file")
+	}
+
+	metadata := config.DetectProjectMetadata(p.ctx.ProjectConfig.Path)
+	metadata.Type = p.Type()
+	p.AddMetadata(metadata)
+	name := schema.GenerateProjectName(metadata, p.ctx.RunContext.Config.EnableDashboard)
+
+	project := schema.NewProject(name, metadata)
+	parser := NewParser(p.ctx)
+
+	pastResources, resources, err := parser.parsePreviewJSON(j, usage)
+	if err != nil {
+		return []*schema.Project{project}, errors.Wrap(err, "Error parsing Pulumi preview JSON file")
+	}
+
+	project.PastResources = pastResources
+	project.Resources = resources
+
+	return []*schema.Project{project}, nil
+}
@@ -0,0 +1,60 @@
+package pulumi
+
+import (
+	"os"
+
+	"github.com/pkg/errors"
+
+	"github.com/infracost/infracost/internal/config"
+	"github.com/infracost/infracost/internal/schema"
+)
+
+type StateJSONProvider struct {
+	ctx  *config.ProjectContext
+	Path string
+}
+
+func NewStateJSONProvider(ctx *config.ProjectContext) schema.Provider {
+	return &StateJSONProvider{
+		ctx:  ctx,
+		Path: ctx.ProjectConfig.Path,
+	}
+}
+
+func (p *StateJSONProvider) Type() string {
+	return "pulumi_state_json"
+}
+
+func (p *StateJSONProvider) DisplayType() string {
+	return "Pulumi state JSON file"
+}
+
+func (p *StateJSONProvider) AddMetadata(metadata *schema.ProjectMetadata) {
+	// no op
+}
+
+func (p *StateJSONProvider) LoadResources(usage map[string]*schema.UsageData) ([]*schema.Project, error) {
+	j, err := os.ReadFile(p.Path)
+	if err != nil {
+		return []*schema.Project{}, errors.Wrap(err, "Error reading Pulumi state JSON file")
+	}
+
+	metadata := config.DetectProjectMetadata(p.ctx.ProjectConfig.Path)
+	metadata.Type = p.Type()
+	p.AddMetadata(metadata)
+	name := schema.GenerateProjectName(metadata, p.ctx.RunContext.Config.EnableDashboard)
+
+	project := schema.NewProject(name, metadata)
+	parser := NewParser(p.ctx)
+
+	resources, err := parser.parseStateJSON(j, usage)
+	if err != nil {
+		return []*schema.Project{project}, errors.Wrap(err, "Error parsing Pulumi state JSON file")
+	}
+
+	// The state only has the current resources so there's nothing to diff against
+	project.HasDiff = false
+	project.Resources = resources
+
+	return []*schema.Project{project}, nil
+}
@@ -0,0 +1,126 @@
+package pulumi
+
+import (
+	"strings"
+
+	"github.com/infracost/infracost/internal/providers/terraform"
+)
+
+// providerPrefixes maps the Pulumi packages that are bridged from Terraform providers
+// to the prefix of the Terraform resource types.
+var providerPrefixes = map[string]string{
+	"aws":   "aws",
+	"gcp":   "google",
+	"azure": "azurerm",
+}
+
+// typeOverrides are the Pulumi types whose Terraform resource type can't be worked
+// out from the module and resource name, e.g. aws:rds/instance:Instance is called
+// aws_db_instance in Terraform.
+var typeOverrides = map[string]string{
+	"aws:alb/loadBalancer:LoadBalancer":                         "aws_alb",
+	"aws:apigateway/restApi:RestApi":                            "aws_api_gateway_rest_api",
+	"aws:apigateway/stage:Stage":                                "aws_api_gateway_stage",
+	"aws:cfg/recorder:Recorder":                                 "aws_config_configuration_recorder",
+	"aws:cfg/rule:Rule":                                         "aws_config_config_rule",
+	"aws:directconnect/connection:Connection":                   "aws_dx_connection",
+	"aws:ec2clientvpn/endpoint:Endpoint":                        "aws_ec2_client_vpn_endpoint",
+	"aws:ec2clientvpn/networkAssociation:NetworkAssociation":    "aws_ec2_client_vpn_network_association",
+	"aws:ec2transitgateway/peeringAttachment:PeeringAttachment": "aws_ec2_transit_gateway_peering_attachment",
+	"aws:ec2transitgateway/vpcAttachment:VpcAttachment":         "aws_ec2_transit_gateway_vpc_attachment",
+	"aws:elb/loadBalancer:LoadBalancer":                         "aws_elb",
+	"aws:lb/loadBalancer:LoadBalancer":                          "aws_lb",
+	"aws:rds/instance:Instance":                                 "aws_db_instance",
+	"azure:appservice/plan:Plan":                                "azurerm_app_service_plan",
+	"azure:eventhub/eventHubNamespace:EventHubNamespace":        "azurerm_eventhub_namespace",
+	"azure:keyvault/key:Key":                                    "azurerm_key_vault_key",
+	"azure:lb/loadBalancer:LoadBalancer":                        "azurerm_lb",
+	"azure:monitoring/actionGroup:ActionGroup":                  "azurerm_monitor_action_group",
+}
+
+// terraformType returns the Terraform resource type for the Pulumi type token, e.g.
+// aws:ec2/instance:Instance returns aws_instance. Most bridged resources are named
+// <provider>_<module>_<resource> or <provider>_<resource> in Terraform, so both are
+// looked up in the resource registry and the first that exists is used.
+func terraformType(pulumiType string) string {
+	if t, ok := typeOverrides[pulumiType]; ok {
+		return t
+	}
+
+	parts := strings.Split(pulumiType, ":")
+	if len(parts) != 3 {
+		return ""
+	}
+
+	prefix, ok := providerPrefixes[parts[0]]
+	if !ok {
+		prefix = parts[0]
+	}
+
+	module := parts[1]
+	resource := parts[1]
+	if i := strings.Index(parts[1], "/"); i != -1 {
+		module = parts[1][:i]
+		resource = parts[1][i+1:]
+	}
+
+	var candidates []string
+	if module != "index" && module != resource {
+		candidates = append(candidates, prefix+"_"+module+"_"+snakeCase(resource))
+	}
+	candidates = append(candidates, prefix+"_"+snakeCase(resource))
+
+	registryMap := terraform.GetResourceRegistryMap()
+	for _, c := range candidates {
+		if _, ok := (*registryMap)[c]; ok {
+			return c
+		}
+	}
+
+	return candidates[0]
+}
+
+// snakeCase converts the camel case names that Pulumi uses to the snake case names
+// in Terraform, e.g. ipv6CidrBlock returns ipv6_cidr_block.
+func snakeCase(s string) string {
+	var b strings.Builder
+
+	runes := []rune(s)
+	for i, r := range runes {
+		if isUpper(r) {
+			if i > 0 && (!isUpper(runes[i-1]) || (i+1 < len(runes) && isLower(runes[i+1]))) {
+				b.WriteRune('_')
+			}
+			r += 'a' - 'A'
+		}
+		b.WriteRune(r)
+	}
+
+	return b.String()
+}
+
+// singular returns the singular of a block name. Pulumi uses plural names for
+// blocks that can be repeated, e.g. ebsBlockDevices, whereas Terraform uses the
+// singular name, e.g. ebs_block_device.
+func singular(s string) string {
+	switch {
+	case strings.HasSuffix(s, "ies"):
+		return strings.TrimSuffix(s, "ies") + "y"
+	case strings.HasSuffix(s, "sses"), strings.HasSuffix(s, "xes"):
+		return strings.TrimSuffix(s, "es")
+	case strings.HasSuffix(s, "ss"):
+		return s
+	case strings.HasSuffix(s, "s"):
+		return strings.TrimSuffix(s, "s")
+	}
+
+	return s
+}
+
+func isUpper(r rune) bool {
+	return r >= 'A' && r <= 'Z'
+}
+
+func isLower(r rune) bool {
+	return r >= 'a' && r <= 'z'
+}
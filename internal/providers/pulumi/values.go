@@ -0,0 +1,201 @@
+package pulumi
+
+import (
+	"encoding/json"
+	"strings"
+
+	"github.com/tidwall/gjson"
+)
+
+const (
+	// unknownValue is the placeholder Pulumi uses in previews for values that aren't
+	// known until the resource is created.
+	unknownValue = "04da6b54-80e4-46f7-96ec-b56ff0331ba9"
+
+	// sigKey and secretSig mark the objects that wrap secret values.
+	sigKey    = "4dabf18193072939515e22adb298388d"
+	secretSig = "1b47061264138c4ac30d75fd1eb44270"
+)
+
+// mapAttributes are the Terraform attributes that are maps rather than blocks, so
+// their keys are kept as they are and they aren't wrapped in a list.
+var mapAttributes = map[string]bool{
+	"app_settings":    true,
+	"labels":          true,
+	"metadata":        true,
+	"parameters":      true,
+	"resource_labels": true,
+	"tags":            true,
+	"tags_all":        true,
+	"variables":       true,
+}
+
+// resourceValues merges the outputs and inputs of a Pulumi resource and converts
+// them to the format of the Terraform plan JSON values, so that the values can be
+// read by the Terraform resource functions. The inputs take precedence since the
+// outputs of resources in a preview are often unknown.
+func resourceValues(inputs, outputs gjson.Result) gjson.Result {
+	values := map[string]interface{}{}
+
+	for _, r := range []gjson.Result{outputs, inputs} {
+		var m map[string]interface{}
+		_ = json.Unmarshal([]byte(r.Raw), &m)
+
+		for k, v := range m {
+			values[k] = v
+		}
+	}
+
+	converted, _ := convertObject(values).(map[string]interface{})
+	if converted == nil {
+		converted = map[string]interface{}{}
+	}
+
+	b, _ := json.Marshal(converted)
+
+	return gjson.ParseBytes(b)
+}
+
+// convertObject converts the keys of the object to snake case and the nested
+// objects to lists with a single item, since Pulumi flattens Terraform blocks with
+// at most one item to objects.
+func convertObject(m map[string]interface{}) interface{} {
+	if sig, ok := m[sigKey]; ok {
+		return unwrapSecret(sig, m)
+	}
+
+	converted := make(map[string]interface{}, len(m))
+
+	for k, v := range m {
+		// Pulumi adds internal keys like __defaults to the inputs
+		if strings.HasPrefix(k, "__") {
+			continue
+		}
+
+		key := snakeCase(k)
+
+		if mapAttributes[key] {
+			if attr, ok := v.(map[string]interface{}); ok {
+				converted[key] = convertMapAttribute(attr)
+				continue
+			}
+		}
+
+		switch val := v.(type) {
+		case map[string]interface{}:
+			c := convertObject(val)
+			if _, ok := c.(map[string]interface{}); ok {
+				c = []interface{}{c}
+			}
+			if c != nil {
+				converted[key] = c
+			}
+		case []interface{}:
+			list := convertList(val)
+			if isBlockList(list) {
+				key = singular(key)
+			}
+			converted[key] = list
+		case string:
+			if val != unknownValue {
+				converted[key] = val
+			}
+		default:
+			converted[key] = val
+		}
+	}
+
+	return converted
+}
+
+func convertList(l []interface{}) []interface{} {
+	converted := make([]interface{}, 0, len(l))
+
+	for _, v := range l {
+		switch val := v.(type) {
+		case map[string]interface{}:
+			if c := convertObject(val); c != nil {
+				converted = append(converted, c)
+			}
+		case []interface{}:
+			converted = append(converted, convertList(val))
+		case string:
+			if val != unknownValue {
+				converted = append(converted, val)
+			}
+		default:
+			converted = append(converted, val)
+		}
+	}
+
+	return converted
+}
+
+// convertMapAttribute keeps the keys of map attributes, e.g. tags, and only
+// removes the unknown values and secrets.
+func convertMapAttribute(m map[string]interface{}) map[string]interface{} {
+	converted := make(map[string]interface{}, len(m))
+
+	for k, v := range m {
+		if s, ok := v.(string); ok && s == unknownValue {
+			continue
+		}
+
+		if obj, ok := v.(map[string]interface{}); ok {
+			if sig, ok := obj[sigKey]; ok {
+				v = unwrapSecret(sig, obj)
+			}
+		}
+
+		if v != nil {
+			converted[k] = v
+		}
+	}
+
+	return converted
+}
+
+// unwrapSecret returns the value of a secret. Previews have the plain value, and
+// stack exports have the value as a JSON string in plaintext unless the stack was
+// exported without --show-secrets. Nil is returned for encrypted secrets and other
+// signed objects like assets.
+func unwrapSecret(sig interface{}, m map[string]interface{}) interface{} {
+	if sig != secretSig {
+		return nil
+	}
+
+	if v, ok := m["value"]; ok {
+		return convertSecretValue(v)
+	}
+
+	if s, ok := m["plaintext"].(string); ok {
+		var v interface{}
+		if json.Unmarshal([]byte(s), &v) == nil {
+			return convertSecretValue(v)
+		}
+	}
+
+	return nil
+}
+
+func convertSecretValue(v interface{}) interface{} {
+	switch val := v.(type) {
+	case map[string]interface{}:
+		return convertObject(val)
+	case []interface{}:
+		return convertList(val)
+	}
+
+	return v
+}
+
+// isBlockList returns true if the list contains blocks, as opposed to a list of
+// values like the IDs of security groups.
+func isBlockList(l []interface{}) bool {
+	if len(l) == 0 {
+		return false
+	}
+
+	_, ok := l[0].(map[string]interface{})
+	return ok
+}
@@ -1,8 +1,8 @@
 package aws
 
 import (
+	"github.com/infracost/infracost/internal/resources/aws"
 	"github.com/infracost/infracost/internal/schema"
-	"github.com/shopspring/decimal"
 )
 
 func GetELBRegistryItem() *schema.RegistryItem {
@@ -13,15 +13,11 @@ func GetELBRegistryItem() *schema.RegistryItem {
 }
 
 func NewELB(d *schema.ResourceData, u *schema.UsageData) *schema.Resource {
-	productFamily := "Load Balancer"
-	costComponentName := "Classic load balancer"
-
-	var dataProcessed *decimal.Decimal
-	if u != nil && u.Get("monthly_data_processed_gb").Exists() {
-		dataProcessed = decimalPtr(decimal.NewFromInt(u.Get("monthly_data_processed_gb").Int()))
+	a := &aws.ELB{
+		Address: d.Address,
+		Region:  d.Get("region").String(),
 	}
+	a.PopulateUsage(u)
 
-	var maxLCU *decimal.Decimal
-
-	return newLBResource(d, productFamily, costComponentName, dataProcessed, maxLCU)
+	return a.BuildResource()
 }
@@ -8,6 +8,10 @@ func intPtr(i int64) *int64 {
 	return &i
 }
 
+func floatPtr(f float64) *float64 {
+	return &f
+}
+
 func strPtr(s string) *string {
 	return &s
 }
@@ -1,15 +1,23 @@
 package azure
 
 import (
+	"context"
 	"fmt"
+	"math"
 	"strings"
 
 	"github.com/infracost/infracost/internal/schema"
 	"github.com/infracost/infracost/internal/usage"
+	"github.com/infracost/infracost/internal/usage/azure"
 	"github.com/shopspring/decimal"
 	"github.com/tidwall/gjson"
 )
 
+var ApplicationGatewayUsageSchema = []*schema.UsageItem{
+	{Key: "monthly_data_processed_gb", DefaultValue: 0, ValueType: schema.Int64},
+	{Key: "monthly_v2_capacity_units", DefaultValue: 0, ValueType: schema.Int64},
+}
+
 func GetAzureRMApplicationGatewayRegistryItem() *schema.RegistryItem {
 	return &schema.RegistryItem{
 		Name:  "azurerm_application_gateway",
@@ -91,8 +99,32 @@ func NewAzureRMApplicationGateway(d *schema.ResourceData, u *schema.UsageData) *
 
 	}
 
+	estimate := func(ctx context.Context, values map[string]interface{}) error {
+		id, err := azure.ResourceID(ctx, d.Get("id").String(), d.Get("resource_group_name").String(), "Microsoft.Network/applicationGateways", d.Get("name").String())
+		if err != nil {
+			return err
+		}
+
+		bytes, err := azure.ApplicationGatewayGetBytesProcessed(ctx, id)
+		if err != nil {
+			return err
+		}
+		values["monthly_data_processed_gb"] = int64(math.Round(bytes / 1024 / 1024 / 1024))
+
+		if sku == "v2" {
+			units, err := azure.ApplicationGatewayGetCapacityUnitHours(ctx, id)
+			if err != nil {
+				return err
+			}
+			values["monthly_v2_capacity_units"] = int64(math.Round(units))
+		}
+		return nil
+	}
+
 	return &schema.Resource{
 		Name:           d.Address,
+		UsageSchema:    ApplicationGatewayUsageSchema,
+		EstimateUsage:  estimate,
 		CostComponents: costComponents,
 	}
 }
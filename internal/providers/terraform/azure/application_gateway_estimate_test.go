@@ -0,0 +1,84 @@
+package azure_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/infracost/infracost/internal/providers/terraform/azure"
+)
+
+func TestAzureApplicationGatewayEstimate(t *testing.T) {
+	stub := stubAzure(t)
+	defer stub.Close()
+
+	id := testResourceID("my-rg", "Microsoft.Network/applicationGateways", "my-gateway")
+
+	stub.WhenMetric(id, "BytesReceived,BytesSent", "").Then(200, `{
+		"value": [
+			{"name": {"value": "BytesReceived"}, "timeseries": [{"data": [{"total": 322122547200}]}]},
+			{"name": {"value": "BytesSent"}, "timeseries": [{"data": [{"total": 107374182400}, {"total": 107374182400}]}]}
+		]
+	}`)
+	stub.WhenMetric(id, "CapacityUnits", "").Then(200, `{
+		"value": [{"timeseries": [{"data": [{"average": 2.5}, {"average": 3.5}, {"average": 4}]}]}]
+	}`)
+
+	d := newResourceData("azurerm_application_gateway", "azurerm_application_gateway.gateway", `{
+		"name": "my-gateway",
+		"resource_group_name": "my-rg",
+		"location": "eastus",
+		"sku": [{"name": "Standard_v2", "tier": "Standard_v2", "capacity": 2}]
+	}`)
+	resource := azure.NewAzureRMApplicationGateway(d, nil)
+	estimates := newEstimates(stub.ctx, t, resource, nil)
+	assert.Equal(t, int64(500), estimates.usage["monthly_data_processed_gb"])
+	assert.Equal(t, int64(10), estimates.usage["monthly_v2_capacity_units"])
+}
+
+func TestAzureApplicationGatewayEstimateV1(t *testing.T) {
+	stub := stubAzure(t)
+	defer stub.Close()
+
+	id := testResourceID("my-rg", "Microsoft.Network/applicationGateways", "my-gateway")
+
+	// Only v2 gateways have capacity units, so the CapacityUnits metric isn't stubbed
+	stub.WhenMetric(id, "BytesReceived,BytesSent", "").Then(200, `{
+		"value": [
+			{"name": {"value": "BytesReceived"}, "timeseries": [{"data": [{"total": 10737418240}]}]},
+			{"name": {"value": "BytesSent"}, "timeseries": [{"data": [{"total": 10737418240}]}]}
+		]
+	}`)
+
+	d := newResourceData("azurerm_application_gateway", "azurerm_application_gateway.gateway", `{
+		"name": "my-gateway",
+		"resource_group_name": "my-rg",
+		"location": "eastus",
+		"sku": [{"name": "Standard_Small", "tier": "Standard", "capacity": 2}]
+	}`)
+	resource := azure.NewAzureRMApplicationGateway(d, nil)
+	estimates := newEstimates(stub.ctx, t, resource, nil)
+	assert.Equal(t, int64(20), estimates.usage["monthly_data_processed_gb"])
+	assert.Nil(t, estimates.usage["monthly_v2_capacity_units"])
+}
+
+func TestAzureApplicationGatewayEstimateError(t *testing.T) {
+	stub := stubAzure(t)
+	defer stub.Close()
+
+	id := testResourceID("my-rg", "Microsoft.Network/applicationGateways", "my-gateway")
+
+	stub.WhenMetric(id, "BytesReceived,BytesSent", "").Then(403, `{
+		"error": {"code": "AuthorizationFailed", "message": "The client does not have authorization to perform action 'microsoft.insights/metrics/read'"}
+	}`)
+
+	d := newResourceData("azurerm_application_gateway", "azurerm_application_gateway.gateway", `{
+		"name": "my-gateway",
+		"resource_group_name": "my-rg",
+		"location": "eastus",
+		"sku": [{"name": "Standard_Small", "tier": "Standard", "capacity": 2}]
+	}`)
+	resource := azure.NewAzureRMApplicationGateway(d, nil)
+	err := resource.EstimateUsage(stub.ctx, map[string]interface{}{})
+	assert.EqualError(t, err, "Azure Monitor API error: The client does not have authorization to perform action 'microsoft.insights/metrics/read'")
+}
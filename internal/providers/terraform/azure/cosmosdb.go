@@ -0,0 +1,73 @@
+package azure
+
+import (
+	"context"
+	"math"
+
+	"github.com/infracost/infracost/internal/schema"
+	"github.com/infracost/infracost/internal/usage/azure"
+	"github.com/tidwall/gjson"
+)
+
+var CosmosDBUsageSchema = []*schema.UsageItem{
+	{Key: "storage_gb", DefaultValue: 0, ValueType: schema.Int64},
+	{Key: "monthly_serverless_request_units", DefaultValue: 0, ValueType: schema.Int64},
+	{Key: "max_request_units_utilization_percentage", DefaultValue: 0, ValueType: schema.Float64},
+}
+
+// cosmosDBEstimate estimates the usage of a database or container from the
+// metrics of its account, which are split by the database and collection names.
+func cosmosDBEstimate(d *schema.ResourceData, account *schema.ResourceData) schema.EstimateFunc {
+	database, collection := cosmosDBMetricNames(d)
+
+	return func(ctx context.Context, values map[string]interface{}) error {
+		id, err := azure.ResourceID(ctx, account.Get("id").String(), account.Get("resource_group_name").String(), "Microsoft.DocumentDB/databaseAccounts", account.Get("name").String())
+		if err != nil {
+			return err
+		}
+
+		bytes, err := azure.CosmosDBGetStorageBytes(ctx, id, database, collection)
+		if err != nil {
+			return err
+		}
+		values["storage_gb"] = int64(math.Round(bytes / 1024 / 1024 / 1024))
+
+		switch {
+		case d.Get("throughput").Type != gjson.Null:
+			// Provisioned throughput is billed whether it's used or not
+		case d.Get("autoscale_settings.0.max_throughput").Type != gjson.Null:
+			utilization, err := azure.CosmosDBGetNormalizedRUConsumption(ctx, id, database, collection)
+			if err != nil {
+				return err
+			}
+			values["max_request_units_utilization_percentage"] = utilization
+		default:
+			units, err := azure.CosmosDBGetRequestUnits(ctx, id, database, collection)
+			if err != nil {
+				return err
+			}
+			values["monthly_serverless_request_units"] = int64(math.Round(units))
+		}
+		return nil
+	}
+}
+
+// cosmosDBMetricNames returns the database and collection names that Azure Monitor
+// uses for the resource. The collection is empty for databases.
+func cosmosDBMetricNames(d *schema.ResourceData) (string, string) {
+	switch d.Type {
+	case "azurerm_cosmosdb_sql_container", "azurerm_cosmosdb_gremlin_graph", "azurerm_cosmosdb_mongo_collection":
+		return d.Get("database_name").String(), d.Get("name").String()
+	case "azurerm_cosmosdb_cassandra_table":
+		keyspace := ""
+		if refs := d.References("cassandra_keyspace_id"); len(refs) > 0 {
+			keyspace = refs[0].Get("name").String()
+		}
+		return keyspace, d.Get("name").String()
+	case "azurerm_cosmosdb_table":
+		// Tables are collections in a database that the Table API creates
+		return "TablesDB", d.Get("name").String()
+	}
+
+	return d.Get("name").String(), ""
+}
@@ -34,6 +34,8 @@ func NewAzureRMCosmosdb(d *schema.ResourceData, u *schema.UsageData) *schema.Res
 		account := d.References("account_name")[0]
 		return &schema.Resource{
 			Name:           d.Address,
+			UsageSchema:    CosmosDBUsageSchema,
+			EstimateUsage:  cosmosDBEstimate(d, account),
 			CostComponents: cosmosDBCostComponents(d, u, account),
 		}
 	}
@@ -23,6 +23,8 @@ func NewAzureRMCosmosdbCassandraTable(d *schema.ResourceData, u *schema.UsageDat
 			account := keyspace.References("account_name")[0]
 			return &schema.Resource{
 				Name:           d.Address,
+				UsageSchema:    CosmosDBUsageSchema,
+				EstimateUsage:  cosmosDBEstimate(d, account),
 				CostComponents: cosmosDBCostComponents(d, u, account),
 			}
 		}
@@ -0,0 +1,105 @@
+package azure_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/infracost/infracost/internal/providers/terraform/azure"
+	"github.com/infracost/infracost/internal/schema"
+)
+
+func newCosmosDBAccount() *schema.ResourceData {
+	return newResourceData("azurerm_cosmosdb_account", "azurerm_cosmosdb_account.account", `{
+		"name": "my-account",
+		"resource_group_name": "my-rg",
+		"location": "eastus",
+		"geo_location": [{"location": "eastus", "failover_priority": 0}]
+	}`)
+}
+
+func TestAzureCosmosDBSQLContainerEstimateAutoscale(t *testing.T) {
+	stub := stubAzure(t)
+	defer stub.Close()
+
+	id := testResourceID("my-rg", "Microsoft.DocumentDB/databaseAccounts", "my-account")
+	filter := "DatabaseName eq 'my-db' and CollectionName eq 'my-container'"
+
+	stub.WhenMetric(id, "DataUsage,IndexUsage", filter).Then(200, `{
+		"value": [
+			{"name": {"value": "DataUsage"}, "timeseries": [{"data": [{"average": 85899345920}, {"average": 128849018880}]}]},
+			{"name": {"value": "IndexUsage"}, "timeseries": [{"data": [{"average": 10737418240}]}]}
+		]
+	}`)
+	stub.WhenMetric(id, "NormalizedRUConsumption", filter).Then(200, `{
+		"value": [{"timeseries": [{"data": [{"maximum": 20}, {"maximum": 40}, {"maximum": 90}]}]}]
+	}`)
+
+	d := newResourceData("azurerm_cosmosdb_sql_container", "azurerm_cosmosdb_sql_container.container", `{
+		"name": "my-container",
+		"database_name": "my-db",
+		"autoscale_settings": [{"max_throughput": 4000}]
+	}`)
+	d.AddReference("account_name", newCosmosDBAccount())
+
+	resource := azure.NewAzureRMCosmosdb(d, nil)
+	estimates := newEstimates(stub.ctx, t, resource, nil)
+	assert.Equal(t, int64(110), estimates.usage["storage_gb"])
+	assert.Equal(t, 50.0, estimates.usage["max_request_units_utilization_percentage"])
+	assert.Nil(t, estimates.usage["monthly_serverless_request_units"])
+}
+
+func TestAzureCosmosDBSQLDatabaseEstimateServerless(t *testing.T) {
+	stub := stubAzure(t)
+	defer stub.Close()
+
+	id := testResourceID("my-rg", "Microsoft.DocumentDB/databaseAccounts", "my-account")
+	filter := "DatabaseName eq 'my-db'"
+
+	stub.WhenMetric(id, "DataUsage,IndexUsage", filter).Then(200, `{
+		"value": [
+			{"name": {"value": "DataUsage"}, "timeseries": [{"data": [{"average": 1073741824}]}]},
+			{"name": {"value": "IndexUsage"}, "timeseries": []}
+		]
+	}`)
+	stub.WhenMetric(id, "TotalRequestUnits", filter).Then(200, `{
+		"value": [{"timeseries": [{"data": [{"total": 2500000.4}, {"total": 7500000}]}]}]
+	}`)
+
+	d := newResourceData("azurerm_cosmosdb_sql_database", "azurerm_cosmosdb_sql_database.db", `{
+		"name": "my-db"
+	}`)
+	d.AddReference("account_name", newCosmosDBAccount())
+
+	resource := azure.NewAzureRMCosmosdb(d, nil)
+	estimates := newEstimates(stub.ctx, t, resource, nil)
+	assert.Equal(t, int64(1), estimates.usage["storage_gb"])
+	assert.Equal(t, int64(10000000), estimates.usage["monthly_serverless_request_units"])
+	assert.Nil(t, estimates.usage["max_request_units_utilization_percentage"])
+}
+
+func TestAzureCosmosDBCassandraTableEstimate(t *testing.T) {
+	stub := stubAzure(t)
+	defer stub.Close()
+
+	id := testResourceID("my-rg", "Microsoft.DocumentDB/databaseAccounts", "my-account")
+
+	stub.WhenMetric(id, "DataUsage,IndexUsage", "DatabaseName eq 'my-keyspace' and CollectionName eq 'my-table'").Then(200, `{
+		"value": [{"name": {"value": "DataUsage"}, "timeseries": [{"data": [{"average": 5368709120}]}]}]
+	}`)
+
+	keyspace := newResourceData("azurerm_cosmosdb_cassandra_keyspace", "azurerm_cosmosdb_cassandra_keyspace.keyspace", `{
+		"name": "my-keyspace"
+	}`)
+	keyspace.AddReference("account_name", newCosmosDBAccount())
+
+	d := newResourceData("azurerm_cosmosdb_cassandra_table", "azurerm_cosmosdb_cassandra_table.table", `{
+		"name": "my-table",
+		"throughput": 400
+	}`)
+	d.AddReference("cassandra_keyspace_id", keyspace)
+
+	resource := azure.NewAzureRMCosmosdbCassandraTable(d, nil)
+	estimates := newEstimates(stub.ctx, t, resource, nil)
+	assert.Equal(t, int64(5), estimates.usage["storage_gb"])
+}
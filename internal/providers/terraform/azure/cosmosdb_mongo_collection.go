@@ -24,6 +24,8 @@ func NewAzureRMCosmosdbMongoCollection(d *schema.ResourceData, u *schema.UsageDa
 			account := mongoDB.References("account_name")[0]
 			return &schema.Resource{
 				Name:           d.Address,
+				UsageSchema:    CosmosDBUsageSchema,
+				EstimateUsage:  cosmosDBEstimate(d, account),
 				CostComponents: cosmosDBCostComponents(d, u, account),
 			}
 		}
@@ -0,0 +1,130 @@
+package azure_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/tidwall/gjson"
+
+	"github.com/infracost/infracost/internal/schema"
+	azureusage "github.com/infracost/infracost/internal/usage/azure"
+)
+
+type estimates struct {
+	t     *testing.T
+	usage map[string]interface{}
+}
+
+func newEstimates(ctx context.Context, t *testing.T, resource *schema.Resource, usage map[string]interface{}) estimates {
+	if usage == nil {
+		usage = make(map[string]interface{})
+	}
+	err := resource.EstimateUsage(ctx, usage)
+	if err != nil {
+		t.Fatalf("Expected %s EstimateUsage to succeed, got %s", resource.Name, err)
+	}
+
+	for _, item := range resource.UsageSchema {
+		value := usage[item.Key]
+		if value == nil {
+			continue
+		}
+		switch item.ValueType {
+		case schema.Int64:
+			if _, ok := value.(int64); !ok {
+				t.Errorf("Expected %s %s of type an int64, got a %T", resource.Name, item.Key, value)
+			}
+		case schema.Float64:
+			if _, ok := value.(float64); !ok {
+				t.Errorf("Expected %s %s of type float64, got a %T", resource.Name, item.Key, value)
+			}
+		default:
+			t.Errorf("Unexpected UsageItem.ValueType %v", item.ValueType)
+		}
+	}
+
+	return estimates{
+		t:     t,
+		usage: usage,
+	}
+}
+
+func newResourceData(resourceType string, address string, values string) *schema.ResourceData {
+	return schema.NewResourceData(resourceType, "registry.terraform.io/hashicorp/azurerm", address, map[string]string{}, gjson.Parse(values))
+}
+
+type stubbedRequest struct {
+	resourceID string
+	metric     string
+	filter     string
+	response   string
+	status     int
+}
+
+func (sr *stubbedRequest) Then(status int, response string) {
+	sr.status = status
+	sr.response = response
+}
+
+type stubbedAzure struct {
+	t        *testing.T
+	server   *httptest.Server
+	ctx      context.Context
+	requests []*stubbedRequest
+}
+
+func (sa *stubbedAzure) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Header.Get("Authorization") != "Bearer test-token" {
+		sa.t.Errorf("Expected stubbed Azure call to have the test token, got %q", r.Header.Get("Authorization"))
+	}
+
+	q := r.URL.Query()
+
+	for _, sr := range sa.requests {
+		if r.URL.Path == sr.resourceID+"/providers/Microsoft.Insights/metrics" &&
+			q.Get("metricnames") == sr.metric &&
+			q.Get("$filter") == sr.filter {
+			w.WriteHeader(sr.status)
+			_, err := w.Write([]byte(sr.response))
+			if err != nil {
+				sa.t.Fatalf("Cannot write stubbed HTTP response: %s", err)
+			}
+			return
+		}
+	}
+	sa.t.Fatalf("received unexpected stubbed Azure call: %s %s", r.Method, r.URL)
+}
+
+// WhenMetric stubs a request for the metrics of the resource, with the metric
+// names and dimension filter.
+func (sa *stubbedAzure) WhenMetric(resourceID string, metric string, filter string) *stubbedRequest {
+	sr := &stubbedRequest{
+		resourceID: resourceID,
+		metric:     metric,
+		filter:     filter,
+	}
+	sa.requests = append(sa.requests, sr)
+	return sr
+}
+
+func (sa *stubbedAzure) Close() {
+	sa.server.Close()
+}
+
+func stubAzure(t *testing.T) *stubbedAzure {
+	stub := &stubbedAzure{
+		t:        t,
+		requests: make([]*stubbedRequest, 0),
+	}
+	stub.server = httptest.NewServer(stub)
+	stub.ctx = azureusage.WithTestEndpoint(context.TODO(), stub.server.URL)
+	return stub
+}
+
+// testResourceID returns the ID of a resource in the test subscription.
+func testResourceID(resourceGroup string, resourceType string, name string) string {
+	return strings.Join([]string{"/subscriptions", azureusage.TestSubscriptionID, "resourceGroups", resourceGroup, "providers", resourceType, name}, "/")
+}
@@ -1,15 +1,24 @@
 package azure
 
 import (
+	"context"
 	"fmt"
+	"math"
 	"strings"
 
 	"github.com/infracost/infracost/internal/schema"
+	"github.com/infracost/infracost/internal/usage/azure"
 	"github.com/shopspring/decimal"
 	log "github.com/sirupsen/logrus"
 	"github.com/tidwall/gjson"
 )
 
+var FunctionAppUsageSchema = []*schema.UsageItem{
+	{Key: "monthly_executions", DefaultValue: 0, ValueType: schema.Int64},
+	{Key: "execution_duration_ms", DefaultValue: 0, ValueType: schema.Int64},
+	{Key: "memory_mb", DefaultValue: 0, ValueType: schema.Int64},
+}
+
 func GetAzureRMAppFunctionRegistryItem() *schema.RegistryItem {
 	return &schema.RegistryItem{
 		Name:  "azurerm_function_app",
@@ -89,6 +98,8 @@ func NewAzureRMAppFunction(d *schema.ResourceData, u *schema.UsageData) *schema.
 	if len(costComponents) > 1 {
 		return &schema.Resource{
 			Name:           d.Address,
+			UsageSchema:    FunctionAppUsageSchema,
+			EstimateUsage:  functionAppEstimate(d),
 			CostComponents: costComponents,
 		}
 	}
@@ -96,6 +107,41 @@ func NewAzureRMAppFunction(d *schema.ResourceData, u *schema.UsageData) *schema.
 	return nil
 }
 
+// functionAppEstimate estimates the consumption plan usage. Azure Monitor only has
+// the execution units, i.e. the memory multiplied by the execution time, so the
+// duration is calculated from the memory in the usage file, or the minimum of
+// 128 MB if it's not set.
+func functionAppEstimate(d *schema.ResourceData) schema.EstimateFunc {
+	return func(ctx context.Context, values map[string]interface{}) error {
+		id, err := azure.ResourceID(ctx, d.Get("id").String(), d.Get("resource_group_name").String(), "Microsoft.Web/sites", d.Get("name").String())
+		if err != nil {
+			return err
+		}
+
+		executions, err := azure.FunctionsGetExecutionCount(ctx, id)
+		if err != nil {
+			return err
+		}
+		values["monthly_executions"] = int64(math.Round(executions))
+
+		units, err := azure.FunctionsGetExecutionUnits(ctx, id)
+		if err != nil {
+			return err
+		}
+
+		memory := usageFloat(values["memory_mb"])
+		if memory <= 0 {
+			memory = 128
+			values["memory_mb"] = int64(memory)
+		}
+
+		if executions > 0 {
+			values["execution_duration_ms"] = int64(math.Round(units / executions / memory))
+		}
+		return nil
+	}
+}
+
 func AppFunctionPremiumCPUCostComponent(skuSize string, instances decimal.Decimal, skuCPU *int64, region string) *schema.CostComponent {
 	return &schema.CostComponent{
 		Name:           fmt.Sprintf("vCPU (%s)", strings.ToUpper(skuSize)),
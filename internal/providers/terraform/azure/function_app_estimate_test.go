@@ -0,0 +1,64 @@
+package azure_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/infracost/infracost/internal/providers/terraform/azure"
+	"github.com/infracost/infracost/internal/schema"
+)
+
+func newFunctionApp(t *testing.T) *schema.Resource {
+	d := newResourceData("azurerm_function_app", "azurerm_function_app.app", `{
+		"name": "my-app",
+		"resource_group_name": "my-rg",
+		"location": "eastus"
+	}`)
+	plan := newResourceData("azurerm_app_service_plan", "azurerm_app_service_plan.plan", `{
+		"kind": "FunctionApp",
+		"sku": [{"tier": "Dynamic", "size": "Y1"}]
+	}`)
+	d.AddReference("app_service_plan_id", plan)
+
+	resource := azure.NewAzureRMAppFunction(d, nil)
+	if resource == nil {
+		t.Fatal("Expected a resource for the function app")
+	}
+	return resource
+}
+
+func stubFunctionAppMetrics(stub *stubbedAzure) {
+	id := testResourceID("my-rg", "Microsoft.Web/sites", "my-app")
+
+	stub.WhenMetric(id, "FunctionExecutionCount", "").Then(200, `{
+		"value": [{"timeseries": [{"data": [{"total": 600000}, {"total": 400000}]}]}]
+	}`)
+	stub.WhenMetric(id, "FunctionExecutionUnits", "").Then(200, `{
+		"value": [{"timeseries": [{"data": [{"total": 51200000000}]}]}]
+	}`)
+}
+
+func TestAzureFunctionAppEstimate(t *testing.T) {
+	stub := stubAzure(t)
+	defer stub.Close()
+
+	stubFunctionAppMetrics(stub)
+
+	estimates := newEstimates(stub.ctx, t, newFunctionApp(t), nil)
+	assert.Equal(t, int64(1000000), estimates.usage["monthly_executions"])
+	assert.Equal(t, int64(128), estimates.usage["memory_mb"])
+	assert.Equal(t, int64(400), estimates.usage["execution_duration_ms"])
+}
+
+func TestAzureFunctionAppEstimateWithMemory(t *testing.T) {
+	stub := stubAzure(t)
+	defer stub.Close()
+
+	stubFunctionAppMetrics(stub)
+
+	estimates := newEstimates(stub.ctx, t, newFunctionApp(t), map[string]interface{}{"memory_mb": int64(512)})
+	assert.Equal(t, int64(1000000), estimates.usage["monthly_executions"])
+	assert.Equal(t, int64(512), estimates.usage["memory_mb"])
+	assert.Equal(t, int64(100), estimates.usage["execution_duration_ms"])
+}
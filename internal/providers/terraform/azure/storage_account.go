@@ -1,11 +1,14 @@
 package azure
 
 import (
+	"context"
 	"fmt"
+	"math"
 	"strings"
 
 	"github.com/infracost/infracost/internal/schema"
 	"github.com/infracost/infracost/internal/usage"
+	"github.com/infracost/infracost/internal/usage/azure"
 	"github.com/shopspring/decimal"
 	log "github.com/sirupsen/logrus"
 	"github.com/tidwall/gjson"
@@ -333,8 +336,48 @@ func NewAzureRMStorageAccount(d *schema.ResourceData, u *schema.UsageData) *sche
 			))
 		}
 	}
+	// Block blob accounts are billed for the blob service and file accounts for
+	// the file service
+	service := "blob"
+	capacityKey := "storage_gb"
+	if strings.ToLower(accountKind) == "filestorage" {
+		service = "file"
+		capacityKey = "data_at_rest_storage_gb"
+	}
+
+	estimate := func(ctx context.Context, values map[string]interface{}) error {
+		id, err := azure.ResourceID(ctx, d.Get("id").String(), d.Get("resource_group_name").String(), "Microsoft.Storage/storageAccounts", d.Get("name").String())
+		if err != nil {
+			return err
+		}
+
+		bytes, err := azure.StorageGetCapacityBytes(ctx, id, service)
+		if err != nil {
+			return err
+		}
+		values[capacityKey] = int64(math.Round(bytes / 1024 / 1024 / 1024))
+
+		t, err := azure.StorageGetTransactions(ctx, id, service)
+		if err != nil {
+			return err
+		}
+		values["monthly_write_operations"] = int64(math.Round(t.Write))
+		values["monthly_list_and_create_container_operations"] = int64(math.Round(t.ListAndCreateContainer))
+		values["monthly_read_operations"] = int64(math.Round(t.Read))
+		values["monthly_other_operations"] = int64(math.Round(t.Other))
+		return nil
+	}
+
 	return &schema.Resource{
-		Name:           d.Address,
+		Name: d.Address,
+		UsageSchema: []*schema.UsageItem{
+			{Key: capacityKey, DefaultValue: 0, ValueType: schema.Int64},
+			{Key: "monthly_write_operations", DefaultValue: 0, ValueType: schema.Int64},
+			{Key: "monthly_list_and_create_container_operations", DefaultValue: 0, ValueType: schema.Int64},
+			{Key: "monthly_read_operations", DefaultValue: 0, ValueType: schema.Int64},
+			{Key: "monthly_other_operations", DefaultValue: 0, ValueType: schema.Int64},
+		},
+		EstimateUsage:  estimate,
 		CostComponents: costComponents,
 	}
 }
@@ -0,0 +1,98 @@
+package azure_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/infracost/infracost/internal/providers/terraform/azure"
+)
+
+func TestAzureStorageAccountEstimate(t *testing.T) {
+	stub := stubAzure(t)
+	defer stub.Close()
+
+	id := testResourceID("my-rg", "Microsoft.Storage/storageAccounts", "myaccount")
+
+	stub.WhenMetric(id+"/blobServices/default", "BlobCapacity", "").Then(200, `{
+		"value": [{
+			"name": {"value": "BlobCapacity"},
+			"timeseries": [{
+				"data": [
+					{"timeStamp": "2021-01-01T00:00:00Z", "average": 107374182400},
+					{"timeStamp": "2021-01-02T00:00:00Z", "average": 214748364800},
+					{"timeStamp": "2021-01-03T00:00:00Z"}
+				]
+			}]
+		}]
+	}`)
+	stub.WhenMetric(id+"/blobServices/default", "Transactions", "ApiName eq '*'").Then(200, `{
+		"value": [{
+			"name": {"value": "Transactions"},
+			"timeseries": [
+				{"metadatavalues": [{"name": {"value": "apiname"}, "value": "PutBlob"}], "data": [{"total": 1000}, {"total": 500}]},
+				{"metadatavalues": [{"name": {"value": "apiname"}, "value": "PutBlockList"}], "data": [{"total": 200}]},
+				{"metadatavalues": [{"name": {"value": "apiname"}, "value": "ListBlobs"}], "data": [{"total": 300}]},
+				{"metadatavalues": [{"name": {"value": "apiname"}, "value": "CreateContainer"}], "data": [{"total": 4}]},
+				{"metadatavalues": [{"name": {"value": "apiname"}, "value": "GetBlob"}], "data": [{"total": 9000}]},
+				{"metadatavalues": [{"name": {"value": "apiname"}, "value": "GetBlobProperties"}], "data": [{"total": 70}]},
+				{"metadatavalues": [{"name": {"value": "apiname"}, "value": "DeleteBlob"}], "data": [{"total": 80}]}
+			]
+		}]
+	}`)
+
+	d := newResourceData("azurerm_storage_account", "azurerm_storage_account.account", `{
+		"name": "myaccount",
+		"resource_group_name": "my-rg",
+		"location": "eastus",
+		"account_kind": "BlockBlobStorage",
+		"account_tier": "Premium",
+		"account_replication_type": "LRS"
+	}`)
+	resource := azure.NewAzureRMStorageAccount(d, nil)
+	estimates := newEstimates(stub.ctx, t, resource, nil)
+	assert.Equal(t, int64(150), estimates.usage["storage_gb"])
+	assert.Equal(t, int64(1700), estimates.usage["monthly_write_operations"])
+	assert.Equal(t, int64(304), estimates.usage["monthly_list_and_create_container_operations"])
+	assert.Equal(t, int64(9000), estimates.usage["monthly_read_operations"])
+	assert.Equal(t, int64(70), estimates.usage["monthly_other_operations"])
+}
+
+func TestAzureStorageAccountEstimateFileStorage(t *testing.T) {
+	stub := stubAzure(t)
+	defer stub.Close()
+
+	id := "/subscriptions/11111111-1111-1111-1111-111111111111/resourceGroups/my-rg/providers/Microsoft.Storage/storageAccounts/myfiles"
+
+	stub.WhenMetric(id+"/fileServices/default", "FileCapacity", "").Then(200, `{
+		"value": [{"timeseries": [{"data": [{"average": 53687091200}]}]}]
+	}`)
+	stub.WhenMetric(id+"/fileServices/default", "Transactions", "ApiName eq '*'").Then(200, `{
+		"value": [{
+			"timeseries": [
+				{"metadatavalues": [{"name": {"value": "apiname"}, "value": "CreateFile"}], "data": [{"total": 10}]},
+				{"metadatavalues": [{"name": {"value": "apiname"}, "value": "PutRange"}], "data": [{"total": 20}]},
+				{"metadatavalues": [{"name": {"value": "apiname"}, "value": "ListFilesAndDirectories"}], "data": [{"total": 30}]},
+				{"metadatavalues": [{"name": {"value": "apiname"}, "value": "GetFile"}], "data": [{"total": 40}]}
+			]
+		}]
+	}`)
+
+	d := newResourceData("azurerm_storage_account", "azurerm_storage_account.files", `{
+		"id": "`+id+`",
+		"name": "myfiles",
+		"resource_group_name": "my-rg",
+		"location": "eastus",
+		"account_kind": "FileStorage",
+		"account_tier": "Premium",
+		"account_replication_type": "LRS"
+	}`)
+	resource := azure.NewAzureRMStorageAccount(d, nil)
+	estimates := newEstimates(stub.ctx, t, resource, nil)
+	assert.Equal(t, int64(50), estimates.usage["data_at_rest_storage_gb"])
+	assert.Nil(t, estimates.usage["storage_gb"])
+	assert.Equal(t, int64(30), estimates.usage["monthly_write_operations"])
+	assert.Equal(t, int64(30), estimates.usage["monthly_list_and_create_container_operations"])
+	assert.Equal(t, int64(40), estimates.usage["monthly_read_operations"])
+	assert.Equal(t, int64(0), estimates.usage["monthly_other_operations"])
+}
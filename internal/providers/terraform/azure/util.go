@@ -101,3 +101,18 @@ func locationNameMapping(l string) string {
 
 	return name
 }
+
+// usageFloat returns the numeric value of a usage item passed to an estimate
+// func, or 0 if it's not a number.
+func usageFloat(v interface{}) float64 {
+	switch n := v.(type) {
+	case int:
+		return float64(n)
+	case int64:
+		return float64(n)
+	case float64:
+		return n
+	}
+
+	return 0
+}
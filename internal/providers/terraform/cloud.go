@@ -9,6 +9,7 @@ import (
 	"path"
 	"path/filepath"
 	"runtime"
+	"strings"
 
 	"github.com/hashicorp/hcl2/gohcl"
 	"github.com/hashicorp/hcl2/hclparse"
@@ -29,13 +30,17 @@ type terraformConfig struct {
 func cloudAPI(host string, path string, token string) ([]byte, error) {
 	client := &http.Client{}
 
-	url := fmt.Sprintf("https://%s%s", host, path)
+	url := cloudURL(host, path)
 	log.Debugf("Calling Terraform Cloud API: %s", url)
 	req, err := http.NewRequest("GET", url, nil)
 	if err != nil {
 		return []byte{}, err
 	}
-	req.Header.Add("Authorization", fmt.Sprintf("Bearer %s", token))
+
+	// Download links can be for another host, which shouldn't get the token
+	if strings.HasPrefix(url, cloudURL(host, "/")) {
+		req.Header.Add("Authorization", fmt.Sprintf("Bearer %s", token))
+	}
 
 	resp, err := client.Do(req)
 	if err != nil {
@@ -52,6 +57,44 @@ func cloudAPI(host string, path string, token string) ([]byte, error) {
 	return io.ReadAll(resp.Body)
 }
 
+// cloudURL returns the URL of the API path. The host can include the scheme,
+// e.g. for Terraform Enterprise instances that don't use HTTPS, and the path can
+// be a URL, e.g. for links to download plans and state.
+func cloudURL(host string, path string) string {
+	if strings.HasPrefix(path, "https://") || strings.HasPrefix(path, "http://") {
+		return path
+	}
+
+	if strings.HasPrefix(host, "https://") || strings.HasPrefix(host, "http://") {
+		return strings.TrimSuffix(host, "/") + path
+	}
+
+	return fmt.Sprintf("https://%s%s", host, path)
+}
+
+// cloudPlanJSON returns the plan JSON of a Terraform Cloud run.
+func cloudPlanJSON(host string, runID string, token string) ([]byte, error) {
+	body, err := cloudAPI(host, fmt.Sprintf("/api/v2/runs/%s/plan", runID), token)
+	if err != nil {
+		return []byte{}, err
+	}
+
+	var parsedResp struct {
+		Data struct {
+			Links map[string]string
+		}
+	}
+	if err := json.Unmarshal(body, &parsedResp); err != nil {
+		return []byte{}, err
+	}
+
+	jsonPath, ok := parsedResp.Data.Links["json-output"]
+	if !ok || jsonPath == "" {
+		return []byte{}, errors.New("Could not parse path to plan JSON from remote")
+	}
+	return cloudAPI(host, jsonPath, token)
+}
+
 func findCloudToken(host string) string {
 	if os.Getenv("TF_CLI_CONFIG_FILE") != "" {
 		log.Debugf("TF_CLI_CONFIG_FILE is set, checking %s for Terraform Cloud credentials", os.Getenv("TF_CLI_CONFIG_FILE"))
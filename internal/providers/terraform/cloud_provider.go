@@ -0,0 +1,281 @@
+package terraform
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/infracost/infracost/internal/config"
+	"github.com/infracost/infracost/internal/schema"
+)
+
+const (
+	cloudPathPrefix  = "tfc://"
+	defaultCloudHost = "app.terraform.io"
+)
+
+// plannedRunStatuses are the statuses of runs that have finished planning.
+var plannedRunStatuses = map[string]bool{
+	"planned":              true,
+	"planned_and_finished": true,
+	"post_plan_running":    true,
+	"post_plan_completed":  true,
+	"cost_estimating":      true,
+	"cost_estimated":       true,
+	"policy_checking":      true,
+	"policy_override":      true,
+	"policy_soft_failed":   true,
+	"policy_checked":       true,
+	"confirmed":            true,
+	"apply_queued":         true,
+	"applying":             true,
+	"applied":              true,
+}
+
+// CloudProvider loads the plan or state of a Terraform Cloud workspace or run
+// from the API, so workspaces can be costed without their code.
+type CloudProvider struct {
+	ctx                 *config.ProjectContext
+	Path                string
+	UseState            bool
+	TerraformCloudHost  string
+	TerraformCloudToken string
+	cachedJSON          []byte
+	cachedMetadata      schema.ProjectMetadata
+}
+
+// cloudWorkspace is a workspace in the Terraform Cloud API.
+type cloudWorkspace struct {
+	ID         string `json:"id"`
+	Attributes struct {
+		Name             string `json:"name"`
+		WorkingDirectory string `json:"working-directory"`
+		VCSRepo          *struct {
+			RepositoryHTTPURL string `json:"repository-http-url"`
+		} `json:"vcs-repo"`
+	} `json:"attributes"`
+}
+
+// IsCloudPath returns true if the path is a Terraform Cloud workspace or run,
+// e.g. tfc://org/workspace or tfc://run-abc123.
+func IsCloudPath(path string) bool {
+	return strings.HasPrefix(path, cloudPathPrefix)
+}
+
+func NewCloudProvider(ctx *config.ProjectContext) schema.Provider {
+	host := ctx.ProjectConfig.TerraformCloudHost
+	if host == "" {
+		host = defaultCloudHost
+	}
+
+	return &CloudProvider{
+		ctx:                 ctx,
+		Path:                ctx.ProjectConfig.Path,
+		UseState:            ctx.ProjectConfig.TerraformUseState,
+		TerraformCloudHost:  host,
+		TerraformCloudToken: ctx.ProjectConfig.TerraformCloudToken,
+	}
+}
+
+func (p *CloudProvider) Type() string {
+	return "terraform_cloud"
+}
+
+func (p *CloudProvider) DisplayType() string {
+	if _, _, runID, err := parseCloudPath(p.Path); err == nil && runID != "" {
+		return "Terraform Cloud run"
+	}
+
+	return "Terraform Cloud workspace"
+}
+
+func (p *CloudProvider) AddMetadata(metadata *schema.ProjectMetadata) {
+	// no op
+}
+
+// loadJSON returns the plan or state JSON of the workspace or run, and the
+// metadata of the workspace. These are cached so the resources can be loaded
+// again with different usage data without calling the API again.
+func (p *CloudProvider) loadJSON() ([]byte, schema.ProjectMetadata, error) {
+	if p.cachedJSON != nil {
+		return p.cachedJSON, p.cachedMetadata, nil
+	}
+
+	org, workspace, runID, err := parseCloudPath(p.Path)
+	if err != nil {
+		return []byte{}, schema.ProjectMetadata{}, err
+	}
+
+	token := p.TerraformCloudToken
+	if token == "" {
+		token = findCloudToken(p.TerraformCloudHost)
+	}
+	if token == "" {
+		return []byte{}, schema.ProjectMetadata{}, ErrMissingCloudToken
+	}
+
+	metadata := schema.ProjectMetadata{
+		Path: p.Path,
+	}
+
+	var j []byte
+
+	if runID != "" {
+		if p.UseState {
+			return []byte{}, schema.ProjectMetadata{}, errors.New("Cannot use the Terraform state with a Terraform Cloud run, use the path of its workspace instead")
+		}
+
+		j, err = cloudPlanJSON(p.TerraformCloudHost, runID, token)
+		if err != nil {
+			return []byte{}, schema.ProjectMetadata{}, errors.Wrapf(err, "Error getting plan JSON of Terraform Cloud run %s", runID)
+		}
+	} else {
+		ws, err := p.workspace(org, workspace, token)
+		if err != nil {
+			return []byte{}, schema.ProjectMetadata{}, errors.Wrapf(err, "Error getting Terraform Cloud workspace %s/%s", org, workspace)
+		}
+
+		metadata.TerraformWorkspace = ws.Attributes.Name
+		if ws.Attributes.VCSRepo != nil {
+			metadata.VCSRepoURL = ws.Attributes.VCSRepo.RepositoryHTTPURL
+			metadata.VCSSubPath = ws.Attributes.WorkingDirectory
+		}
+
+		if p.UseState {
+			j, err = p.stateJSON(ws.ID, token)
+			if err != nil {
+				return []byte{}, schema.ProjectMetadata{}, errors.Wrapf(err, "Error getting state JSON of Terraform Cloud workspace %s/%s", org, workspace)
+			}
+		} else {
+			runID, err = p.latestPlannedRun(ws.ID, token)
+			if err != nil {
+				return []byte{}, schema.ProjectMetadata{}, errors.Wrapf(err, "Error getting runs of Terraform Cloud workspace %s/%s", org, workspace)
+			}
+
+			j, err = cloudPlanJSON(p.TerraformCloudHost, runID, token)
+			if err != nil {
+				return []byte{}, schema.ProjectMetadata{}, errors.Wrapf(err, "Error getting plan JSON of Terraform Cloud run %s", runID)
+			}
+		}
+	}
+
+	p.cachedJSON = j
+	p.cachedMetadata = metadata
+
+	return j, metadata, nil
+}
+
+func (p *CloudProvider) LoadResources(usage map[string]*schema.UsageData) ([]*schema.Project, error) {
+	j, metadata, err := p.loadJSON()
+	if err != nil {
+		return []*schema.Project{}, err
+	}
+
+	metadata.Type = p.Type()
+	p.AddMetadata(&metadata)
+	name := schema.GenerateProjectName(&metadata, p.ctx.RunContext.Config.EnableDashboard)
+
+	project := schema.NewProject(name, &metadata)
+	parser := NewParser(p.ctx)
+
+	pastResources, resources, err := parser.parseJSON(j, usage)
+	if err != nil {
+		return []*schema.Project{project}, errors.Wrap(err, "Error parsing Terraform Cloud JSON")
+	}
+
+	project.HasDiff = !p.UseState
+	project.PastResources = pastResources
+	project.Resources = resources
+
+	return []*schema.Project{project}, nil
+}
+
+// parseCloudPath returns the organization and workspace, or the run ID, of a
+// Terraform Cloud path.
+func parseCloudPath(path string) (string, string, string, error) {
+	parts := strings.Split(strings.Trim(strings.TrimPrefix(path, cloudPathPrefix), "/"), "/")
+
+	if len(parts) == 1 && strings.HasPrefix(parts[0], "run-") {
+		return "", "", parts[0], nil
+	}
+
+	if len(parts) == 2 && parts[0] != "" && parts[1] != "" {
+		return parts[0], parts[1], "", nil
+	}
+
+	return "", "", "", fmt.Errorf("Invalid Terraform Cloud path %s, expected %sorg/workspace or %srun-id", path, cloudPathPrefix, cloudPathPrefix)
+}
+
+func (p *CloudProvider) workspace(org string, name string, token string) (*cloudWorkspace, error) {
+	body, err := cloudAPI(p.TerraformCloudHost, fmt.Sprintf("/api/v2/organizations/%s/workspaces/%s", url.PathEscape(org), url.PathEscape(name)), token)
+	if err != nil {
+		return nil, err
+	}
+
+	var resp struct {
+		Data cloudWorkspace `json:"data"`
+	}
+	if err := json.Unmarshal(body, &resp); err != nil {
+		return nil, err
+	}
+
+	return &resp.Data, nil
+}
+
+// latestPlannedRun returns the ID of the latest run of the workspace that has
+// finished planning. Runs are listed with the latest first.
+func (p *CloudProvider) latestPlannedRun(workspaceID string, token string) (string, error) {
+	body, err := cloudAPI(p.TerraformCloudHost, fmt.Sprintf("/api/v2/workspaces/%s/runs?page%%5Bsize%%5D=100", workspaceID), token)
+	if err != nil {
+		return "", err
+	}
+
+	var resp struct {
+		Data []struct {
+			ID         string `json:"id"`
+			Attributes struct {
+				Status string `json:"status"`
+			} `json:"attributes"`
+		} `json:"data"`
+	}
+	if err := json.Unmarshal(body, &resp); err != nil {
+		return "", err
+	}
+
+	for _, r := range resp.Data {
+		if plannedRunStatuses[r.Attributes.Status] {
+			return r.ID, nil
+		}
+	}
+
+	return "", errors.New("The workspace has no runs with a plan")
+}
+
+// stateJSON returns the current state of the workspace in the format of
+// terraform show -json.
+func (p *CloudProvider) stateJSON(workspaceID string, token string) ([]byte, error) {
+	body, err := cloudAPI(p.TerraformCloudHost, fmt.Sprintf("/api/v2/workspaces/%s/current-state-version", workspaceID), token)
+	if err != nil {
+		return []byte{}, err
+	}
+
+	var resp struct {
+		Data struct {
+			Attributes struct {
+				JSONDownloadURL string `json:"hosted-json-state-download-url"`
+			} `json:"attributes"`
+		} `json:"data"`
+	}
+	if err := json.Unmarshal(body, &resp); err != nil {
+		return []byte{}, err
+	}
+
+	if resp.Data.Attributes.JSONDownloadURL == "" {
+		return []byte{}, errors.New("The current state version has no JSON state, which needs Terraform 1.3 or later")
+	}
+
+	return cloudAPI(p.TerraformCloudHost, resp.Data.Attributes.JSONDownloadURL, token)
+}
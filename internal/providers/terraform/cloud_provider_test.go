@@ -0,0 +1,190 @@
+package terraform
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/infracost/infracost/internal/config"
+	"github.com/infracost/infracost/internal/schema"
+)
+
+const cloudTestToken = "test-token"
+
+const cloudTestPlanJSON = `{
+	"format_version": "0.1",
+	"planned_values": {
+		"root_module": {
+			"resources": [
+				{
+					"address": "aws_nat_gateway.nat",
+					"mode": "managed",
+					"type": "aws_nat_gateway",
+					"name": "nat",
+					"provider_name": "registry.terraform.io/hashicorp/aws",
+					"values": {}
+				}
+			]
+		}
+	},
+	"configuration": {
+		"provider_config": {
+			"aws": {
+				"name": "aws",
+				"expressions": {"region": {"constant_value": "eu-west-1"}}
+			}
+		}
+	}
+}`
+
+const cloudTestStateJSON = `{
+	"format_version": "0.1",
+	"values": {
+		"root_module": {
+			"resources": [
+				{
+					"address": "aws_eip.nat",
+					"mode": "managed",
+					"type": "aws_eip",
+					"name": "nat",
+					"provider_name": "registry.terraform.io/hashicorp/aws",
+					"values": {"vpc": true}
+				}
+			]
+		}
+	}
+}`
+
+// newCloudTestServer returns a stub of the Terraform Cloud API with the acme/prod
+// workspace. The state is downloaded from a separate server, like the hosted
+// download links, which must not get the token.
+func newCloudTestServer(t *testing.T) *httptest.Server {
+	download := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Empty(t, r.Header.Get("Authorization"))
+		fmt.Fprint(w, cloudTestStateJSON)
+	}))
+	t.Cleanup(download.Close)
+
+	responses := map[string]string{
+		"/api/v2/organizations/acme/workspaces/prod": `{"data": {"id": "ws-1", "attributes": {
+			"name": "prod",
+			"working-directory": "infra",
+			"vcs-repo": {"repository-http-url": "https://github.com/acme/platform"}
+		}}}`,
+		"/api/v2/workspaces/ws-1/runs": `{"data": [
+			{"id": "run-3", "attributes": {"status": "planning"}},
+			{"id": "run-2", "attributes": {"status": "errored"}},
+			{"id": "run-1", "attributes": {"status": "planned_and_finished"}}
+		]}`,
+		"/api/v2/runs/run-1/plan":          `{"data": {"links": {"json-output": "/api/v2/plans/plan-1/json-output"}}}`,
+		"/api/v2/plans/plan-1/json-output": cloudTestPlanJSON,
+		"/api/v2/workspaces/ws-1/current-state-version": fmt.Sprintf(`{"data": {"attributes": {
+			"hosted-json-state-download-url": "%s/state.json"
+		}}}`, download.URL),
+	}
+
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer "+cloudTestToken {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+
+		body, ok := responses[r.URL.Path]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		fmt.Fprint(w, body)
+	}))
+	t.Cleanup(s.Close)
+
+	return s
+}
+
+func newCloudTestProvider(host string, path string, useState bool) schema.Provider {
+	ctx := config.EmptyProjectContext()
+	ctx.ProjectConfig.Path = path
+	ctx.ProjectConfig.TerraformCloudHost = host
+	ctx.ProjectConfig.TerraformCloudToken = cloudTestToken
+	ctx.ProjectConfig.TerraformUseState = useState
+
+	return NewCloudProvider(ctx)
+}
+
+func cloudResourceNames(project *schema.Project) []string {
+	names := make([]string, 0, len(project.Resources))
+	for _, r := range project.Resources {
+		names = append(names, r.Name)
+	}
+	return names
+}
+
+func TestCloudProviderWorkspacePlan(t *testing.T) {
+	s := newCloudTestServer(t)
+
+	p := newCloudTestProvider(s.URL, "tfc://acme/prod", false)
+	assert.Equal(t, "Terraform Cloud workspace", p.DisplayType())
+
+	projects, err := p.LoadResources(map[string]*schema.UsageData{})
+	require.NoError(t, err)
+	require.Len(t, projects, 1)
+
+	project := projects[0]
+	assert.Equal(t, "acme/platform/infra (prod)", project.Name)
+	assert.Equal(t, "terraform_cloud", project.Metadata.Type)
+	assert.True(t, project.HasDiff)
+	assert.Equal(t, []string{"aws_nat_gateway.nat"}, cloudResourceNames(project))
+}
+
+func TestCloudProviderWorkspaceState(t *testing.T) {
+	s := newCloudTestServer(t)
+
+	projects, err := newCloudTestProvider(s.URL, "tfc://acme/prod", true).LoadResources(map[string]*schema.UsageData{})
+	require.NoError(t, err)
+	require.Len(t, projects, 1)
+
+	assert.False(t, projects[0].HasDiff)
+	assert.Equal(t, []string{"aws_eip.nat"}, cloudResourceNames(projects[0]))
+}
+
+func TestCloudProviderRun(t *testing.T) {
+	s := newCloudTestServer(t)
+
+	p := newCloudTestProvider(s.URL, "tfc://run-1", false)
+	assert.Equal(t, "Terraform Cloud run", p.DisplayType())
+
+	projects, err := p.LoadResources(map[string]*schema.UsageData{})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"aws_nat_gateway.nat"}, cloudResourceNames(projects[0]))
+
+	_, err = newCloudTestProvider(s.URL, "tfc://run-1", true).LoadResources(map[string]*schema.UsageData{})
+	assert.EqualError(t, err, "Cannot use the Terraform state with a Terraform Cloud run, use the path of its workspace instead")
+}
+
+func TestCloudProviderErrors(t *testing.T) {
+	s := newCloudTestServer(t)
+
+	_, err := newCloudTestProvider(s.URL, "tfc://acme/missing", false).LoadResources(map[string]*schema.UsageData{})
+	assert.EqualError(t, err, "Error getting Terraform Cloud workspace acme/missing: invalid response from Terraform remote: 404 Not Found")
+
+	_, err = newCloudTestProvider(s.URL, "tfc://acme", false).LoadResources(map[string]*schema.UsageData{})
+	assert.EqualError(t, err, "Invalid Terraform Cloud path tfc://acme, expected tfc://org/workspace or tfc://run-id")
+
+	ctx := config.EmptyProjectContext()
+	ctx.ProjectConfig.Path = "tfc://acme/prod"
+	ctx.ProjectConfig.TerraformCloudHost = s.URL
+	t.Setenv("TF_CLI_CONFIG_FILE", "")
+	t.Setenv("HOME", t.TempDir())
+
+	_, err = NewCloudProvider(ctx).LoadResources(map[string]*schema.UsageData{})
+	assert.Equal(t, ErrMissingCloudToken, err)
+}
+
+func TestIsCloudPath(t *testing.T) {
+	assert.True(t, IsCloudPath("tfc://acme/prod"))
+	assert.False(t, IsCloudPath("acme/prod"))
+}
@@ -2,7 +2,6 @@ package terraform
 
 import (
 	"bytes"
-	"encoding/json"
 	"fmt"
 	"net/url"
 	"os"
@@ -58,6 +57,7 @@ func NewDirProvider(ctx *config.ProjectContext) schema.Provider {
 			EnableLogging: ctx.RunContext.Config.IsLogging(),
 			NoColor:       ctx.RunContext.Config.NoColor,
 			Indent:        "  ",
+			Prefix:        ctx.LogPrefix,
 		},
 		PlanFlags:           ctx.ProjectConfig.TerraformPlanFlags,
 		Workspace:           ctx.ProjectConfig.TerraformWorkspace,
@@ -283,7 +283,7 @@ func (p *DirProvider) runPlan(opts *CmdOptions, spinner *ui.Spinner, initOnFail
 
 	args := []string{}
 	if p.IsTerragrunt {
-		args = append(args, "run-all", "--terragrunt-ignore-external-dependencies")
+		args = append(args, p.terragruntRunAllArgs()...)
 	}
 
 	args = append(args, "plan", "-input=false", "-lock=false", "-no-color")
@@ -296,7 +296,7 @@ func (p *DirProvider) runPlan(opts *CmdOptions, spinner *ui.Spinner, initOnFail
 
 		// If the plan returns this error then Terraform is configured with remote execution mode
 		if strings.HasPrefix(extractedErr, "Error: Saving a generated plan is currently not supported") {
-			log.Info("Continuing with Terraform Remote Execution Mode")
+			log.Infof("%sContinuing with Terraform Remote Execution Mode", p.spinnerOpts.Prefix)
 			p.ctx.SetContextValue("terraformRemoteExecutionModeEnabled", true)
 			planJSON, err = p.runRemotePlan(opts, args)
 		} else if initOnFail && (strings.Contains(extractedErr, "Error: Could not load plugin") ||
@@ -317,19 +317,19 @@ func (p *DirProvider) runPlan(opts *CmdOptions, spinner *ui.Spinner, initOnFail
 		spinner.Fail()
 
 		if errors.Is(err, ErrMissingCloudToken) {
-			msg := "Please set your TERRAFORM_CLOUD_TOKEN environment variable.\n"
+			msg := p.spinnerOpts.Prefix + "Please set your TERRAFORM_CLOUD_TOKEN environment variable.\n"
 			msg += "It seems like Terraform Cloud's Remote Execution Mode is being used.\n"
 			msg += "Create a Team or User API Token in the Terraform Cloud dashboard and set this environment variable."
 			fmt.Fprintln(os.Stderr, msg)
 		} else if errors.Is(err, ErrInvalidCloudToken) {
-			msg := "Please set your TERRAFORM_CLOUD_TOKEN environment variable.\n"
+			msg := p.spinnerOpts.Prefix + "Please set your TERRAFORM_CLOUD_TOKEN environment variable.\n"
 			msg += "It seems like Terraform Cloud's Remote Execution Mode is being used.\n"
 			msg += "Create a Team or User API Token in the Terraform Cloud dashboard and set this environment variable."
 			fmt.Fprintln(os.Stderr, msg)
 		} else {
 			p.printTerraformErr(err)
 		}
-		return "", planJSON, errors.Wrap(err, "Error running terraform plan")
+		return fileName, planJSON, errors.Wrap(err, "Error running terraform plan")
 	}
 
 	spinner.Success()
@@ -340,7 +340,7 @@ func (p *DirProvider) runPlan(opts *CmdOptions, spinner *ui.Spinner, initOnFail
 func (p *DirProvider) runInit(opts *CmdOptions, spinner *ui.Spinner) error {
 	args := []string{}
 	if p.IsTerragrunt {
-		args = append(args, "run-all", "--terragrunt-ignore-external-dependencies")
+		args = append(args, p.terragruntRunAllArgs()...)
 	}
 	args = append(args, "init", "-input=false", "-no-color")
 
@@ -387,25 +387,7 @@ func (p *DirProvider) runRemotePlan(opts *CmdOptions, args []string) ([]byte, er
 		return []byte{}, ErrMissingCloudToken
 	}
 
-	body, err := cloudAPI(host, fmt.Sprintf("/api/v2/runs/%s/plan", runID), token)
-	if err != nil {
-		return []byte{}, err
-	}
-
-	var parsedResp struct {
-		Data struct {
-			Links map[string]string
-		}
-	}
-	if json.Unmarshal(body, &parsedResp) != nil {
-		return []byte{}, err
-	}
-
-	jsonPath, ok := parsedResp.Data.Links["json-output"]
-	if !ok || jsonPath == "" {
-		return []byte{}, errors.New("Could not parse path to plan JSON from remote")
-	}
-	return cloudAPI(host, jsonPath, token)
+	return cloudPlanJSON(host, runID, token)
 }
 
 func (p *DirProvider) runShow(opts *CmdOptions, spinner *ui.Spinner, planFile string) ([]byte, error) {
@@ -467,7 +449,7 @@ func (p *DirProvider) printTerraformErr(err error) {
 		binName = "Terragrunt"
 	}
 
-	msg := fmt.Sprintf("\n  %s command failed with:\n%s\n", binName, ui.Indent(stderr, "    "))
+	msg := fmt.Sprintf("\n  %s%s command failed with:\n%s\n", p.spinnerOpts.Prefix, binName, ui.Indent(stderr, "    "))
 
 	if strings.HasPrefix(stderr, "Error: Failed to select workspace") {
 		msg += "\nRun `terraform workspace select your_workspace` first or set the TF_WORKSPACE environment variable.\n"
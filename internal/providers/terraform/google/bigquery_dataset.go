@@ -15,6 +15,9 @@ func GetBigqueryDatasetRegistryItem() *schema.RegistryItem {
 	}
 }
 
+// NewBigqueryDataset doesn't estimate the usage from Cloud Monitoring since the
+// bytes billed for queries are only reported for the whole project, not for each
+// dataset.
 func NewBigqueryDataset(d *schema.ResourceData, u *schema.UsageData) *schema.Resource {
 	region := d.Get("region").String()
 
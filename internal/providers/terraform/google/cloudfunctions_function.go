@@ -1,10 +1,20 @@
 package google
 
 import (
+	"context"
+	"math"
+
 	"github.com/infracost/infracost/internal/schema"
+	"github.com/infracost/infracost/internal/usage/google"
 	"github.com/shopspring/decimal"
 )
 
+var CloudFunctionsUsageSchema = []*schema.UsageItem{
+	{Key: "request_duration_ms", DefaultValue: 0, ValueType: schema.Int64},
+	{Key: "monthly_function_invocations", DefaultValue: 0, ValueType: schema.Int64},
+	{Key: "monthly_outbound_data_gb", DefaultValue: 0, ValueType: schema.Int64},
+}
+
 func GetCloudFunctionsRegistryItem() *schema.RegistryItem {
 	return &schema.RegistryItem{
 		Name:  "google_cloudfunctions_function",
@@ -49,8 +59,34 @@ func NewCloudFunctions(d *schema.ResourceData, u *schema.UsageData) *schema.Reso
 		networkEgrees = decimalPtr(decimal.NewFromInt(u.Get("monthly_outbound_data_gb").Int()))
 	}
 
+	project := d.Get("project").String()
+	name := d.Get("name").String()
+
+	estimate := func(ctx context.Context, values map[string]interface{}) error {
+		inv, err := google.CloudFunctionsGetExecutions(ctx, project, region, name)
+		if err != nil {
+			return err
+		}
+		values["monthly_function_invocations"] = int64(math.Round(inv))
+
+		dur, err := google.CloudFunctionsGetExecutionTimeAvg(ctx, project, region, name)
+		if err != nil {
+			return err
+		}
+		values["request_duration_ms"] = int64(math.Round(dur))
+
+		egress, err := google.CloudFunctionsGetNetworkEgressBytes(ctx, project, region, name)
+		if err != nil {
+			return err
+		}
+		values["monthly_outbound_data_gb"] = int64(math.Round(egress / 1024 / 1024 / 1024))
+		return nil
+	}
+
 	return &schema.Resource{
-		Name: d.Address,
+		Name:          d.Address,
+		UsageSchema:   CloudFunctionsUsageSchema,
+		EstimateUsage: estimate,
 		CostComponents: []*schema.CostComponent{
 			{
 				Name:            "CPU",
@@ -0,0 +1,49 @@
+package google_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/infracost/infracost/internal/providers/terraform/google"
+)
+
+func TestCloudFunctionsEstimate(t *testing.T) {
+	stub := stubGoogle(t)
+	defer stub.Close()
+
+	labels := []string{`resource.labels.function_name = "my-function"`, `resource.labels.region = "us-central1"`}
+
+	stub.WhenFilter("my-project", append(labels, `"cloudfunctions.googleapis.com/function/execution_count"`)...).Then(200, `{
+		"timeSeries": [
+			{"points": [{"value": {"int64Value": "1000000"}}]}
+		],
+		"nextPageToken": "page-2"
+	}`)
+	stub.WhenFilter("my-project", append(labels, `"cloudfunctions.googleapis.com/function/execution_count"`)...).Page("page-2").Then(200, `{
+		"timeSeries": [
+			{"points": [{"value": {"int64Value": "234"}}]}
+		]
+	}`)
+	stub.WhenFilter("my-project", append(labels, `"cloudfunctions.googleapis.com/function/execution_times"`)...).Then(200, `{
+		"timeSeries": [{
+			"points": [{"value": {"distributionValue": {"count": "1000234", "mean": 312600000}}}]
+		}]
+	}`)
+	stub.WhenFilter("my-project", append(labels, `"cloudfunctions.googleapis.com/function/network_egress"`)...).Then(200, `{
+		"timeSeries": [{
+			"points": [{"value": {"int64Value": "26843545600"}}]
+		}]
+	}`)
+
+	d := newResourceData("google_cloudfunctions_function", "google_cloudfunctions_function.function", `{
+		"project": "my-project",
+		"region": "us-central1",
+		"name": "my-function"
+	}`)
+	resource := google.NewCloudFunctions(d, nil)
+	estimates := newEstimates(stub.ctx, t, resource)
+	assert.Equal(t, int64(1000234), estimates.usage["monthly_function_invocations"])
+	assert.Equal(t, int64(313), estimates.usage["request_duration_ms"])
+	assert.Equal(t, int64(25), estimates.usage["monthly_outbound_data_gb"])
+}
@@ -0,0 +1,132 @@
+package google_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/tidwall/gjson"
+
+	"github.com/infracost/infracost/internal/schema"
+	googleusage "github.com/infracost/infracost/internal/usage/google"
+)
+
+type estimates struct {
+	t     *testing.T
+	usage map[string]interface{}
+}
+
+func newEstimates(ctx context.Context, t *testing.T, resource *schema.Resource) estimates {
+	u := make(map[string]interface{})
+	err := resource.EstimateUsage(ctx, u)
+	if err != nil {
+		t.Fatalf("Expected %T.EstimateUsage to succeed, got %s", resource, err)
+	}
+
+	for _, item := range resource.UsageSchema {
+		value := u[item.Key]
+		if value == nil {
+			continue
+		}
+		switch item.ValueType {
+		case schema.Int64:
+			if _, ok := value.(int64); !ok {
+				t.Errorf("Expected %s %s of type an int64, got a %T", resource.Name, item.Key, value)
+			}
+		case schema.Float64:
+			if _, ok := value.(float64); !ok {
+				t.Errorf("Expected %s %s of type float64, got a %T", resource.Name, item.Key, value)
+			}
+		default:
+			t.Errorf("Unexpected UsageItem.ValueType %v", item.ValueType)
+		}
+	}
+
+	return estimates{
+		t:     t,
+		usage: u,
+	}
+}
+
+func newResourceData(resourceType string, address string, values string) *schema.ResourceData {
+	return schema.NewResourceData(resourceType, "registry.terraform.io/hashicorp/google", address, map[string]string{}, gjson.Parse(values))
+}
+
+type stubbedRequest struct {
+	path            string
+	filterFragments []string
+	pageToken       string
+	response        string
+	responseStatus  int
+}
+
+// Page matches the request for the page with the token instead of the first page.
+func (sr *stubbedRequest) Page(token string) *stubbedRequest {
+	sr.pageToken = token
+	return sr
+}
+
+func (sr *stubbedRequest) Then(status int, response string) {
+	sr.responseStatus = status
+	sr.response = response
+}
+
+type stubbedGoogle struct {
+	t        *testing.T
+	server   *httptest.Server
+	ctx      context.Context
+	requests []*stubbedRequest
+}
+
+func (sg *stubbedGoogle) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Header.Get("Authorization") != "Bearer test-token" {
+		sg.t.Errorf("Expected stubbed Google call to have the test token, got %q", r.Header.Get("Authorization"))
+	}
+
+	filter := r.URL.Query().Get("filter")
+
+	for _, sr := range sg.requests {
+		match := sr.path == r.URL.Path && sr.pageToken == r.URL.Query().Get("pageToken")
+
+		for _, fragment := range sr.filterFragments {
+			match = match && strings.Contains(filter, fragment)
+		}
+
+		if match {
+			w.WriteHeader(sr.responseStatus)
+			_, err := w.Write([]byte(sr.response))
+			if err != nil {
+				sg.t.Fatalf("Cannot write stubbed HTTP response: %s", err)
+			}
+			return
+		}
+	}
+	sg.t.Fatalf("received unexpected stubbed Google call: %s %s", r.Method, r.URL)
+}
+
+// WhenFilter stubs a timeSeries.list request for the project with a filter that
+// contains the fragments.
+func (sg *stubbedGoogle) WhenFilter(project string, fragments ...string) *stubbedRequest {
+	sr := &stubbedRequest{
+		path:            "/v3/projects/" + project + "/timeSeries",
+		filterFragments: fragments,
+	}
+	sg.requests = append(sg.requests, sr)
+	return sr
+}
+
+func (sg *stubbedGoogle) Close() {
+	sg.server.Close()
+}
+
+func stubGoogle(t *testing.T) *stubbedGoogle {
+	stub := &stubbedGoogle{
+		t:        t,
+		requests: make([]*stubbedRequest, 0),
+	}
+	stub.server = httptest.NewServer(stub)
+	stub.ctx = googleusage.WithTestEndpoint(context.TODO(), stub.server.URL)
+	return stub
+}
@@ -1,10 +1,17 @@
 package google
 
 import (
+	"context"
+
 	"github.com/infracost/infracost/internal/schema"
+	"github.com/infracost/infracost/internal/usage/google"
 	"github.com/shopspring/decimal"
 )
 
+var PubSubTopicUsageSchema = []*schema.UsageItem{
+	{Key: "monthly_message_data_tb", DefaultValue: 0, ValueType: schema.Float64},
+}
+
 func GetPubSubTopicRegistryItem() *schema.RegistryItem {
 	return &schema.RegistryItem{
 		Name:  "google_pubsub_topic",
@@ -19,8 +26,22 @@ func NewPubSubTopic(d *schema.ResourceData, u *schema.UsageData) *schema.Resourc
 		messageDataTB = decimalPtr(decimal.NewFromFloat(u.Get("monthly_message_data_tb").Float()))
 	}
 
+	project := d.Get("project").String()
+	topic := d.Get("name").String()
+
+	estimate := func(ctx context.Context, values map[string]interface{}) error {
+		bytes, err := google.PubSubGetTopicBytes(ctx, project, topic)
+		if err != nil {
+			return err
+		}
+		values["monthly_message_data_tb"] = bytes / 1024 / 1024 / 1024 / 1024
+		return nil
+	}
+
 	return &schema.Resource{
-		Name: d.Address,
+		Name:          d.Address,
+		UsageSchema:   PubSubTopicUsageSchema,
+		EstimateUsage: estimate,
 		CostComponents: []*schema.CostComponent{
 			{
 				Name:            "Message ingestion data",
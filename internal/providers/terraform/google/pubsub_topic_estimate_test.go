@@ -0,0 +1,28 @@
+package google_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/infracost/infracost/internal/providers/terraform/google"
+)
+
+func TestPubSubTopicEstimate(t *testing.T) {
+	stub := stubGoogle(t)
+	defer stub.Close()
+
+	stub.WhenFilter("my-project", `"pubsub.googleapis.com/topic/byte_cost"`, `resource.labels.topic_id = "my-topic"`).Then(200, `{
+		"timeSeries": [{
+			"points": [{"value": {"int64Value": "2199023255552"}}]
+		}]
+	}`)
+
+	d := newResourceData("google_pubsub_topic", "google_pubsub_topic.topic", `{
+		"project": "my-project",
+		"name": "my-topic"
+	}`)
+	resource := google.NewPubSubTopic(d, nil)
+	estimates := newEstimates(stub.ctx, t, resource)
+	assert.Equal(t, 2.0, estimates.usage["monthly_message_data_tb"])
+}
@@ -1,13 +1,22 @@
 package google
 
 import (
+	"context"
 	"fmt"
+	"math"
 	"strings"
 
 	"github.com/infracost/infracost/internal/schema"
+	"github.com/infracost/infracost/internal/usage/google"
 	"github.com/shopspring/decimal"
 )
 
+var StorageBucketUsageSchema = []*schema.UsageItem{
+	{Key: "storage_gb", DefaultValue: 0, ValueType: schema.Int64},
+	{Key: "monthly_class_a_operations", DefaultValue: 0, ValueType: schema.Int64},
+	{Key: "monthly_class_b_operations", DefaultValue: 0, ValueType: schema.Int64},
+}
+
 func GetStorageBucketRegistryItem() *schema.RegistryItem {
 	return &schema.RegistryItem{
 		Name:                "google_storage_bucket",
@@ -26,8 +35,30 @@ func NewStorageBucket(d *schema.ResourceData, u *schema.UsageData) *schema.Resou
 		components = append(components, data)
 	}
 	components = append(components, operations(d, u)...)
+
+	project := d.Get("project").String()
+	bucket := d.Get("name").String()
+
+	estimate := func(ctx context.Context, values map[string]interface{}) error {
+		bytes, err := google.StorageGetBucketSizeBytes(ctx, project, bucket)
+		if err != nil {
+			return err
+		}
+		values["storage_gb"] = int64(math.Round(bytes / 1024 / 1024 / 1024))
+
+		ops, err := google.StorageGetBucketOperations(ctx, project, bucket)
+		if err != nil {
+			return err
+		}
+		values["monthly_class_a_operations"] = int64(math.Round(ops.ClassA))
+		values["monthly_class_b_operations"] = int64(math.Round(ops.ClassB))
+		return nil
+	}
+
 	return &schema.Resource{
 		Name:           d.Address,
+		UsageSchema:    StorageBucketUsageSchema,
+		EstimateUsage:  estimate,
 		CostComponents: components,
 		SubResources: []*schema.Resource{
 			networkEgress(region, u, "Network egress", "Data transfer", StorageBucketEgress),
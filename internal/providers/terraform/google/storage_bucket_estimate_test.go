@@ -0,0 +1,57 @@
+package google_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/infracost/infracost/internal/providers/terraform/google"
+)
+
+func TestStorageBucketEstimate(t *testing.T) {
+	stub := stubGoogle(t)
+	defer stub.Close()
+
+	stub.WhenFilter("my-project", `"storage.googleapis.com/storage/total_bytes"`, `resource.labels.bucket_name = "my-bucket"`).Then(200, `{
+		"timeSeries": [{
+			"points": [{"value": {"doubleValue": 161061273600}}]
+		}]
+	}`)
+	stub.WhenFilter("my-project", `"storage.googleapis.com/api/request_count"`, `resource.labels.bucket_name = "my-bucket"`).Then(200, `{
+		"timeSeries": [
+			{"metric": {"labels": {"method": "WriteObject"}}, "points": [{"value": {"int64Value": "30000"}}]},
+			{"metric": {"labels": {"method": "ListObjects"}}, "points": [{"value": {"int64Value": "10000"}}]},
+			{"metric": {"labels": {"method": "ReadObject"}}, "points": [{"value": {"int64Value": "15000"}}]},
+			{"metric": {"labels": {"method": "GetObjectMetadata"}}, "points": [{"value": {"int64Value": "5000"}}]},
+			{"metric": {"labels": {"method": "DeleteObject"}}, "points": [{"value": {"int64Value": "700"}}]}
+		]
+	}`)
+
+	d := newResourceData("google_storage_bucket", "google_storage_bucket.bucket", `{
+		"project": "my-project",
+		"name": "my-bucket",
+		"location": "US"
+	}`)
+	resource := google.NewStorageBucket(d, nil)
+	estimates := newEstimates(stub.ctx, t, resource)
+	assert.Equal(t, int64(150), estimates.usage["storage_gb"])
+	assert.Equal(t, int64(40000), estimates.usage["monthly_class_a_operations"])
+	assert.Equal(t, int64(20000), estimates.usage["monthly_class_b_operations"])
+}
+
+func TestStorageBucketEstimateError(t *testing.T) {
+	stub := stubGoogle(t)
+	defer stub.Close()
+
+	stub.WhenFilter("my-project", `"storage.googleapis.com/storage/total_bytes"`).Then(403, `{
+		"error": {"code": 403, "message": "Permission monitoring.timeSeries.list denied", "status": "PERMISSION_DENIED"}
+	}`)
+
+	d := newResourceData("google_storage_bucket", "google_storage_bucket.bucket", `{
+		"project": "my-project",
+		"name": "my-bucket"
+	}`)
+	resource := google.NewStorageBucket(d, nil)
+	err := resource.EstimateUsage(stub.ctx, map[string]interface{}{})
+	assert.EqualError(t, err, "Cloud Monitoring API error: Permission monitoring.timeSeries.list denied")
+}
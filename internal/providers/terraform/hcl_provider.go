@@ -0,0 +1,153 @@
+package terraform
+
+import (
+	"strings"
+
+	"github.com/infracost/infracost/internal/config"
+	"github.com/infracost/infracost/internal/hcl"
+	"github.com/infracost/infracost/internal/schema"
+	"github.com/kballard/go-shellquote"
+	"github.com/pkg/errors"
+
+	log "github.com/sirupsen/logrus"
+)
+
+// HCLProvider evaluates the Terraform files in a directory directly instead of
+// running terraform plan, so it doesn't need the Terraform binary, provider
+// credentials or access to the backend.
+type HCLProvider struct {
+	ctx       *config.ProjectContext
+	Path      string
+	PlanFlags string
+	Workspace string
+	Env       map[string]string
+}
+
+func NewHCLProvider(ctx *config.ProjectContext) schema.Provider {
+	return &HCLProvider{
+		ctx:       ctx,
+		Path:      ctx.ProjectConfig.Path,
+		PlanFlags: ctx.ProjectConfig.TerraformPlanFlags,
+		Workspace: ctx.ProjectConfig.TerraformWorkspace,
+		Env:       ctx.ProjectConfig.Env,
+	}
+}
+
+func (p *HCLProvider) Type() string {
+	return "terraform_hcl"
+}
+
+func (p *HCLProvider) DisplayType() string {
+	return "Terraform directory (HCL)"
+}
+
+func (p *HCLProvider) AddMetadata(metadata *schema.ProjectMetadata) {
+	metadata.TerraformWorkspace = p.workspace()
+}
+
+func (p *HCLProvider) LoadResources(usage map[string]*schema.UsageData) ([]*schema.Project, error) {
+	opts, err := p.loadOptions()
+	if err != nil {
+		return []*schema.Project{}, err
+	}
+
+	result, err := hcl.LoadPlanJSON(p.Path, opts)
+	if err != nil {
+		return []*schema.Project{}, errors.Wrap(err, "Error evaluating Terraform HCL")
+	}
+
+	for _, w := range result.Warnings {
+		log.Warnf("%s%s: %s", p.ctx.LogPrefix, p.Path, w)
+	}
+	p.ctx.SetContextValue("terraformHCLWarningCount", len(result.Warnings))
+
+	metadata := config.DetectProjectMetadata(p.ctx.ProjectConfig.Path)
+	metadata.Type = p.Type()
+	p.AddMetadata(metadata)
+	name := schema.GenerateProjectName(metadata, p.ctx.RunContext.Config.EnableDashboard)
+
+	project := schema.NewProject(name, metadata)
+	parser := NewParser(p.ctx)
+
+	pastResources, resources, err := parser.parseJSON(result.PlanJSON, usage)
+	if err != nil {
+		return []*schema.Project{project}, errors.Wrap(err, "Error parsing Terraform HCL")
+	}
+
+	project.HasDiff = true
+	project.PastResources = pastResources
+	project.Resources = resources
+
+	return []*schema.Project{project}, nil
+}
+
+func (p *HCLProvider) workspace() string {
+	if p.Workspace != "" {
+		return p.Workspace
+	}
+
+	if w, ok := p.Env["TF_WORKSPACE"]; ok && w != "" {
+		return w
+	}
+
+	return "default"
+}
+
+// loadOptions returns the options for evaluating the HCL. The -var and -var-file
+// flags are taken from the Terraform plan flags so that the same variables are
+// used as when running terraform plan, any other flags are ignored.
+func (p *HCLProvider) loadOptions() (hcl.Options, error) {
+	opts := hcl.Options{
+		Workspace: p.workspace(),
+		Vars:      make(map[string]string),
+		Env:       p.Env,
+	}
+
+	flags, err := shellquote.Split(p.PlanFlags)
+	if err != nil {
+		return opts, errors.Wrap(err, "Error parsing terraform plan flags")
+	}
+
+	for i := 0; i < len(flags); i++ {
+		name, value, hasValue := splitFlag(flags[i])
+		if name != "var" && name != "var-file" {
+			continue
+		}
+
+		if !hasValue {
+			if i+1 >= len(flags) {
+				return opts, errors.Errorf("Error parsing terraform plan flags: -%s requires a value", name)
+			}
+			i++
+			value = flags[i]
+		}
+
+		if name == "var-file" {
+			opts.VarFiles = append(opts.VarFiles, value)
+			continue
+		}
+
+		parts := strings.SplitN(value, "=", 2)
+		if len(parts) != 2 {
+			return opts, errors.Errorf("Error parsing terraform plan flags: invalid -var value %s", value)
+		}
+		opts.Vars[parts[0]] = parts[1]
+	}
+
+	return opts, nil
+}
+
+// splitFlag splits a flag like -var-file=prod.tfvars into its name and value.
+func splitFlag(flag string) (string, string, bool) {
+	if !strings.HasPrefix(flag, "-") {
+		return "", "", false
+	}
+
+	flag = strings.TrimLeft(flag, "-")
+	parts := strings.SplitN(flag, "=", 2)
+	if len(parts) == 1 {
+		return parts[0], "", false
+	}
+
+	return parts[0], parts[1], true
+}
@@ -0,0 +1,32 @@
+package terraform
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHCLProviderLoadOptions(t *testing.T) {
+	p := &HCLProvider{
+		PlanFlags: `-var-file=prod.tfvars -var 'instance_type=m5.large' -var="tags={env=\"prod\"}" -refresh=false -var-file shared.tfvars`,
+		Env:       map[string]string{"TF_WORKSPACE": "prod"},
+	}
+
+	opts, err := p.loadOptions()
+	require.NoError(t, err)
+
+	assert.Equal(t, "prod", opts.Workspace)
+	assert.Equal(t, []string{"prod.tfvars", "shared.tfvars"}, opts.VarFiles)
+	assert.Equal(t, map[string]string{
+		"instance_type": "m5.large",
+		"tags":          `{env="prod"}`,
+	}, opts.Vars)
+}
+
+func TestHCLProviderLoadOptionsInvalidVar(t *testing.T) {
+	p := &HCLProvider{PlanFlags: "-var instance_type"}
+
+	_, err := p.loadOptions()
+	assert.EqualError(t, err, "Error parsing terraform plan flags: invalid -var value instance_type")
+}
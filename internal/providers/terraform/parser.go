@@ -69,6 +69,7 @@ func (p *Parser) createResource(d *schema.ResourceData, u *schema.UsageData) *sc
 		if res != nil {
 			res.ResourceType = d.Type
 			res.Tags = d.Tags
+			res.CloudResourceIDs = cloudResourceIDs(d)
 			if u != nil {
 				res.EstimationSummary = u.CalcEstimationSummary()
 			}
@@ -606,6 +607,20 @@ func isAwsChina(d *schema.ResourceData) bool {
 	return strings.HasPrefix(d.Type, "aws_") && strings.HasPrefix(d.Get("region").String(), "cn-")
 }
 
+// cloudResourceIDs returns the IDs of the resource from the state, which are
+// only known once the resource is deployed.
+func cloudResourceIDs(d *schema.ResourceData) []string {
+	var ids []string
+
+	for _, key := range []string{"id", "arn", "self_link"} {
+		if id := d.Get(key).String(); id != "" {
+			ids = append(ids, id)
+		}
+	}
+
+	return ids
+}
+
 func containsString(a []string, s string) bool {
 	for _, i := range a {
 		if i == s {
@@ -3,8 +3,12 @@ package terraform
 import (
 	"bytes"
 	"encoding/json"
+	"fmt"
 	"os"
 	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
 
 	"github.com/infracost/infracost/internal/config"
 	"github.com/infracost/infracost/internal/schema"
@@ -20,6 +24,8 @@ type TerragruntProvider struct {
 	ctx  *config.ProjectContext
 	Path string
 	*DirProvider
+	cachedConfigDirs []string
+	cachedOutputs    []terragruntOutput
 }
 
 type TerragruntInfo struct {
@@ -27,6 +33,13 @@ type TerragruntInfo struct {
 	WorkingDir string
 }
 
+// terragruntOutput is the plan or state JSON of a Terragrunt module, or the error
+// if it couldn't be generated.
+type terragruntOutput struct {
+	json []byte
+	err  error
+}
+
 func NewTerragruntProvider(ctx *config.ProjectContext) schema.Provider {
 	dirProvider := NewDirProvider(ctx).(*DirProvider)
 
@@ -58,22 +71,7 @@ func (p *TerragruntProvider) AddMetadata(metadata *schema.ProjectMetadata) {
 }
 
 func (p *TerragruntProvider) LoadResources(usage map[string]*schema.UsageData) ([]*schema.Project, error) {
-	// We want to run Terragrunt commands from the config dirs
-	// Terragrunt internally runs Terraform in the working dirs, so we need to be aware of these
-	// so we can handle reading and cleaning up the generated plan files.
-	configDirs, workingDirs, err := p.getProjectDirs()
-
-	if err != nil {
-		return []*schema.Project{}, err
-	}
-
-	var outs [][]byte
-
-	if p.UseState {
-		outs, err = p.generateStateJSONs(configDirs)
-	} else {
-		outs, err = p.generatePlanJSONs(configDirs, workingDirs)
-	}
+	configDirs, outs, err := p.generateOutputs()
 	if err != nil {
 		return []*schema.Project{}, err
 	}
@@ -87,14 +85,27 @@ func (p *TerragruntProvider) LoadResources(usage map[string]*schema.UsageData) (
 		name := schema.GenerateProjectName(metadata, p.ctx.RunContext.Config.EnableDashboard)
 
 		project := schema.NewProject(name, metadata)
+		project.HasDiff = !p.UseState
+
+		// A module that fails is still added to the projects with its error so the
+		// other modules can be costed and the output shows which ones are missing
+		if outs[i].err != nil {
+			log.Warnf("%sSkipping Terragrunt module %s: %s", p.ctx.LogPrefix, path, outs[i].err)
+			project.Error = moduleErrorMessage(outs[i].err)
+			projects = append(projects, project)
+			continue
+		}
 
 		parser := NewParser(p.ctx)
-		pastResources, resources, err := parser.parseJSON(outs[i], usage)
+		pastResources, resources, err := parser.parseJSON(outs[i].json, usage)
 		if err != nil {
-			return projects, errors.Wrap(err, "Error parsing Terraform JSON")
+			err = errors.Wrap(err, "Error parsing Terraform JSON")
+			log.Warnf("%sSkipping Terragrunt module %s: %s", p.ctx.LogPrefix, path, err)
+			project.Error = err.Error()
+			projects = append(projects, project)
+			continue
 		}
 
-		project.HasDiff = !p.UseState
 		if project.HasDiff {
 			project.PastResources = pastResources
 		}
@@ -106,6 +117,39 @@ func (p *TerragruntProvider) LoadResources(usage map[string]*schema.UsageData) (
 	return projects, nil
 }
 
+// generateOutputs returns the config dirs of the modules and their plan or state
+// JSON. These are cached so the resources can be loaded again with different
+// usage data without running Terragrunt again.
+func (p *TerragruntProvider) generateOutputs() ([]string, []terragruntOutput, error) {
+	if p.cachedOutputs != nil {
+		return p.cachedConfigDirs, p.cachedOutputs, nil
+	}
+
+	// We want to run Terragrunt commands from the config dirs
+	// Terragrunt internally runs Terraform in the working dirs, so we need to be aware of these
+	// so we can handle reading and cleaning up the generated plan files.
+	configDirs, workingDirs, err := p.getProjectDirs()
+	if err != nil {
+		return configDirs, []terragruntOutput{}, err
+	}
+
+	var outs []terragruntOutput
+
+	if p.UseState {
+		outs, err = p.generateStateJSONs(configDirs)
+	} else {
+		outs, err = p.generatePlanJSONs(configDirs, workingDirs)
+	}
+	if err != nil {
+		return configDirs, outs, err
+	}
+
+	p.cachedConfigDirs = configDirs
+	p.cachedOutputs = outs
+
+	return configDirs, outs, nil
+}
+
 func (p *TerragruntProvider) getProjectDirs() ([]string, []string, error) {
 	spinner := ui.NewSpinner("Running terragrunt run-all terragrunt-info", p.spinnerOpts)
 
@@ -146,94 +190,178 @@ func (p *TerragruntProvider) getProjectDirs() ([]string, []string, error) {
 	return configDirs, workingDirs, nil
 }
 
-func (p *TerragruntProvider) generateStateJSONs(configDirs []string) ([][]byte, error) {
+func (p *TerragruntProvider) generateStateJSONs(configDirs []string) ([]terragruntOutput, error) {
 	err := p.checks()
 	if err != nil {
-		return [][]byte{}, err
+		return []terragruntOutput{}, err
 	}
 
-	outs := make([][]byte, 0, len(configDirs))
-
-	spinnerMsg := "Running terragrunt show"
-	if len(configDirs) > 1 {
-		spinnerMsg += " for each project"
-	}
-	spinner := ui.NewSpinner(spinnerMsg, p.spinnerOpts)
-
-	for _, path := range configDirs {
-		opts, err := p.buildCommandOpts(path)
+	return p.runModules(configDirs, func(i int, mp *DirProvider) ([]byte, error) {
+		opts, err := mp.buildCommandOpts(configDirs[i])
 		if err != nil {
-			return [][]byte{}, err
+			return []byte{}, err
 		}
 		if opts.TerraformConfigFile != "" {
 			defer os.Remove(opts.TerraformConfigFile)
 		}
 
-		out, err := p.runShow(opts, spinner, "")
-		if err != nil {
-			return outs, err
-		}
-		outs = append(outs, out)
-	}
-
-	return outs, nil
+		spinner := ui.NewSpinner("Running terragrunt show", mp.spinnerOpts)
+		return mp.runShow(opts, spinner, "")
+	}), nil
 }
 
-func (p *DirProvider) generatePlanJSONs(configDirs []string, workingDirs []string) ([][]byte, error) {
+// generatePlanJSONs plans all the modules with terragrunt run-all so that
+// Terragrunt runs the modules in the order of their dependencies, then shows the
+// plan of each module. The run-all plan fails if any of the modules fail, so the
+// error is only returned for the modules that don't have a plan file.
+func (p *TerragruntProvider) generatePlanJSONs(configDirs []string, workingDirs []string) ([]terragruntOutput, error) {
 	err := p.checks()
 	if err != nil {
-		return [][]byte{}, err
+		return []terragruntOutput{}, err
 	}
 
 	opts, err := p.buildCommandOpts(p.Path)
 	if err != nil {
-		return [][]byte{}, err
+		return []terragruntOutput{}, err
 	}
 	if opts.TerraformConfigFile != "" {
 		defer os.Remove(opts.TerraformConfigFile)
 	}
 
 	spinner := ui.NewSpinner("Running terragrunt run-all plan", p.spinnerOpts)
-	planFile, planJSON, err := p.runPlan(opts, spinner, true)
+	planFile, planJSON, planErr := p.runPlan(opts, spinner, true)
 	defer func() {
 		err := cleanupPlanFiles(workingDirs, planFile)
 		if err != nil {
-			log.Warnf("Error cleaning up plan files: %v", err)
+			log.Warnf("%sError cleaning up plan files: %v", p.ctx.LogPrefix, err)
 		}
 	}()
 
-	if err != nil {
-		return [][]byte{}, err
+	if planFile == "" {
+		return []terragruntOutput{}, planErr
 	}
 
-	if len(planJSON) > 0 {
-		return [][]byte{planJSON}, nil
+	// Modules that use Terraform Cloud's remote execution mode return the plan JSON
+	// directly, which is only possible when there's one module
+	if len(planJSON) > 0 && len(configDirs) == 1 {
+		return []terragruntOutput{{json: planJSON}}, nil
 	}
 
-	outs := make([][]byte, 0, len(configDirs))
-	spinnerMsg := "Running terragrunt show"
-	if len(configDirs) > 1 {
-		spinnerMsg += " for each project"
-	}
-	spinner = ui.NewSpinner(spinnerMsg, p.spinnerOpts)
+	return p.runModules(configDirs, func(i int, mp *DirProvider) ([]byte, error) {
+		planPath := filepath.Join(workingDirs[i], planFile)
+		if _, err := os.Stat(planPath); planErr != nil && os.IsNotExist(err) {
+			return []byte{}, planErr
+		}
 
-	for i, path := range configDirs {
-		opts, err := p.buildCommandOpts(path)
+		opts, err := mp.buildCommandOpts(configDirs[i])
 		if err != nil {
-			return [][]byte{}, err
+			return []byte{}, err
 		}
 		if opts.TerraformConfigFile != "" {
 			defer os.Remove(opts.TerraformConfigFile)
 		}
 
-		out, err := p.runShow(opts, spinner, filepath.Join(workingDirs[i], planFile))
-		if err != nil {
-			return outs, err
+		spinner := ui.NewSpinner("Running terragrunt show", mp.spinnerOpts)
+		return mp.runShow(opts, spinner, planPath)
+	}), nil
+}
+
+// runModules calls fn for each of the module config dirs with a pool of workers,
+// limited by the Terragrunt parallelism of the project. An error from one module
+// doesn't stop the others, it's returned in the output of that module. The outputs
+// are in the same order as the config dirs.
+func (p *TerragruntProvider) runModules(configDirs []string, fn func(i int, mp *DirProvider) ([]byte, error)) []terragruntOutput {
+	parallelism := config.ParallelismFor(p.terragruntParallelism(), len(configDirs))
+
+	outs := make([]terragruntOutput, len(configDirs))
+	jobs := make(chan int)
+
+	var wg sync.WaitGroup
+	for w := 0; w < parallelism; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			for i := range jobs {
+				// Each module gets its own copy of the provider so its spinners can be
+				// prefixed with the module path
+				mp := *p.DirProvider
+				if len(configDirs) > 1 {
+					mp.spinnerOpts.Prefix += fmt.Sprintf("[%s] ", p.modulePath(configDirs[i]))
+				}
+
+				out, err := fn(i, &mp)
+				outs[i] = terragruntOutput{json: out, err: err}
+			}
+		}()
+	}
+
+	for i := range configDirs {
+		jobs <- i
+	}
+	close(jobs)
+
+	wg.Wait()
+
+	return outs
+}
+
+// terragruntParallelism returns the number of Terragrunt modules to run at the
+// same time, or 0 if it isn't set.
+func (p *DirProvider) terragruntParallelism() int {
+	if p.ctx.ProjectConfig.TerragruntParallelism > 0 {
+		return p.ctx.ProjectConfig.TerragruntParallelism
+	}
+
+	return p.ctx.RunContext.Config.Parallelism
+}
+
+// terragruntRunAllArgs returns the args to run a command in all the Terragrunt
+// modules, in the order of their dependencies.
+func (p *DirProvider) terragruntRunAllArgs() []string {
+	args := []string{"run-all", "--terragrunt-ignore-external-dependencies"}
+
+	if n := p.terragruntParallelism(); n > 0 {
+		args = append(args, "--terragrunt-parallelism", strconv.Itoa(n))
+	}
+
+	return args
+}
+
+// modulePath returns the path of the module config dir relative to the project path.
+func (p *TerragruntProvider) modulePath(configDir string) string {
+	rel, err := filepath.Rel(p.Path, configDir)
+	if err != nil || rel == "." {
+		return ui.DisplayPath(p.Path)
+	}
+
+	return rel
+}
+
+// moduleErrorMessage returns the message for a module that errored. When the
+// error is from a Terragrunt command, it includes the error part of stderr since
+// the error itself is usually just the exit status.
+func moduleErrorMessage(err error) string {
+	msg := err.Error()
+
+	var cmdErr *CmdError
+	if !errors.As(err, &cmdErr) {
+		return msg
+	}
+
+	stderr := stripBlankLines(string(cmdErr.Stderr))
+	if stderr == "" {
+		return msg
+	}
+
+	// Terragrunt logs to stderr too so skip the lines before the first error
+	if i := strings.Index(stderr, "Error:"); i != -1 {
+		if j := strings.LastIndex(stderr[:i], "\n"); j != -1 {
+			stderr = stderr[j+1:]
 		}
-		outs = append(outs, out)
 	}
 
-	return outs, nil
+	return msg + "\n" + stderr
 }
 
 func cleanupPlanFiles(paths []string, planFile string) error {
@@ -241,9 +369,10 @@ func cleanupPlanFiles(paths []string, planFile string) error {
 		return nil
 	}
 
+	// Modules that failed to plan don't have a plan file
 	for _, path := range paths {
 		err := os.Remove(filepath.Join(path, planFile))
-		if err != nil {
+		if err != nil && !os.IsNotExist(err) {
 			return err
 		}
 	}
@@ -0,0 +1,64 @@
+package terraform
+
+import (
+	"fmt"
+	"path/filepath"
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/infracost/infracost/internal/config"
+)
+
+func TestTerragruntRunModules(t *testing.T) {
+	ctx := config.EmptyProjectContext()
+	ctx.ProjectConfig.Path = "infra"
+	ctx.ProjectConfig.TerragruntParallelism = 2
+
+	p := NewTerragruntProvider(ctx).(*TerragruntProvider)
+
+	configDirs := []string{
+		filepath.Join("infra", "prod", "app"),
+		filepath.Join("infra", "prod", "db"),
+		filepath.Join("infra", "prod", "cache"),
+	}
+
+	outs := p.runModules(configDirs, func(i int, mp *DirProvider) ([]byte, error) {
+		if i == 1 {
+			return []byte{}, errors.New("Error running terraform plan")
+		}
+
+		return []byte(fmt.Sprintf(`{"prefix": %q}`, mp.spinnerOpts.Prefix)), nil
+	})
+
+	assert.Len(t, outs, 3)
+	assert.Equal(t, fmt.Sprintf(`{"prefix": "[%s] "}`, filepath.Join("prod", "app")), string(outs[0].json))
+	assert.NoError(t, outs[0].err)
+	assert.EqualError(t, outs[1].err, "Error running terraform plan")
+	assert.Equal(t, fmt.Sprintf(`{"prefix": "[%s] "}`, filepath.Join("prod", "cache")), string(outs[2].json))
+	assert.NoError(t, outs[2].err)
+}
+
+func TestModuleErrorMessage(t *testing.T) {
+	err := errors.Wrap(&CmdError{
+		err:    errors.New("exit status 1"),
+		Stderr: []byte("[terragrunt] Running command: terraform plan\n\nError: No value for required variable\n\n  on variables.tf line 1:\n"),
+	}, "Error running terraform plan")
+
+	assert.Equal(t, "Error running terraform plan: exit status 1\nError: No value for required variable\n  on variables.tf line 1:", moduleErrorMessage(err))
+	assert.Equal(t, "Error parsing Terraform JSON", moduleErrorMessage(errors.New("Error parsing Terraform JSON")))
+}
+
+func TestTerragruntRunAllArgs(t *testing.T) {
+	ctx := config.EmptyProjectContext()
+	p := NewTerragruntProvider(ctx).(*TerragruntProvider)
+
+	assert.Equal(t, []string{"run-all", "--terragrunt-ignore-external-dependencies"}, p.terragruntRunAllArgs())
+
+	ctx.RunContext.Config.Parallelism = 4
+	assert.Equal(t, []string{"run-all", "--terragrunt-ignore-external-dependencies", "--terragrunt-parallelism", "4"}, p.terragruntRunAllArgs())
+
+	ctx.ProjectConfig.TerragruntParallelism = 2
+	assert.Equal(t, []string{"run-all", "--terragrunt-ignore-external-dependencies", "--terragrunt-parallelism", "2"}, p.terragruntRunAllArgs())
+}
@@ -263,11 +263,12 @@ func RunCostCalculations(t *testing.T, runCtx *config.RunContext, tfProject Terr
 		return projects, err
 	}
 
+	err = prices.PopulatePrices(runCtx, projects)
+	if err != nil {
+		return projects, err
+	}
+
 	for _, project := range projects {
-		err = prices.PopulatePrices(runCtx.Config, project)
-		if err != nil {
-			return projects, err
-		}
 		schema.CalculateCosts(project)
 	}
 
@@ -309,7 +310,7 @@ func RunSyncUsage(t *testing.T, runCtx *config.RunContext, tfProject TerraformPr
 		return nil, err
 	}
 
-	_, err = usage.SyncUsageData(usageFile, projects)
+	_, err = usage.SyncUsageData(usageFile, projects, usage.SyncUsageDataOpts{})
 	if err != nil {
 		return nil, err
 	}
@@ -0,0 +1,202 @@
+package aws
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/infracost/infracost/internal/resources"
+	"github.com/infracost/infracost/internal/schema"
+	"github.com/shopspring/decimal"
+)
+
+type DBInstance struct {
+	// "required" args that can't really be missing.
+	Address       string
+	Region        string
+	InstanceClass string
+	Engine        string
+	MultiAZ       bool
+
+	// "optional" args, that may be empty depending on the resource config
+	LicenseModel       string
+	StorageType        string
+	IOPS               float64
+	AllocatedStorageGB *float64
+
+	// "usage" args
+	MonthlyStandardIORequests *int64 `infracost_usage:"monthly_standard_io_requests"`
+}
+
+func (a *DBInstance) PopulateUsage(u *schema.UsageData) {
+	resources.PopulateArgsWithUsage(a, u)
+}
+
+func (a *DBInstance) BuildResource() *schema.Resource {
+	deploymentOption := "Single-AZ"
+	if a.MultiAZ {
+		deploymentOption = "Multi-AZ"
+	}
+
+	engine := strings.ToLower(a.Engine)
+
+	var databaseEngine *string
+	switch engine {
+	case "postgres":
+		databaseEngine = strPtr("PostgreSQL")
+	case "mysql":
+		databaseEngine = strPtr("MySQL")
+	case "mariadb":
+		databaseEngine = strPtr("MariaDB")
+	case "aurora", "aurora-mysql":
+		databaseEngine = strPtr("Aurora MySQL")
+	case "aurora-postgresql":
+		databaseEngine = strPtr("Aurora PostgreSQL")
+	case "oracle-se", "oracle-se1", "oracle-se2", "oracle-ee":
+		databaseEngine = strPtr("Oracle")
+	case "sqlserver-ex", "sqlserver-web", "sqlserver-se", "sqlserver-ee":
+		databaseEngine = strPtr("SQL Server")
+	}
+
+	var databaseEdition *string
+	switch engine {
+	case "oracle-se", "sqlserver-se":
+		databaseEdition = strPtr("Standard")
+	case "oracle-se1":
+		databaseEdition = strPtr("Standard One")
+	case "oracle-se2":
+		databaseEdition = strPtr("Standard Two")
+	case "oracle-ee", "sqlserver-ee":
+		databaseEdition = strPtr("Enterprise")
+	case "sqlserver-ex":
+		databaseEdition = strPtr("Express")
+	case "sqlserver-web":
+		databaseEdition = strPtr("Web")
+	}
+
+	var licenseModel *string
+	if engine == "oracle-se1" || engine == "oracle-se2" || strings.HasPrefix(engine, "sqlserver-") {
+		licenseModel = strPtr("License included")
+	}
+	if strings.ToLower(a.LicenseModel) == "bring-your-own-license" {
+		licenseModel = strPtr("Bring your own license")
+	}
+
+	iopsVal := decimal.NewFromFloat(a.IOPS)
+
+	allocatedStorageVal := decimal.NewFromInt(20)
+	if a.AllocatedStorageGB != nil {
+		allocatedStorageVal = decimal.NewFromFloat(*a.AllocatedStorageGB)
+	}
+
+	volumeType := "General Purpose"
+	storageName := "Storage (general purpose SSD, gp2)"
+	if a.StorageType != "" {
+		if strings.ToLower(a.StorageType) == "io1" || iopsVal.GreaterThan(decimal.Zero) {
+			volumeType = "Provisioned IOPS"
+			storageName = "Storage (provisioned IOPS SSD, io1)"
+			if iopsVal.LessThan(decimal.NewFromInt(1000)) {
+				iopsVal = decimal.NewFromInt(1000)
+			}
+			if allocatedStorageVal.LessThan(decimal.NewFromInt(100)) {
+				allocatedStorageVal = decimal.NewFromInt(100)
+			}
+		} else if strings.ToLower(a.StorageType) == "standard" {
+			volumeType = "Magnetic"
+			storageName = "Storage (magnetic)"
+		}
+	}
+
+	instanceAttributeFilters := []*schema.AttributeFilter{
+		{Key: "instanceType", Value: strPtr(a.InstanceClass)},
+		{Key: "deploymentOption", Value: strPtr(deploymentOption)},
+		{Key: "databaseEngine", Value: databaseEngine},
+	}
+	if databaseEdition != nil {
+		instanceAttributeFilters = append(instanceAttributeFilters, &schema.AttributeFilter{
+			Key:   "databaseEdition",
+			Value: databaseEdition,
+		})
+	}
+	if licenseModel != nil {
+		instanceAttributeFilters = append(instanceAttributeFilters, &schema.AttributeFilter{
+			Key:   "licenseModel",
+			Value: licenseModel,
+		})
+	}
+
+	costComponents := []*schema.CostComponent{
+		{
+			Name:           fmt.Sprintf("Database instance (on-demand, %s, %s)", deploymentOption, a.InstanceClass),
+			Unit:           "hours",
+			UnitMultiplier: decimal.NewFromInt(1),
+			HourlyQuantity: decimalPtr(decimal.NewFromInt(1)),
+			ProductFilter: &schema.ProductFilter{
+				VendorName:       strPtr("aws"),
+				Region:           strPtr(a.Region),
+				Service:          strPtr("AmazonRDS"),
+				ProductFamily:    strPtr("Database Instance"),
+				AttributeFilters: instanceAttributeFilters,
+			},
+			PriceFilter: &schema.PriceFilter{
+				PurchaseOption: strPtr("on_demand"),
+			},
+		},
+		{
+			Name:            storageName,
+			Unit:            "GB",
+			UnitMultiplier:  decimal.NewFromInt(1),
+			MonthlyQuantity: &allocatedStorageVal,
+			ProductFilter: &schema.ProductFilter{
+				VendorName:    strPtr("aws"),
+				Region:        strPtr(a.Region),
+				Service:       strPtr("AmazonRDS"),
+				ProductFamily: strPtr("Database Storage"),
+				AttributeFilters: []*schema.AttributeFilter{
+					{Key: "volumeType", Value: strPtr(volumeType)},
+					{Key: "deploymentOption", Value: strPtr(deploymentOption)},
+				},
+			},
+		},
+	}
+
+	if volumeType == "Magnetic" {
+		costComponents = append(costComponents, &schema.CostComponent{
+			Name:            "I/O requests",
+			Unit:            "1M requests",
+			UnitMultiplier:  decimal.NewFromInt(1000000),
+			MonthlyQuantity: intPtrToDecimalPtr(a.MonthlyStandardIORequests),
+			ProductFilter: &schema.ProductFilter{
+				VendorName:    strPtr("aws"),
+				Region:        strPtr(a.Region),
+				Service:       strPtr("AmazonRDS"),
+				ProductFamily: strPtr("System Operation"),
+				AttributeFilters: []*schema.AttributeFilter{
+					{Key: "usagetype", ValueRegex: strPtr("/RDS:StorageIOUsage/i")},
+				},
+			},
+		})
+	}
+
+	if volumeType == "Provisioned IOPS" {
+		costComponents = append(costComponents, &schema.CostComponent{
+			Name:            "Provisioned IOPS",
+			Unit:            "IOPS",
+			UnitMultiplier:  decimal.NewFromInt(1),
+			MonthlyQuantity: &iopsVal,
+			ProductFilter: &schema.ProductFilter{
+				VendorName:    strPtr("aws"),
+				Region:        strPtr(a.Region),
+				Service:       strPtr("AmazonRDS"),
+				ProductFamily: strPtr("Provisioned IOPS"),
+				AttributeFilters: []*schema.AttributeFilter{
+					{Key: "deploymentOption", Value: strPtr(deploymentOption)},
+				},
+			},
+		})
+	}
+
+	return &schema.Resource{
+		Name:           a.Address,
+		CostComponents: costComponents,
+	}
+}
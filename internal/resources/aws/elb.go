@@ -0,0 +1,47 @@
+package aws
+
+import (
+	"github.com/infracost/infracost/internal/resources"
+	"github.com/infracost/infracost/internal/schema"
+	"github.com/shopspring/decimal"
+)
+
+// ELB is a classic load balancer.
+type ELB struct {
+	// "required" args that can't really be missing.
+	Address string
+	Region  string
+
+	// "usage" args
+	MonthlyDataProcessedGB *int64 `infracost_usage:"monthly_data_processed_gb"`
+}
+
+func (a *ELB) PopulateUsage(u *schema.UsageData) {
+	resources.PopulateArgsWithUsage(a, u)
+}
+
+func (a *ELB) BuildResource() *schema.Resource {
+	productFamily := "Load Balancer"
+
+	return &schema.Resource{
+		Name: a.Address,
+		CostComponents: []*schema.CostComponent{
+			loadBalancerHoursCostComponent(a.Region, productFamily, "Classic load balancer"),
+			{
+				Name:            "Data processed",
+				Unit:            "GB",
+				UnitMultiplier:  decimal.NewFromInt(1),
+				MonthlyQuantity: intPtrToDecimalPtr(a.MonthlyDataProcessedGB),
+				ProductFilter: &schema.ProductFilter{
+					VendorName:    strPtr("aws"),
+					Region:        strPtr(a.Region),
+					Service:       strPtr("AWSELB"),
+					ProductFamily: strPtr(productFamily),
+					AttributeFilters: []*schema.AttributeFilter{
+						{Key: "usagetype", ValueRegex: strPtr("/DataProcessing-Bytes/")},
+					},
+				},
+			},
+		},
+	}
+}
@@ -0,0 +1,107 @@
+package aws
+
+import (
+	"strings"
+
+	"github.com/infracost/infracost/internal/resources"
+	"github.com/infracost/infracost/internal/schema"
+	"github.com/shopspring/decimal"
+)
+
+// LB is an application or network load balancer.
+type LB struct {
+	// "required" args that can't really be missing.
+	Address          string
+	Region           string
+	LoadBalancerType string
+
+	// "usage" args
+	NewConnections    *int64 `infracost_usage:"new_connections"`
+	ActiveConnections *int64 `infracost_usage:"active_connections"`
+	ProcessedBytesGB  *int64 `infracost_usage:"processed_bytes_gb"`
+	RuleEvaluations   *int64 `infracost_usage:"rule_evaluations"`
+}
+
+func (a *LB) PopulateUsage(u *schema.UsageData) {
+	resources.PopulateArgsWithUsage(a, u)
+}
+
+func (a *LB) BuildResource() *schema.Resource {
+	// The LCUs are the maximum of the LCUs for each of the dimensions
+	var maxLCU *decimal.Decimal
+	maxLCU = maxDecimalPtr(maxLCU, lcuPtr(a.NewConnections, 100))
+	maxLCU = maxDecimalPtr(maxLCU, lcuPtr(a.ActiveConnections, 3000))
+	maxLCU = maxDecimalPtr(maxLCU, lcuPtr(a.ProcessedBytesGB, 1))
+
+	costComponentName := "Network load balancer"
+	productFamily := "Load Balancer-Network"
+
+	if strings.ToLower(a.LoadBalancerType) == "application" {
+		costComponentName = "Application load balancer"
+		productFamily = "Load Balancer-Application"
+		maxLCU = maxDecimalPtr(maxLCU, lcuPtr(a.RuleEvaluations, 1000))
+	}
+
+	return &schema.Resource{
+		Name: a.Address,
+		CostComponents: []*schema.CostComponent{
+			loadBalancerHoursCostComponent(a.Region, productFamily, costComponentName),
+			{
+				Name:            "Load balancer capacity units",
+				Unit:            "LCU",
+				UnitMultiplier:  schema.HourToMonthUnitMultiplier,
+				MonthlyQuantity: maxLCU,
+				ProductFilter: &schema.ProductFilter{
+					VendorName:    strPtr("aws"),
+					Region:        strPtr(a.Region),
+					Service:       strPtr("AWSELB"),
+					ProductFamily: strPtr(productFamily),
+					AttributeFilters: []*schema.AttributeFilter{
+						{Key: "locationType", Value: strPtr("AWS Region")},
+						{Key: "usagetype", ValueRegex: strPtr("/LCUUsage/")},
+					},
+				},
+			},
+		},
+	}
+}
+
+func loadBalancerHoursCostComponent(region, productFamily, name string) *schema.CostComponent {
+	return &schema.CostComponent{
+		Name:           name,
+		Unit:           "hours",
+		HourlyQuantity: decimalPtr(decimal.NewFromInt(1)),
+		UnitMultiplier: decimal.NewFromInt(1),
+		ProductFilter: &schema.ProductFilter{
+			VendorName:    strPtr("aws"),
+			Region:        strPtr(region),
+			Service:       strPtr("AWSELB"),
+			ProductFamily: strPtr(productFamily),
+			AttributeFilters: []*schema.AttributeFilter{
+				{Key: "locationType", Value: strPtr("AWS Region")},
+				{Key: "usagetype", ValueRegex: strPtr("/LoadBalancerUsage/")},
+			},
+		},
+	}
+}
+
+// lcuPtr returns the LCUs for the usage, given the amount of the usage that
+// one LCU provides.
+func lcuPtr(i *int64, perLCU int64) *decimal.Decimal {
+	if i == nil {
+		return nil
+	}
+
+	return decimalPtr(decimal.NewFromInt(*i).Div(decimal.NewFromInt(perLCU)))
+}
+
+func maxDecimalPtr(a, b *decimal.Decimal) *decimal.Decimal {
+	if a == nil {
+		return b
+	}
+	if b == nil {
+		return a
+	}
+
+	return decimalPtr(decimal.Max(*a, *b))
+}
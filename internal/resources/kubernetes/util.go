@@ -0,0 +1,11 @@
+package kubernetes
+
+import "github.com/shopspring/decimal"
+
+func strPtr(s string) *string {
+	return &s
+}
+
+func decimalPtr(d decimal.Decimal) *decimal.Decimal {
+	return &d
+}
@@ -0,0 +1,174 @@
+package kubernetes
+
+import (
+	"fmt"
+
+	"github.com/shopspring/decimal"
+
+	"github.com/infracost/infracost/internal/resources"
+	"github.com/infracost/infracost/internal/schema"
+)
+
+// The relative cost of a vCPU-hour and a GB-hour of memory, which are used to
+// split the price of a node between its CPU and memory. These are the default
+// OpenCost weights for on-demand nodes.
+var (
+	cpuHourlyWeight    = decimal.NewFromFloat(0.031611)
+	memoryHourlyWeight = decimal.NewFromFloat(0.004237)
+)
+
+// NodeProfile is the node that workloads are scheduled on, which is used to
+// attribute the node price to the CPU and memory that workloads request.
+type NodeProfile struct {
+	Cloud        string
+	Region       string
+	InstanceType string
+	VCPU         float64
+	MemoryGB     float64
+}
+
+// Workload is a Deployment or StatefulSet. The CPU and memory are the requests
+// of a single replica.
+type Workload struct {
+	Address     string
+	NodeProfile NodeProfile
+	CPU         float64
+	MemoryGB    float64
+
+	Replicas *int64 `infracost_usage:"replicas"`
+}
+
+var WorkloadUsageSchema = []*schema.UsageItem{
+	{Key: "replicas", DefaultValue: 0, ValueType: schema.Int64},
+}
+
+func (a *Workload) PopulateUsage(u *schema.UsageData) {
+	resources.PopulateArgsWithUsage(a, u)
+}
+
+func (a *Workload) BuildResource() *schema.Resource {
+	replicas := decimal.NewFromInt(1)
+	if a.Replicas != nil {
+		replicas = decimal.NewFromInt(*a.Replicas)
+	}
+
+	vcpu := decimal.NewFromFloat(a.NodeProfile.VCPU)
+	memoryGB := decimal.NewFromFloat(a.NodeProfile.MemoryGB)
+
+	cpuCost := vcpu.Mul(cpuHourlyWeight)
+	memoryCost := memoryGB.Mul(memoryHourlyWeight)
+	cpuShare := cpuCost.Div(cpuCost.Add(memoryCost))
+	memoryShare := decimal.NewFromInt(1).Sub(cpuShare)
+
+	var costComponents []*schema.CostComponent
+
+	if a.CPU > 0 {
+		costComponents = append(costComponents, a.nodeCostComponent(
+			fmt.Sprintf("CPU requests (%s)", a.NodeProfile.InstanceType),
+			"vCPU-hours",
+			decimal.NewFromFloat(a.CPU).Mul(replicas),
+			cpuShare.Div(vcpu),
+		))
+	}
+
+	if a.MemoryGB > 0 {
+		costComponents = append(costComponents, a.nodeCostComponent(
+			fmt.Sprintf("Memory requests (%s)", a.NodeProfile.InstanceType),
+			"GB-hours",
+			decimal.NewFromFloat(a.MemoryGB).Mul(replicas),
+			memoryShare.Div(memoryGB),
+		))
+	}
+
+	return &schema.Resource{
+		Name:           a.Address,
+		UsageSchema:    a.usageSchema(),
+		CostComponents: costComponents,
+	}
+}
+
+// usageSchema returns the usage schema with the replicas of the workload as the
+// default, so that syncing the usage file doesn't set the replicas to 0.
+func (a *Workload) usageSchema() []*schema.UsageItem {
+	if a.Replicas == nil {
+		return WorkloadUsageSchema
+	}
+
+	return []*schema.UsageItem{
+		{Key: "replicas", DefaultValue: *a.Replicas, ValueType: schema.Int64},
+	}
+}
+
+// nodeCostComponent returns a cost component for the share of the node price.
+// The price is per node-hour, so the unit multiplier is the share of the node
+// that's attributed to one unit, e.g. one vCPU.
+func (a *Workload) nodeCostComponent(name, unit string, quantity, unitShare decimal.Decimal) *schema.CostComponent {
+	return &schema.CostComponent{
+		Name:           name,
+		Unit:           unit,
+		UnitMultiplier: unitShare,
+		HourlyQuantity: decimalPtr(quantity.Mul(unitShare)),
+		ProductFilter:  a.NodeProfile.productFilter(),
+		PriceFilter:    a.NodeProfile.priceFilter(),
+	}
+}
+
+func (p NodeProfile) productFilter() *schema.ProductFilter {
+	switch p.Cloud {
+	case "google":
+		return &schema.ProductFilter{
+			VendorName:    strPtr("gcp"),
+			Region:        strPtr(p.Region),
+			Service:       strPtr("Compute Engine"),
+			ProductFamily: strPtr("Compute Instance"),
+			AttributeFilters: []*schema.AttributeFilter{
+				{Key: "machineType", ValueRegex: strPtr(fmt.Sprintf("/%s/i", p.InstanceType))},
+			},
+		}
+	case "azure":
+		return &schema.ProductFilter{
+			VendorName:    strPtr("azure"),
+			Region:        strPtr(p.Region),
+			Service:       strPtr("Virtual Machines"),
+			ProductFamily: strPtr("Compute"),
+			AttributeFilters: []*schema.AttributeFilter{
+				{Key: "skuName", ValueRegex: strPtr("/^(?!.*(Low Priority|Spot)$).*$/i")},
+				{Key: "armSkuName", ValueRegex: strPtr(fmt.Sprintf("/^%s$/i", p.InstanceType))},
+				{Key: "productName", ValueRegex: strPtr("/Virtual Machines .* Series$/")},
+			},
+		}
+	default:
+		return &schema.ProductFilter{
+			VendorName:    strPtr("aws"),
+			Region:        strPtr(p.Region),
+			Service:       strPtr("AmazonEC2"),
+			ProductFamily: strPtr("Compute Instance"),
+			AttributeFilters: []*schema.AttributeFilter{
+				{Key: "instanceType", Value: strPtr(p.InstanceType)},
+				{Key: "tenancy", Value: strPtr("Shared")},
+				{Key: "operatingSystem", Value: strPtr("Linux")},
+				{Key: "preInstalledSw", Value: strPtr("NA")},
+				{Key: "licenseModel", Value: strPtr("No License required")},
+				{Key: "capacitystatus", Value: strPtr("Used")},
+			},
+		}
+	}
+}
+
+func (p NodeProfile) priceFilter() *schema.PriceFilter {
+	switch p.Cloud {
+	case "google":
+		return &schema.PriceFilter{
+			PurchaseOption: strPtr("on_demand"),
+		}
+	case "azure":
+		return &schema.PriceFilter{
+			PurchaseOption: strPtr("Consumption"),
+			Unit:           strPtr("1 Hour"),
+		}
+	default:
+		return &schema.PriceFilter{
+			PurchaseOption: strPtr("on_demand"),
+		}
+	}
+}
@@ -10,6 +10,7 @@ import (
 	"regexp"
 	"strings"
 
+	"github.com/shopspring/decimal"
 	log "github.com/sirupsen/logrus"
 )
 
@@ -22,6 +23,13 @@ type ProjectMetadata struct {
 	TerraformWorkspace string `json:"terraformWorkspace,omitempty"`
 }
 
+// Budget is the monthly budget of a project. AlertThreshold is an optional
+// percentage of the budget above which the project should be flagged.
+type Budget struct {
+	MonthlyBudget  decimal.Decimal
+	AlertThreshold *decimal.Decimal
+}
+
 // Project contains the existing, planned state of
 // resources and the diff between them.
 type Project struct {
@@ -31,6 +39,11 @@ type Project struct {
 	Resources     []*Resource
 	Diff          []*Resource
 	HasDiff       bool
+	Budget        *Budget
+	// Error is set when the project could not be loaded, e.g. because its plan
+	// failed. Errored projects have no resources but are still included in the
+	// output so that users know they weren't costed.
+	Error string
 }
 
 func NewProject(name string, metadata *ProjectMetadata) *Project {
@@ -25,6 +25,9 @@ type Resource struct {
 	UsageSchema       []*UsageItem
 	EstimateUsage     EstimateFunc
 	EstimationSummary map[string]bool
+	// CloudResourceIDs are the IDs of the deployed resource, e.g. its ID and ARN
+	// from the Terraform state, used to match it to billing exports
+	CloudResourceIDs []string
 }
 
 func CalculateCosts(project *Project) {
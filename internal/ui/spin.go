@@ -14,12 +14,18 @@ type SpinnerOptions struct {
 	EnableLogging bool
 	NoColor       bool
 	Indent        string
+	// Prefix is added to the start of the messages, e.g. the project path when
+	// projects are loaded in parallel. Spinners with a prefix aren't animated since
+	// other spinners can be running at the same time, only their result is printed.
+	Prefix string
 }
 
 type Spinner struct {
 	spinner *spinnerpkg.Spinner
 	msg     string
 	opts    SpinnerOptions
+	// waiting is set for spinners that aren't animated until they are stopped.
+	waiting bool
 }
 
 func NewSpinner(msg string, opts SpinnerOptions) *Spinner {
@@ -29,12 +35,14 @@ func NewSpinner(msg string, opts SpinnerOptions) *Spinner {
 	}
 	s := &Spinner{
 		spinner: spinnerpkg.New(spinnerpkg.CharSets[spinnerCharNumb], 100*time.Millisecond, spinnerpkg.WithWriter(os.Stderr)),
-		msg:     msg,
+		msg:     opts.Prefix + msg,
 		opts:    opts,
 	}
 
 	if s.opts.EnableLogging {
-		log.Infof("starting: %s", msg)
+		log.Infof("starting: %s", s.msg)
+	} else if s.opts.Prefix != "" {
+		s.waiting = true
 	} else {
 		s.spinner.Prefix = opts.Indent
 		s.spinner.Suffix = fmt.Sprintf(" %s", msg)
@@ -49,10 +57,15 @@ func NewSpinner(msg string, opts SpinnerOptions) *Spinner {
 
 func (s *Spinner) Stop() {
 	s.spinner.Stop()
+	s.waiting = false
+}
+
+func (s *Spinner) active() bool {
+	return s.spinner.Active() || s.waiting
 }
 
 func (s *Spinner) Fail() {
-	if s.spinner == nil || !s.spinner.Active() {
+	if s.spinner == nil || !s.active() {
 		return
 	}
 	s.Stop()
@@ -68,12 +81,12 @@ func (s *Spinner) Fail() {
 }
 
 func (s *Spinner) SuccessWithMessage(newMsg string) {
-	s.msg = newMsg
+	s.msg = s.opts.Prefix + newMsg
 	s.Success()
 }
 
 func (s *Spinner) Success() {
-	if !s.spinner.Active() {
+	if !s.active() {
 		return
 	}
 	s.Stop()
@@ -0,0 +1,33 @@
+package azure
+
+import (
+	"context"
+
+	log "github.com/sirupsen/logrus"
+)
+
+// ApplicationGatewayGetBytesProcessed returns the bytes received and sent by the
+// application gateway.
+func ApplicationGatewayGetBytesProcessed(ctx context.Context, gatewayID string) (float64, error) {
+	log.Debugf("Querying Azure Monitor: BytesReceived,BytesSent(gateway: %s)", gatewayID)
+
+	return monitorGetMonthlyTotal(ctx, metricRequest{
+		resourceID:  gatewayID,
+		metric:      "BytesReceived,BytesSent",
+		aggregation: aggregationTotal,
+		interval:    intervalDay,
+	})
+}
+
+// ApplicationGatewayGetCapacityUnitHours returns the capacity units used by a
+// v2 application gateway, which are billed hourly.
+func ApplicationGatewayGetCapacityUnitHours(ctx context.Context, gatewayID string) (float64, error) {
+	log.Debugf("Querying Azure Monitor: CapacityUnits(gateway: %s)", gatewayID)
+
+	return monitorGetMonthlyTotal(ctx, metricRequest{
+		resourceID:  gatewayID,
+		metric:      "CapacityUnits",
+		aggregation: aggregationAverage,
+		interval:    intervalHour,
+	})
+}
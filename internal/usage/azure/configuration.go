@@ -0,0 +1,152 @@
+package azure
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"os"
+	"os/exec"
+	"strings"
+	"sync"
+
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+)
+
+const (
+	defaultManagementEndpoint = "https://management.azure.com"
+	loginEndpoint             = "https://login.microsoftonline.com"
+)
+
+var (
+	cachedConfig     config
+	cachedConfigErr  error
+	cachedConfigOnce sync.Once
+)
+
+type ctxConfigKeyType struct{}
+
+var ctxConfigKey = &ctxConfigKeyType{}
+
+type config struct {
+	endpoint       string
+	token          string
+	subscriptionID string
+}
+
+// getConfig returns the Azure Resource Manager endpoint, access token and
+// subscription. The credentials are read from the same ARM_ environment
+// variables as the Terraform AzureRM provider, or from the Azure CLI.
+func getConfig(ctx context.Context) (config, error) {
+	if cfg, ok := ctx.Value(ctxConfigKey).(config); ok {
+		return cfg, nil
+	}
+
+	cachedConfigOnce.Do(func() {
+		cachedConfig, cachedConfigErr = loadConfig(ctx)
+	})
+
+	return cachedConfig, cachedConfigErr
+}
+
+func loadConfig(ctx context.Context) (config, error) {
+	cfg := config{
+		endpoint:       defaultManagementEndpoint,
+		subscriptionID: os.Getenv("ARM_SUBSCRIPTION_ID"),
+	}
+
+	var err error
+
+	clientID := os.Getenv("ARM_CLIENT_ID")
+	clientSecret := os.Getenv("ARM_CLIENT_SECRET")
+	tenantID := os.Getenv("ARM_TENANT_ID")
+
+	if clientID != "" && clientSecret != "" && tenantID != "" {
+		log.Debugf("Getting Azure access token for client %s", clientID)
+		cfg.token, err = clientCredentialsToken(ctx, tenantID, clientID, clientSecret)
+		if err != nil {
+			return cfg, errors.Wrap(err, "Could not get an Azure access token for ARM_CLIENT_ID")
+		}
+	} else {
+		log.Debugf("Getting Azure access token from the Azure CLI")
+		cfg.token, err = azCLI("account", "get-access-token", "--resource", defaultManagementEndpoint+"/", "--query", "accessToken", "--output", "tsv")
+		if err != nil {
+			return cfg, errors.Wrap(err, "Could not get an Azure access token, set ARM_CLIENT_ID, ARM_CLIENT_SECRET and ARM_TENANT_ID or log in with the Azure CLI")
+		}
+	}
+
+	if cfg.subscriptionID == "" {
+		cfg.subscriptionID, err = azCLI("account", "show", "--query", "id", "--output", "tsv")
+		if err != nil {
+			return cfg, errors.Wrap(err, "Could not find the Azure subscription, set ARM_SUBSCRIPTION_ID or log in with the Azure CLI")
+		}
+	}
+
+	return cfg, nil
+}
+
+func azCLI(args ...string) (string, error) {
+	out, err := exec.Command("az", args...).Output()
+	if err != nil {
+		return "", err
+	}
+
+	return strings.TrimSpace(string(out)), nil
+}
+
+// clientCredentialsToken gets an access token for a service principal with a
+// client secret.
+func clientCredentialsToken(ctx context.Context, tenantID, clientID, clientSecret string) (string, error) {
+	form := url.Values{}
+	form.Set("grant_type", "client_credentials")
+	form.Set("client_id", clientID)
+	form.Set("client_secret", clientSecret)
+	form.Set("scope", defaultManagementEndpoint+"/.default")
+
+	req, err := http.NewRequestWithContext(ctx, "POST", loginEndpoint+"/"+url.PathEscape(tenantID)+"/oauth2/v2.0/token", strings.NewReader(form.Encode()))
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	var r struct {
+		AccessToken      string `json:"access_token"`
+		ErrorDescription string `json:"error_description"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&r); err != nil {
+		return "", err
+	}
+
+	if r.AccessToken == "" {
+		return "", errors.Errorf("Invalid response from Azure AD: %s %s", resp.Status, r.ErrorDescription)
+	}
+
+	return r.AccessToken, nil
+}
+
+// ResourceID returns the ID of the Azure resource. Resources that already exist
+// have their ID in the Terraform state, otherwise it's built from the
+// subscription, resource group, resource type (e.g. Microsoft.Web/sites) and name.
+func ResourceID(ctx context.Context, id string, resourceGroup string, resourceType string, name string) (string, error) {
+	if id != "" {
+		return id, nil
+	}
+
+	if resourceGroup == "" || name == "" {
+		return "", errors.New("Could not find the resource group and name of the Azure resource")
+	}
+
+	cfg, err := getConfig(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	return "/subscriptions/" + cfg.subscriptionID + "/resourceGroups/" + resourceGroup + "/providers/" + resourceType + "/" + name, nil
+}
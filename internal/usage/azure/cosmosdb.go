@@ -0,0 +1,57 @@
+package azure
+
+import (
+	"context"
+
+	log "github.com/sirupsen/logrus"
+)
+
+// cosmosDBFilter filters the account metrics by the database and, for
+// containers, the collection.
+func cosmosDBFilter(database string, collection string) string {
+	if collection == "" {
+		return dimensionFilter("DatabaseName", database)
+	}
+
+	return dimensionFilter("DatabaseName", database, "CollectionName", collection)
+}
+
+func CosmosDBGetRequestUnits(ctx context.Context, accountID string, database string, collection string) (float64, error) {
+	log.Debugf("Querying Azure Monitor: TotalRequestUnits(account: %s, database: %s, collection: %s)", accountID, database, collection)
+
+	return monitorGetMonthlyTotal(ctx, metricRequest{
+		resourceID:  accountID,
+		metric:      "TotalRequestUnits",
+		aggregation: aggregationTotal,
+		interval:    intervalDay,
+		filter:      cosmosDBFilter(database, collection),
+	})
+}
+
+// CosmosDBGetNormalizedRUConsumption returns the average of the hourly maximum
+// percentage of the provisioned throughput that was used. Autoscale throughput
+// is billed at the highest throughput in each hour.
+func CosmosDBGetNormalizedRUConsumption(ctx context.Context, accountID string, database string, collection string) (float64, error) {
+	log.Debugf("Querying Azure Monitor: NormalizedRUConsumption(account: %s, database: %s, collection: %s)", accountID, database, collection)
+
+	return monitorGetMonthlyMean(ctx, metricRequest{
+		resourceID:  accountID,
+		metric:      "NormalizedRUConsumption",
+		aggregation: aggregationMaximum,
+		interval:    intervalHour,
+		filter:      cosmosDBFilter(database, collection),
+	})
+}
+
+// CosmosDBGetStorageBytes returns the average size of the data and indexes.
+func CosmosDBGetStorageBytes(ctx context.Context, accountID string, database string, collection string) (float64, error) {
+	log.Debugf("Querying Azure Monitor: DataUsage,IndexUsage(account: %s, database: %s, collection: %s)", accountID, database, collection)
+
+	return monitorGetMonthlyMean(ctx, metricRequest{
+		resourceID:  accountID,
+		metric:      "DataUsage,IndexUsage",
+		aggregation: aggregationAverage,
+		interval:    intervalDay,
+		filter:      cosmosDBFilter(database, collection),
+	})
+}
@@ -0,0 +1,31 @@
+package azure
+
+import (
+	"context"
+
+	log "github.com/sirupsen/logrus"
+)
+
+func FunctionsGetExecutionCount(ctx context.Context, appID string) (float64, error) {
+	log.Debugf("Querying Azure Monitor: FunctionExecutionCount(app: %s)", appID)
+
+	return monitorGetMonthlyTotal(ctx, metricRequest{
+		resourceID:  appID,
+		metric:      "FunctionExecutionCount",
+		aggregation: aggregationTotal,
+		interval:    intervalDay,
+	})
+}
+
+// FunctionsGetExecutionUnits returns the execution units of the function app in
+// MB-milliseconds, i.e. the memory used multiplied by the execution time.
+func FunctionsGetExecutionUnits(ctx context.Context, appID string) (float64, error) {
+	log.Debugf("Querying Azure Monitor: FunctionExecutionUnits(app: %s)", appID)
+
+	return monitorGetMonthlyTotal(ctx, metricRequest{
+		resourceID:  appID,
+		metric:      "FunctionExecutionUnits",
+		aggregation: aggregationTotal,
+		interval:    intervalDay,
+	})
+}
@@ -0,0 +1,209 @@
+package azure
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+const metricsAPIVersion = "2018-01-01"
+
+const (
+	aggregationTotal   = "Total"
+	aggregationAverage = "Average"
+	aggregationMaximum = "Maximum"
+)
+
+const (
+	intervalHour = "PT1H"
+	intervalDay  = "P1D"
+)
+
+type metricRequest struct {
+	resourceID  string
+	metric      string
+	aggregation string
+	interval    string
+	// filter is an OData filter on the metric dimensions, e.g. ApiName eq '*'
+	// to split the metric by API name
+	filter string
+}
+
+type metricSeries struct {
+	dimensions map[string]string
+	values     []float64
+}
+
+func (s metricSeries) sum() float64 {
+	total := 0.0
+	for _, v := range s.values {
+		total += v
+	}
+
+	return total
+}
+
+func (s metricSeries) mean() float64 {
+	if len(s.values) == 0 {
+		return 0
+	}
+
+	return s.sum() / float64(len(s.values))
+}
+
+type metricsResponse struct {
+	Value []struct {
+		Timeseries []struct {
+			Metadatavalues []struct {
+				Name struct {
+					Value string `json:"value"`
+				} `json:"name"`
+				Value string `json:"value"`
+			} `json:"metadatavalues"`
+			Data []map[string]interface{} `json:"data"`
+		} `json:"timeseries"`
+	} `json:"value"`
+}
+
+// monitorGetMetric returns the time series of the metric over the last month. The
+// values of each series are its data points for the aggregation, skipping the
+// intervals that have no data.
+func monitorGetMetric(ctx context.Context, req metricRequest) ([]metricSeries, error) {
+	cfg, err := getConfig(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now().UTC()
+
+	params := url.Values{}
+	params.Set("api-version", metricsAPIVersion)
+	params.Set("metricnames", req.metric)
+	params.Set("aggregation", req.aggregation)
+	params.Set("interval", req.interval)
+	params.Set("timespan", fmt.Sprintf("%s/%s", now.Add(-timeMonth).Format(time.RFC3339), now.Format(time.RFC3339)))
+	if req.filter != "" {
+		params.Set("$filter", req.filter)
+	}
+
+	u := fmt.Sprintf("%s%s/providers/Microsoft.Insights/metrics?%s", strings.TrimSuffix(cfg.endpoint, "/"), req.resourceID, params.Encode())
+
+	var resp metricsResponse
+	if err := monitorGet(ctx, u, cfg.token, &resp); err != nil {
+		return nil, err
+	}
+
+	// The data points are keyed by the lower case aggregation, e.g. total
+	key := strings.ToLower(req.aggregation)
+
+	var series []metricSeries
+
+	for _, m := range resp.Value {
+		for _, ts := range m.Timeseries {
+			s := metricSeries{dimensions: make(map[string]string)}
+
+			for _, md := range ts.Metadatavalues {
+				s.dimensions[strings.ToLower(md.Name.Value)] = md.Value
+			}
+
+			for _, d := range ts.Data {
+				if v, ok := d[key].(float64); ok {
+					s.values = append(s.values, v)
+				}
+			}
+
+			series = append(series, s)
+		}
+	}
+
+	return series, nil
+}
+
+// monitorGetMonthlyTotal returns the sum of the metric over the last month.
+func monitorGetMonthlyTotal(ctx context.Context, req metricRequest) (float64, error) {
+	series, err := monitorGetMetric(ctx, req)
+	if err != nil {
+		return 0, err
+	}
+
+	total := 0.0
+	for _, s := range series {
+		total += s.sum()
+	}
+
+	return total, nil
+}
+
+// monitorGetMonthlyMean returns the mean of the data points of the metric over
+// the last month, summed across the time series.
+func monitorGetMonthlyMean(ctx context.Context, req metricRequest) (float64, error) {
+	series, err := monitorGetMetric(ctx, req)
+	if err != nil {
+		return 0, err
+	}
+
+	total := 0.0
+	for _, s := range series {
+		total += s.mean()
+	}
+
+	return total, nil
+}
+
+func monitorGet(ctx context.Context, u string, token string, v interface{}) error {
+	req, err := http.NewRequestWithContext(ctx, "GET", u, nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Add("Authorization", fmt.Sprintf("Bearer %s", token))
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		// Azure Monitor returns the error at the top level, while the other
+		// Resource Manager APIs wrap it in an error object
+		var apiErr struct {
+			Message string `json:"message"`
+			Error   struct {
+				Message string `json:"message"`
+			} `json:"error"`
+		}
+		if json.Unmarshal(body, &apiErr) == nil {
+			if apiErr.Error.Message != "" {
+				return errors.Errorf("Azure Monitor API error: %s", apiErr.Error.Message)
+			}
+			if apiErr.Message != "" {
+				return errors.Errorf("Azure Monitor API error: %s", apiErr.Message)
+			}
+		}
+		return errors.Errorf("Invalid response from Azure Monitor API: %s", resp.Status)
+	}
+
+	return json.Unmarshal(body, v)
+}
+
+// dimensionFilter returns an OData filter that matches the dimension values.
+func dimensionFilter(dimensions ...string) string {
+	var filters []string
+	for i := 0; i+1 < len(dimensions); i += 2 {
+		filters = append(filters, fmt.Sprintf("%s eq '%s'", dimensions[i], strings.ReplaceAll(dimensions[i+1], "'", "''")))
+	}
+
+	return strings.Join(filters, " and ")
+}
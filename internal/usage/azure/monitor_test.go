@@ -0,0 +1,191 @@
+package azure
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type stubbedResponse struct {
+	path   string
+	metric string
+	filter string
+	status int
+	body   string
+}
+
+type stubbedMonitor struct {
+	t         *testing.T
+	server    *httptest.Server
+	ctx       context.Context
+	responses []stubbedResponse
+	queries   []url.Values
+}
+
+func (sm *stubbedMonitor) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Header.Get("Authorization") != "Bearer test-token" {
+		sm.t.Errorf("Expected stubbed Azure Monitor call to have the test token, got %q", r.Header.Get("Authorization"))
+	}
+
+	q := r.URL.Query()
+	sm.queries = append(sm.queries, q)
+
+	for _, resp := range sm.responses {
+		if r.URL.Path == resp.path && q.Get("metricnames") == resp.metric && q.Get("$filter") == resp.filter {
+			w.WriteHeader(resp.status)
+			_, err := w.Write([]byte(resp.body))
+			if err != nil {
+				sm.t.Fatalf("Cannot write stubbed HTTP response: %s", err)
+			}
+			return
+		}
+	}
+	sm.t.Fatalf("Received unexpected stubbed Azure Monitor call: %s %s", r.Method, r.URL)
+}
+
+// When stubs the response for the metrics of the resource, with the metric names
+// and dimension filter.
+func (sm *stubbedMonitor) When(resourceID string, metric string, filter string, status int, body string) {
+	sm.responses = append(sm.responses, stubbedResponse{
+		path:   resourceID + "/providers/Microsoft.Insights/metrics",
+		metric: metric,
+		filter: filter,
+		status: status,
+		body:   body,
+	})
+}
+
+func stubMonitor(t *testing.T) *stubbedMonitor {
+	stub := &stubbedMonitor{t: t}
+	stub.server = httptest.NewServer(stub)
+	stub.ctx = WithTestEndpoint(context.TODO(), stub.server.URL)
+	t.Cleanup(stub.server.Close)
+	return stub
+}
+
+const (
+	testGatewayID        = "/subscriptions/" + TestSubscriptionID + "/resourceGroups/my-rg/providers/Microsoft.Network/applicationGateways/my-gateway"
+	testStorageAccountID = "/subscriptions/" + TestSubscriptionID + "/resourceGroups/my-rg/providers/Microsoft.Storage/storageAccounts/myaccount"
+	testCosmosDBID       = "/subscriptions/" + TestSubscriptionID + "/resourceGroups/my-rg/providers/Microsoft.DocumentDB/databaseAccounts/my-account"
+)
+
+func TestMonitorGetMonthlyTotal(t *testing.T) {
+	stub := stubMonitor(t)
+
+	stub.When(testGatewayID, "BytesReceived,BytesSent", "", 200, `{
+		"value": [
+			{"name": {"value": "BytesReceived"}, "timeseries": [{"data": [{"total": 300}]}]},
+			{"name": {"value": "BytesSent"}, "timeseries": [{"data": [{"total": 100}, {"timeStamp": "2021-01-02T00:00:00Z"}, {"total": 100}]}]}
+		]
+	}`)
+	stub.When(testGatewayID, "CapacityUnits", "", 200, `{
+		"value": [{"timeseries": [{"data": [{"average": 2.5}, {"average": 3.5}, {"average": 4}]}]}]
+	}`)
+
+	bytes, err := ApplicationGatewayGetBytesProcessed(stub.ctx, testGatewayID)
+	require.NoError(t, err)
+	assert.Equal(t, 500.0, bytes)
+
+	hours, err := ApplicationGatewayGetCapacityUnitHours(stub.ctx, testGatewayID)
+	require.NoError(t, err)
+	assert.Equal(t, 10.0, hours)
+
+	require.Len(t, stub.queries, 2)
+	assert.Equal(t, metricsAPIVersion, stub.queries[0].Get("api-version"))
+	assert.Equal(t, aggregationTotal, stub.queries[0].Get("aggregation"))
+	assert.Equal(t, intervalDay, stub.queries[0].Get("interval"))
+	assert.NotEmpty(t, stub.queries[0].Get("timespan"))
+	assert.Equal(t, aggregationAverage, stub.queries[1].Get("aggregation"))
+	assert.Equal(t, intervalHour, stub.queries[1].Get("interval"))
+}
+
+func TestMonitorGetMonthlyMean(t *testing.T) {
+	stub := stubMonitor(t)
+
+	filter := "DatabaseName eq 'my-db' and CollectionName eq 'my-container'"
+
+	stub.When(testCosmosDBID, "DataUsage,IndexUsage", filter, 200, `{
+		"value": [
+			{"name": {"value": "DataUsage"}, "timeseries": [{"data": [{"average": 80}, {"average": 120}]}]},
+			{"name": {"value": "IndexUsage"}, "timeseries": [{"data": [{"average": 10}]}]}
+		]
+	}`)
+	stub.When(testCosmosDBID, "NormalizedRUConsumption", filter, 200, `{
+		"value": [{"timeseries": [{"data": [{"maximum": 20}, {"maximum": 40}, {"maximum": 90}]}]}]
+	}`)
+	stub.When(testStorageAccountID+"/fileServices/default", "FileCapacity", "", 200, `{
+		"value": [{"timeseries": [{"data": [{"average": 100}, {"average": 200}, {"timeStamp": "2021-01-03T00:00:00Z"}]}]}]
+	}`)
+
+	storage, err := CosmosDBGetStorageBytes(stub.ctx, testCosmosDBID, "my-db", "my-container")
+	require.NoError(t, err)
+	assert.Equal(t, 110.0, storage)
+
+	utilization, err := CosmosDBGetNormalizedRUConsumption(stub.ctx, testCosmosDBID, "my-db", "my-container")
+	require.NoError(t, err)
+	assert.Equal(t, 50.0, utilization)
+
+	capacity, err := StorageGetCapacityBytes(stub.ctx, testStorageAccountID, "file")
+	require.NoError(t, err)
+	assert.Equal(t, 150.0, capacity)
+}
+
+func TestMonitorGetMetricError(t *testing.T) {
+	stub := stubMonitor(t)
+
+	stub.When(testGatewayID, "BytesReceived,BytesSent", "", 403, `{
+		"error": {"code": "AuthorizationFailed", "message": "The client does not have authorization to perform action 'microsoft.insights/metrics/read'"}
+	}`)
+	stub.When(testCosmosDBID, "TotalRequestUnits", "DatabaseName eq 'my-db'", 400, `{
+		"code": "BadRequest", "message": "Metric: TotalRequestUnits does not support requested dimension combination"
+	}`)
+	stub.When(testGatewayID, "CapacityUnits", "", 500, `Internal error`)
+
+	_, err := ApplicationGatewayGetBytesProcessed(stub.ctx, testGatewayID)
+	assert.EqualError(t, err, "Azure Monitor API error: The client does not have authorization to perform action 'microsoft.insights/metrics/read'")
+
+	_, err = CosmosDBGetRequestUnits(stub.ctx, testCosmosDBID, "my-db", "")
+	assert.EqualError(t, err, "Azure Monitor API error: Metric: TotalRequestUnits does not support requested dimension combination")
+
+	_, err = ApplicationGatewayGetCapacityUnitHours(stub.ctx, testGatewayID)
+	assert.EqualError(t, err, "Invalid response from Azure Monitor API: 500 Internal Server Error")
+}
+
+func TestDimensionFilter(t *testing.T) {
+	assert.Equal(t, "ApiName eq '*'", dimensionFilter("ApiName", "*"))
+	assert.Equal(t, "DatabaseName eq 'o''brien' and CollectionName eq 'my-container'", dimensionFilter("DatabaseName", "o'brien", "CollectionName", "my-container"))
+	assert.Equal(t, "", dimensionFilter())
+}
+
+func TestStorageGetTransactions(t *testing.T) {
+	stub := stubMonitor(t)
+
+	stub.When(testStorageAccountID+"/blobServices/default", "Transactions", "ApiName eq '*'", 200, `{
+		"value": [{
+			"name": {"value": "Transactions"},
+			"timeseries": [
+				{"metadatavalues": [{"name": {"value": "apiname"}, "value": "PutBlob"}], "data": [{"total": 1000}, {"total": 500}]},
+				{"metadatavalues": [{"name": {"value": "apiname"}, "value": "PutBlockList"}], "data": [{"total": 200}]},
+				{"metadatavalues": [{"name": {"value": "apiname"}, "value": "ListBlobs"}], "data": [{"total": 300}]},
+				{"metadatavalues": [{"name": {"value": "apiname"}, "value": "CreateContainer"}], "data": [{"total": 4}]},
+				{"metadatavalues": [{"name": {"value": "apiname"}, "value": "GetBlob"}], "data": [{"total": 9000}]},
+				{"metadatavalues": [{"name": {"value": "apiname"}, "value": "GetBlobProperties"}], "data": [{"total": 70}]},
+				{"metadatavalues": [{"name": {"value": "apiname"}, "value": "DeleteBlob"}], "data": [{"total": 80}]}
+			]
+		}]
+	}`)
+
+	transactions, err := StorageGetTransactions(stub.ctx, testStorageAccountID, "blob")
+	require.NoError(t, err)
+	assert.Equal(t, StorageTransactions{
+		Write:                  1700,
+		ListAndCreateContainer: 304,
+		Read:                   9000,
+		Other:                  70,
+	}, transactions)
+}
@@ -0,0 +1,95 @@
+package azure
+
+import (
+	"context"
+	"strings"
+
+	log "github.com/sirupsen/logrus"
+)
+
+// StorageTransactions is the number of transactions on a storage service by
+// operation type.
+type StorageTransactions struct {
+	Write                  float64
+	ListAndCreateContainer float64
+	Read                   float64
+	Other                  float64
+}
+
+// storageServices are the metric namespaces of the storage services, which
+// are child resources of the account.
+var storageServices = map[string]string{
+	"blob": "blobServices/default",
+	"file": "fileServices/default",
+}
+
+var storageCapacityMetrics = map[string]string{
+	"blob": "BlobCapacity",
+	"file": "FileCapacity",
+}
+
+// StorageGetCapacityBytes returns the average capacity of the blob or file
+// service of the storage account.
+func StorageGetCapacityBytes(ctx context.Context, accountID string, service string) (float64, error) {
+	metric := storageCapacityMetrics[service]
+	log.Debugf("Querying Azure Monitor: %s(account: %s)", metric, accountID)
+
+	return monitorGetMonthlyMean(ctx, metricRequest{
+		resourceID:  accountID + "/" + storageServices[service],
+		metric:      metric,
+		aggregation: aggregationAverage,
+		interval:    intervalDay,
+	})
+}
+
+// StorageGetTransactions returns the transactions on the blob or file service of
+// the storage account, split by the API operations.
+func StorageGetTransactions(ctx context.Context, accountID string, service string) (StorageTransactions, error) {
+	log.Debugf("Querying Azure Monitor: Transactions(account: %s, service: %s)", accountID, service)
+
+	var t StorageTransactions
+
+	series, err := monitorGetMetric(ctx, metricRequest{
+		resourceID:  accountID + "/" + storageServices[service],
+		metric:      "Transactions",
+		aggregation: aggregationTotal,
+		interval:    intervalDay,
+		filter:      dimensionFilter("ApiName", "*"),
+	})
+	if err != nil {
+		return t, err
+	}
+
+	for _, s := range series {
+		switch storageOperationType(s.dimensions["apiname"]) {
+		case "write":
+			t.Write += s.sum()
+		case "list":
+			t.ListAndCreateContainer += s.sum()
+		case "read":
+			t.Read += s.sum()
+		case "other":
+			t.Other += s.sum()
+		}
+	}
+
+	return t, nil
+}
+
+// storageOperationType returns the type that the API operation is billed as.
+// Deletes are free.
+func storageOperationType(api string) string {
+	switch {
+	case api == "", strings.HasPrefix(api, "Delete"):
+		return ""
+	case strings.HasPrefix(api, "List"), api == "CreateContainer", api == "CreateShare", api == "CreateDirectory":
+		return "list"
+	case strings.HasPrefix(api, "Put"), strings.HasPrefix(api, "Append"), strings.HasPrefix(api, "Copy"),
+		strings.HasPrefix(api, "Snapshot"), api == "SetBlobTier", api == "CreateFile":
+		return "write"
+	case api == "GetBlob", api == "GetFile":
+		return "read"
+	default:
+		return "other"
+	}
+}
@@ -0,0 +1,19 @@
+package azure
+
+import (
+	"context"
+)
+
+// TestSubscriptionID is the subscription used for resources without an ID when
+// testing against a stubbed endpoint.
+const TestSubscriptionID = "00000000-0000-0000-0000-000000000000"
+
+// WithTestEndpoint returns a context that sends Azure Monitor requests to the
+// URL with a test access token.
+func WithTestEndpoint(ctx context.Context, url string) context.Context {
+	return context.WithValue(ctx, ctxConfigKey, config{
+		endpoint:       url,
+		token:          "test-token",
+		subscriptionID: TestSubscriptionID,
+	})
+}
@@ -0,0 +1,7 @@
+package azure
+
+import (
+	"time"
+)
+
+const timeMonth = time.Hour * 24 * 30
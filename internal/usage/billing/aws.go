@@ -0,0 +1,74 @@
+package billing
+
+import (
+	"strconv"
+	"time"
+)
+
+// awsCUR is the format of AWS Cost and Usage Reports. The columns are in the
+// lineItem/UsageType format of the CSV reports, or the line_item_usage_type
+// format of the reports that are integrated with Athena.
+var awsCUR = &format{
+	cloud: "aws",
+	detect: func(cols columns) bool {
+		return cols.has("lineItem/UsageType", "line_item_usage_type")
+	},
+	parse: func(r row) (LineItem, bool) {
+		switch r.get("lineItem/LineItemType", "line_item_line_item_type") {
+		case "Usage", "DiscountedUsage", "SavingsPlanCoveredUsage":
+		default:
+			return LineItem{}, false
+		}
+
+		quantity, err := strconv.ParseFloat(r.get("lineItem/UsageAmount", "line_item_usage_amount"), 64)
+		if err != nil {
+			return LineItem{}, false
+		}
+
+		tags := r.prefixed("resourcetags/user:")
+		for k, v := range r.prefixed("resource_tags_user_") {
+			tags[k] = v
+		}
+
+		return LineItem{
+			ResourceID: r.get("lineItem/ResourceId", "line_item_resource_id"),
+			Tags:       tags,
+			UsageType:  r.get("lineItem/UsageType", "line_item_usage_type"),
+			Quantity:   quantity,
+			Unit:       r.get("pricing/unit", "pricing_unit"),
+			Start:      parseTime(r.get("lineItem/UsageStartDate", "line_item_usage_start_date"), time.RFC3339, "2006-01-02T15:04Z", "2006-01-02 15:04:05"),
+			End:        parseTime(r.get("lineItem/UsageEndDate", "line_item_usage_end_date"), time.RFC3339, "2006-01-02T15:04Z", "2006-01-02 15:04:05"),
+		}, true
+	},
+	// The usage types are prefixed by the region code, e.g. USE1-Requests-Tier1
+	rules: map[string][]usageRule{
+		"aws_s3_bucket": {
+			rule("standard.storage_gb", `TimedStorage-ByteHrs$`, unitGB),
+			rule("standard.monthly_tier_1_requests", `-Requests-Tier1$`, unitCount),
+			rule("standard.monthly_tier_2_requests", `-Requests-Tier2$`, unitCount),
+			rule("standard_infrequent_access.storage_gb", `TimedStorage-SIA-ByteHrs$`, unitGB),
+			rule("standard_infrequent_access.monthly_tier_1_requests", `Requests-SIA-Tier1$`, unitCount),
+			rule("standard_infrequent_access.monthly_tier_2_requests", `Requests-SIA-Tier2$`, unitCount),
+			rule("standard_infrequent_access.monthly_data_retrieval_gb", `Retrieval-SIA$`, unitGB),
+			rule("one_zone_infrequent_access.storage_gb", `TimedStorage-ZIA-ByteHrs$`, unitGB),
+			rule("one_zone_infrequent_access.monthly_tier_1_requests", `Requests-ZIA-Tier1$`, unitCount),
+			rule("one_zone_infrequent_access.monthly_tier_2_requests", `Requests-ZIA-Tier2$`, unitCount),
+			rule("one_zone_infrequent_access.monthly_data_retrieval_gb", `Retrieval-ZIA$`, unitGB),
+			rule("glacier.storage_gb", `TimedStorage-GlacierByteHrs$`, unitGB),
+			rule("glacier_deep_archive.storage_gb", `TimedStorage-GDA-ByteHrs$`, unitGB),
+		},
+		"aws_lambda_function": {
+			rule("monthly_requests", `-Request(-ARM)?$`, unitCount),
+		},
+		"aws_dynamodb_table": {
+			rule("monthly_write_request_units", `-WriteRequestUnits$`, unitCount),
+			rule("monthly_read_request_units", `-ReadRequestUnits$`, unitCount),
+			rule("storage_gb", `-TimedStorage-ByteHrs$`, unitGB),
+			rule("pitr_backup_storage_gb", `-TimedPITRStorage-ByteHrs$`, unitGB),
+			rule("on_demand_backup_storage_gb", `-TimedBackupStorage-ByteHrs$`, unitGB),
+		},
+		"aws_nat_gateway": {
+			rule("monthly_data_processed_gb", `-NatGateway-Bytes$`, unitGB),
+		},
+	},
+}
@@ -0,0 +1,79 @@
+package billing
+
+import (
+	"encoding/json"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// azureCostDetails is the format of the Azure cost details CSV file, which is
+// exported from Cost Management or downloaded from the usage and charges of the
+// billing account.
+var azureCostDetails = &format{
+	cloud: "azure",
+	detect: func(cols columns) bool {
+		return cols.has("MeterName") && cols.has("Quantity")
+	},
+	parse: func(r row) (LineItem, bool) {
+		quantity, err := strconv.ParseFloat(r.get("Quantity"), 64)
+		if err != nil {
+			return LineItem{}, false
+		}
+
+		// The line items are daily
+		start := parseTime(r.get("Date", "UsageDate"), "01/02/2006", "2006-01-02", time.RFC3339)
+		end := start
+		if !start.IsZero() {
+			end = start.Add(24 * time.Hour)
+		}
+
+		return LineItem{
+			ResourceID: r.get("ResourceId", "InstanceId", "InstanceName"),
+			Tags:       azureTags(r.get("Tags")),
+			UsageType:  r.get("MeterName"),
+			Quantity:   quantity,
+			Unit:       r.get("UnitOfMeasure"),
+			Start:      start,
+			End:        end,
+		}, true
+	},
+	rules: map[string][]usageRule{
+		"azurerm_storage_account": {
+			// Only one of these is in the usage file depending on the account kind
+			rule("storage_gb", `Data Stored$`, unitGB),
+			rule("data_at_rest_storage_gb", `Data Stored$`, unitGB),
+			rule("monthly_write_operations", `Write Operations$`, unitCount),
+			rule("monthly_list_and_create_container_operations", `List and Create Container Operations$`, unitCount),
+			rule("monthly_read_operations", `Read Operations$`, unitCount),
+			rule("monthly_other_operations", `Other Operations$`, unitCount),
+			rule("monthly_data_retrieval_gb", `Data Retrieval$`, unitGB),
+			rule("monthly_data_write_gb", `Data Write$`, unitGB),
+		},
+		"azurerm_function_app": {
+			rule("monthly_executions", `^Total Executions$`, unitCount),
+		},
+		"azurerm_application_gateway": {
+			rule("monthly_data_processed_gb", `Data Processed$`, unitGB),
+			rule("monthly_v2_capacity_units", `^Capacity Units$`, unitCount),
+		},
+	},
+}
+
+// azureTags parses the tags of a line item. The tags are a JSON object, but
+// some exports omit the braces.
+func azureTags(s string) map[string]string {
+	tags := make(map[string]string)
+
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return tags
+	}
+	if !strings.HasPrefix(s, "{") {
+		s = "{" + s + "}"
+	}
+
+	_ = json.Unmarshal([]byte(s), &tags)
+
+	return tags
+}
@@ -0,0 +1,212 @@
+package billing
+
+import (
+	"compress/gzip"
+	"encoding/csv"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+const timeMonth = time.Hour * 24 * 30
+
+// LineItem is a usage line item of a billing export.
+type LineItem struct {
+	// ResourceID is the ID of the resource in the cloud, e.g. an ARN for AWS,
+	// the resource name for GCP or the resource ID for Azure.
+	ResourceID string
+	Tags       map[string]string
+	// UsageType identifies what was used, e.g. the usage type for AWS, the SKU
+	// description for GCP or the meter name for Azure.
+	UsageType string
+	Quantity  float64
+	Unit      string
+	Start     time.Time
+	End       time.Time
+}
+
+// Export is a billing export that's used to populate the usage of resources
+// from their actual usage.
+type Export struct {
+	format    *format
+	LineItems []LineItem
+}
+
+// Cloud returns the cloud of the billing export, i.e. aws, google or azure.
+func (e *Export) Cloud() string {
+	return e.format.cloud
+}
+
+// months returns the number of months that the line items cover, so the usage
+// can be converted to a monthly usage.
+func (e *Export) months() float64 {
+	var start, end time.Time
+
+	for _, li := range e.LineItems {
+		if !li.Start.IsZero() && (start.IsZero() || li.Start.Before(start)) {
+			start = li.Start
+		}
+		if li.End.After(end) {
+			end = li.End
+		}
+	}
+
+	if start.IsZero() || !end.After(start) {
+		return 1
+	}
+
+	return float64(end.Sub(start)) / float64(timeMonth)
+}
+
+// LoadExport reads a billing export CSV file, which can be gzipped. The format is
+// detected from the columns, and can be an AWS Cost and Usage Report, a GCP
+// billing export or an Azure cost details export.
+func LoadExport(path string) (*Export, error) {
+	if strings.HasSuffix(path, ".parquet") {
+		return nil, errors.New("Parquet billing exports are not supported, export the AWS Cost and Usage Report as a CSV file instead")
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var r io.Reader = f
+	if strings.HasSuffix(path, ".gz") {
+		gz, err := gzip.NewReader(f)
+		if err != nil {
+			return nil, errors.Wrap(err, "Error reading gzipped billing export")
+		}
+		defer gz.Close()
+		r = gz
+	}
+
+	return readExport(r)
+}
+
+func readExport(r io.Reader) (*Export, error) {
+	cr := csv.NewReader(r)
+	cr.FieldsPerRecord = -1
+
+	header, err := cr.Read()
+	if err != nil {
+		return nil, errors.Wrap(err, "Error reading billing export header")
+	}
+
+	cols := newColumns(header)
+
+	var f *format
+	for _, candidate := range formats {
+		if candidate.detect(cols) {
+			f = candidate
+			break
+		}
+	}
+	if f == nil {
+		return nil, errors.New("Unknown billing export format, expected an AWS Cost and Usage Report, GCP billing export or Azure cost details CSV file")
+	}
+
+	export := &Export{format: f}
+
+	for {
+		record, err := cr.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, errors.Wrap(err, "Error reading billing export")
+		}
+
+		li, ok := f.parse(row{cols: cols, values: record})
+		if ok {
+			export.LineItems = append(export.LineItems, li)
+		}
+	}
+
+	return export, nil
+}
+
+// format is a billing export format of a cloud.
+type format struct {
+	cloud  string
+	detect func(cols columns) bool
+	// parse returns the line item of the row, or false if the row isn't a usage
+	// line item, e.g. for taxes and credits
+	parse func(r row) (LineItem, bool)
+	// rules are the rules for populating the usage keys of each resource type
+	rules map[string][]usageRule
+}
+
+var formats = []*format{awsCUR, googleExport, azureCostDetails}
+
+// columns are the indexes of the columns by their lower case names.
+type columns map[string]int
+
+func newColumns(header []string) columns {
+	cols := make(columns, len(header))
+	for i, h := range header {
+		// Strip the byte order mark that Excel and the Azure portal add
+		h = strings.TrimPrefix(h, "\ufeff")
+		cols[strings.ToLower(strings.TrimSpace(h))] = i
+	}
+
+	return cols
+}
+
+// has returns true if any of the columns exist.
+func (c columns) has(names ...string) bool {
+	for _, n := range names {
+		if _, ok := c[strings.ToLower(n)]; ok {
+			return true
+		}
+	}
+
+	return false
+}
+
+type row struct {
+	cols   columns
+	values []string
+}
+
+// get returns the value of the first of the columns that exists.
+func (r row) get(names ...string) string {
+	for _, n := range names {
+		if i, ok := r.cols[strings.ToLower(n)]; ok && i < len(r.values) {
+			return strings.TrimSpace(r.values[i])
+		}
+	}
+
+	return ""
+}
+
+// prefixed returns the values of the columns with the prefix, keyed by the
+// rest of the column name.
+func (r row) prefixed(prefix string) map[string]string {
+	m := make(map[string]string)
+
+	for name, i := range r.cols {
+		if !strings.HasPrefix(name, prefix) || i >= len(r.values) || r.values[i] == "" {
+			continue
+		}
+		m[strings.TrimPrefix(name, prefix)] = r.values[i]
+	}
+
+	return m
+}
+
+// parseTime parses a time in any of the layouts, returning the zero time if it
+// can't be parsed.
+func parseTime(s string, layouts ...string) time.Time {
+	for _, l := range layouts {
+		if t, err := time.Parse(l, s); err == nil {
+			return t
+		}
+	}
+
+	return time.Time{}
+}
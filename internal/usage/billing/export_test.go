@@ -0,0 +1,163 @@
+package billing
+
+import (
+	"bytes"
+	"compress/gzip"
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/infracost/infracost/internal/schema"
+)
+
+func TestLoadExportAWS(t *testing.T) {
+	export, err := LoadExport("testdata/aws_cur.csv")
+	require.NoError(t, err)
+
+	assert.Equal(t, "aws", export.Cloud())
+	// The tax line item is skipped
+	assert.Len(t, export.LineItems, 11)
+
+	resources := []*schema.Resource{
+		{Name: "aws_s3_bucket.bucket", ResourceType: "aws_s3_bucket", CloudResourceIDs: []string{"my-bucket", "arn:aws:s3:::my-bucket"}},
+		{Name: "aws_lambda_function.function", ResourceType: "aws_lambda_function", CloudResourceIDs: []string{"my-function"}},
+		{Name: "aws_nat_gateway.nat", ResourceType: "aws_nat_gateway", CloudResourceIDs: []string{"nat-0123456789abcdef0"}},
+		{Name: "aws_dynamodb_table.orders", ResourceType: "aws_dynamodb_table", Tags: map[string]string{"Name": "orders"}},
+		{Name: "aws_dynamodb_table.users", ResourceType: "aws_dynamodb_table", Tags: map[string]string{"Name": "users"}},
+	}
+
+	usages := export.ResourceUsages(resources)
+
+	// The line items cover 2 months
+	assertUsages(t, map[string]map[string]float64{
+		"aws_s3_bucket.bucket": {
+			"standard.storage_gb":                   60.25,
+			"standard.monthly_tier_1_requests":      10000,
+			"standard.monthly_tier_2_requests":      150000,
+			"standard_infrequent_access.storage_gb": 20,
+		},
+		"aws_lambda_function.function": {
+			"monthly_requests": 1000000,
+		},
+		"aws_nat_gateway.nat": {
+			"monthly_data_processed_gb": 125.125,
+		},
+		"aws_dynamodb_table.orders": {
+			"monthly_write_request_units": 500000,
+			"monthly_read_request_units":  2000000,
+		},
+	}, usages)
+}
+
+func TestLoadExportGoogle(t *testing.T) {
+	export, err := LoadExport("testdata/google_billing_export.csv")
+	require.NoError(t, err)
+
+	assert.Equal(t, "google", export.Cloud())
+
+	resources := []*schema.Resource{
+		{Name: "google_storage_bucket.bucket", ResourceType: "google_storage_bucket", CloudResourceIDs: []string{"my-bucket", "https://www.googleapis.com/storage/v1/b/my-bucket"}},
+		{Name: "google_pubsub_topic.topic", ResourceType: "google_pubsub_topic", Tags: map[string]string{"app": "events"}},
+		{Name: "google_bigquery_dataset.dataset", ResourceType: "google_bigquery_dataset", CloudResourceIDs: []string{"projects/my-project/datasets/my_dataset"}},
+	}
+
+	usages := export.ResourceUsages(resources)
+
+	assertUsages(t, map[string]map[string]float64{
+		"google_storage_bucket.bucket": {
+			"storage_gb":                 400.5,
+			"monthly_class_a_operations": 25000,
+			"monthly_class_b_operations": 125000,
+		},
+		"google_pubsub_topic.topic": {
+			"monthly_message_data_tb": 2,
+		},
+	}, usages)
+}
+
+func TestLoadExportAzure(t *testing.T) {
+	export, err := LoadExport("testdata/azure_cost_details.csv")
+	require.NoError(t, err)
+
+	assert.Equal(t, "azure", export.Cloud())
+
+	resources := []*schema.Resource{
+		{Name: "azurerm_storage_account.account", ResourceType: "azurerm_storage_account", CloudResourceIDs: []string{"/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/my-rg/providers/Microsoft.Storage/storageAccounts/mystorageaccount"}},
+		{Name: "azurerm_function_app.api", ResourceType: "azurerm_function_app", Tags: map[string]string{"app": "api"}},
+	}
+
+	usages := export.ResourceUsages(resources)
+
+	assertUsages(t, map[string]map[string]float64{
+		"azurerm_storage_account.account": {
+			"storage_gb":               1.5,
+			"data_at_rest_storage_gb":  1.5,
+			"monthly_write_operations": 5000,
+			"monthly_read_operations":  20000,
+		},
+		"azurerm_function_app.api": {
+			"monthly_executions": 15000000,
+		},
+	}, usages)
+}
+
+func TestLoadExportGzip(t *testing.T) {
+	data, err := ioutil.ReadFile("testdata/aws_cur.csv")
+	require.NoError(t, err)
+
+	var buf bytes.Buffer
+	gz := gzip.NewWriter(&buf)
+	_, err = gz.Write(data)
+	require.NoError(t, err)
+	require.NoError(t, gz.Close())
+
+	path := filepath.Join(t.TempDir(), "cur.csv.gz")
+	require.NoError(t, ioutil.WriteFile(path, buf.Bytes(), 0600))
+
+	export, err := LoadExport(path)
+	require.NoError(t, err)
+	assert.Len(t, export.LineItems, 11)
+}
+
+func TestLoadExportErrors(t *testing.T) {
+	_, err := LoadExport("testdata/cur.parquet")
+	assert.EqualError(t, err, "Parquet billing exports are not supported, export the AWS Cost and Usage Report as a CSV file instead")
+
+	path := filepath.Join(t.TempDir(), "unknown.csv")
+	require.NoError(t, ioutil.WriteFile(path, []byte("a,b\n1,2\n"), 0600))
+
+	_, err = LoadExport(path)
+	assert.EqualError(t, err, "Unknown billing export format, expected an AWS Cost and Usage Report, GCP billing export or Azure cost details CSV file")
+}
+
+func TestResourceUsagesAmbiguousIDs(t *testing.T) {
+	export, err := LoadExport("testdata/aws_cur.csv")
+	require.NoError(t, err)
+
+	// Both functions are named my-function, e.g. in different regions, so the line
+	// items can't be matched by the name
+	resources := []*schema.Resource{
+		{Name: "aws_lambda_function.east", ResourceType: "aws_lambda_function", CloudResourceIDs: []string{"my-function"}},
+		{Name: "aws_lambda_function.west", ResourceType: "aws_lambda_function", CloudResourceIDs: []string{"my-function"}},
+	}
+
+	assert.Empty(t, export.ResourceUsages(resources))
+}
+
+func assertUsages(t *testing.T, expected map[string]map[string]float64, actual map[string]map[string]float64) {
+	t.Helper()
+
+	require.Len(t, actual, len(expected))
+
+	for name, values := range expected {
+		require.Contains(t, actual, name)
+		require.Len(t, actual[name], len(values), name)
+
+		for k, v := range values {
+			assert.InDelta(t, v, actual[name][k], 0.0001, "%s %s", name, k)
+		}
+	}
+}
@@ -0,0 +1,88 @@
+package billing
+
+import (
+	"encoding/json"
+	"strconv"
+	"time"
+)
+
+const googleTimeLayout = "2006-01-02 15:04:05 MST"
+
+// googleExport is the format of the Cloud Billing export to BigQuery, exported
+// from BigQuery to CSV. Resources are only identified by name in the detailed
+// usage cost export, otherwise they're matched by their labels.
+var googleExport = &format{
+	cloud: "google",
+	detect: func(cols columns) bool {
+		return cols.has("sku.description", "sku_description")
+	},
+	parse: func(r row) (LineItem, bool) {
+		// Prefer the amount in pricing units, since the usage amount of storage is
+		// in byte-seconds
+		amount, unit := r.get("usage.amount_in_pricing_units", "usage_amount_in_pricing_units"), r.get("usage.pricing_unit", "usage_pricing_unit")
+		if amount == "" {
+			amount, unit = r.get("usage.amount", "usage_amount"), r.get("usage.unit", "usage_unit")
+		}
+
+		quantity, err := strconv.ParseFloat(amount, 64)
+		if err != nil {
+			return LineItem{}, false
+		}
+
+		resourceID := r.get("resource.name", "resource_name")
+		if resourceID == "" {
+			resourceID = r.get("resource.global_name", "resource_global_name")
+		}
+
+		return LineItem{
+			ResourceID: resourceID,
+			Tags:       googleLabels(r.get("labels")),
+			UsageType:  r.get("sku.description", "sku_description"),
+			Quantity:   quantity,
+			Unit:       unit,
+			Start:      parseTime(r.get("usage_start_time"), googleTimeLayout, time.RFC3339),
+			End:        parseTime(r.get("usage_end_time"), googleTimeLayout, time.RFC3339),
+		}, true
+	},
+	rules: map[string][]usageRule{
+		"google_storage_bucket": {
+			ruleExcluding("storage_gb", `Storage`, `Early Delete|Operations|Retrieval|Egress|Replication`, unitGB),
+			rule("monthly_class_a_operations", `Class A Operations`, unitCount),
+			rule("monthly_class_b_operations", `Class B Operations`, unitCount),
+			rule("monthly_data_retrieval_gb", `Data Retrieval`, unitGB),
+		},
+		"google_cloudfunctions_function": {
+			rule("monthly_function_invocations", `^Invocations`, unitCount),
+			rule("monthly_outbound_data_gb", `Network Egress`, unitGB),
+		},
+		"google_pubsub_topic": {
+			rule("monthly_message_data_tb", `^Message Delivery`, unitTB),
+		},
+		"google_bigquery_dataset": {
+			rule("monthly_queries_tb", `^Analysis`, unitTB),
+		},
+	},
+}
+
+// googleLabels parses the labels of a line item, which are exported as a JSON
+// list of key and value objects.
+func googleLabels(s string) map[string]string {
+	labels := make(map[string]string)
+	if s == "" {
+		return labels
+	}
+
+	var l []struct {
+		Key   string `json:"key"`
+		Value string `json:"value"`
+	}
+	if err := json.Unmarshal([]byte(s), &l); err != nil {
+		return labels
+	}
+
+	for _, label := range l {
+		labels[label.Key] = label.Value
+	}
+
+	return labels
+}
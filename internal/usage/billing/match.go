@@ -0,0 +1,136 @@
+package billing
+
+import (
+	"strings"
+
+	"github.com/infracost/infracost/internal/schema"
+)
+
+// ResourceUsages returns the monthly usage of the resources from the line items
+// of the billing export, keyed by the resource name and the usage key. Line items
+// are matched to resources by the cloud resource IDs, and otherwise by the tags
+// of the resources.
+func (e *Export) ResourceUsages(resources []*schema.Resource) map[string]map[string]float64 {
+	candidates := make([]*schema.Resource, 0, len(resources))
+	for _, r := range resources {
+		if _, ok := e.format.rules[r.ResourceType]; ok {
+			candidates = append(candidates, r)
+		}
+	}
+
+	byID := resourcesByID(candidates)
+	months := e.months()
+
+	usages := make(map[string]map[string]float64)
+
+	for _, li := range e.LineItems {
+		r := matchResource(li, byID, candidates)
+		if r == nil {
+			continue
+		}
+
+		for _, rule := range e.format.rules[r.ResourceType] {
+			if !rule.matches(li.UsageType) {
+				continue
+			}
+
+			quantity, ok := convertQuantity(li.Quantity, li.Unit, rule.unit)
+			if !ok {
+				continue
+			}
+
+			if usages[r.Name] == nil {
+				usages[r.Name] = make(map[string]float64)
+			}
+			usages[r.Name][rule.key] += quantity / months
+		}
+	}
+
+	return usages
+}
+
+// resourcesByID returns the resources by their lower case cloud resource IDs and
+// the last segments of the IDs, e.g. the name of a resource from its ARN. A key
+// that's shared by more than one resource is mapped to nil, since the line items
+// can't be matched to either resource.
+func resourcesByID(resources []*schema.Resource) map[string]*schema.Resource {
+	byID := make(map[string]*schema.Resource)
+
+	add := func(key string, r *schema.Resource) {
+		if key == "" {
+			return
+		}
+		if existing, ok := byID[key]; ok && existing != r {
+			byID[key] = nil
+			return
+		}
+		byID[key] = r
+	}
+
+	for _, r := range resources {
+		for _, id := range r.CloudResourceIDs {
+			id = strings.ToLower(id)
+			add(id, r)
+			add(lastSegment(id), r)
+		}
+	}
+
+	return byID
+}
+
+func matchResource(li LineItem, byID map[string]*schema.Resource, resources []*schema.Resource) *schema.Resource {
+	if li.ResourceID != "" {
+		id := strings.ToLower(li.ResourceID)
+		if r, ok := byID[id]; ok {
+			return r
+		}
+		if r, ok := byID[lastSegment(id)]; ok {
+			return r
+		}
+	}
+
+	if len(li.Tags) == 0 {
+		return nil
+	}
+
+	var match *schema.Resource
+	for _, r := range resources {
+		if !tagsMatch(r.Tags, li.Tags) {
+			continue
+		}
+		if match != nil {
+			// The tags aren't unique to a resource
+			return nil
+		}
+		match = r
+	}
+
+	return match
+}
+
+// tagsMatch returns true if the resource has tags and they're all on the line
+// item. The keys are compared case-insensitively since some exports lower case
+// them, e.g. the column names of AWS Cost and Usage Reports.
+func tagsMatch(resourceTags map[string]string, lineItemTags map[string]string) bool {
+	if len(resourceTags) == 0 {
+		return false
+	}
+
+	lower := make(map[string]string, len(lineItemTags))
+	for k, v := range lineItemTags {
+		lower[strings.ToLower(k)] = v
+	}
+
+	for k, v := range resourceTags {
+		if lower[strings.ToLower(k)] != v {
+			return false
+		}
+	}
+
+	return true
+}
+
+func lastSegment(id string) string {
+	id = strings.TrimRight(id, "/")
+	return id[strings.LastIndexAny(id, "/:")+1:]
+}
@@ -0,0 +1,32 @@
+package billing
+
+import (
+	"regexp"
+)
+
+// usageRule populates a usage key of a resource from the line items with a
+// matching usage type.
+type usageRule struct {
+	// key is the usage key, which is prefixed by the sub-resource key for
+	// sub-resource usage, e.g. standard.storage_gb
+	key       string
+	usageType *regexp.Regexp
+	// exclude skips the usage types that match usageType but are billed
+	// separately, e.g. early deletes of storage
+	exclude *regexp.Regexp
+	unit    string
+}
+
+func (r usageRule) matches(usageType string) bool {
+	return r.usageType.MatchString(usageType) && (r.exclude == nil || !r.exclude.MatchString(usageType))
+}
+
+func rule(key string, usageType string, unit string) usageRule {
+	return usageRule{key: key, usageType: regexp.MustCompile(usageType), unit: unit}
+}
+
+func ruleExcluding(key string, usageType string, exclude string, unit string) usageRule {
+	r := rule(key, usageType, unit)
+	r.exclude = regexp.MustCompile(exclude)
+	return r
+}
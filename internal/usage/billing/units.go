@@ -0,0 +1,78 @@
+package billing
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+const (
+	unitCount = "count"
+	unitGB    = "GB"
+	unitTB    = "TB"
+)
+
+var (
+	unitMultiplierRegex = regexp.MustCompile(`^(\d+(?:\.\d+)?)\s*([km]?)\b\s*(.*)$`)
+	unitWordRegex       = regexp.MustCompile(`[a-z]+`)
+)
+
+// sizeUnits are the sizes of the units in GB. The exports use GB for the binary
+// units, e.g. AWS usage in GB-Mo is in GiB.
+var sizeUnits = map[string]float64{
+	"byte":     1.0 / 1024 / 1024 / 1024,
+	"bytes":    1.0 / 1024 / 1024 / 1024,
+	"kb":       1.0 / 1024 / 1024,
+	"kib":      1.0 / 1024 / 1024,
+	"kibibyte": 1.0 / 1024 / 1024,
+	"mb":       1.0 / 1024,
+	"mib":      1.0 / 1024,
+	"mebibyte": 1.0 / 1024,
+	"gb":       1,
+	"gib":      1,
+	"gibibyte": 1,
+	"tb":       1024,
+	"tib":      1024,
+	"tebibyte": 1024,
+}
+
+// convertQuantity converts the quantity of the line item unit to the target unit.
+// It returns false if the units aren't compatible, e.g. for a count of requests
+// that's converted to GB.
+func convertQuantity(quantity float64, unit string, target string) (float64, bool) {
+	unit = strings.ToLower(strings.TrimSpace(unit))
+
+	// Azure units can have a multiplier, e.g. 10K for operations or 1 GB/Month
+	if m := unitMultiplierRegex.FindStringSubmatch(unit); m != nil {
+		n, _ := strconv.ParseFloat(m[1], 64)
+		switch m[2] {
+		case "k":
+			n *= 1000
+		case "m":
+			n *= 1000 * 1000
+		}
+		quantity *= n
+		unit = m[3]
+	}
+
+	words := unitWordRegex.FindAllString(unit, -1)
+
+	size, isSize := 0.0, false
+	if len(words) > 0 {
+		size, isSize = sizeUnits[words[0]]
+	}
+
+	if isSize && len(words) > 1 && strings.HasPrefix(words[1], "second") {
+		// GCP storage is in byte-seconds, so convert it to a month
+		size /= timeMonth.Seconds()
+	}
+
+	switch target {
+	case unitGB:
+		return quantity * size, isSize
+	case unitTB:
+		return quantity * size / 1024, isSize
+	default:
+		return quantity, !isSize
+	}
+}
@@ -0,0 +1,61 @@
+package google
+
+import (
+	"context"
+
+	log "github.com/sirupsen/logrus"
+)
+
+func cloudFunctionLabels(region string, function string) map[string]string {
+	labels := map[string]string{
+		"resource.labels.function_name": function,
+	}
+	if region != "" {
+		labels["resource.labels.region"] = region
+	}
+
+	return labels
+}
+
+func CloudFunctionsGetExecutions(ctx context.Context, project string, region string, function string) (float64, error) {
+	log.Debugf("Querying Google Cloud Monitoring: cloudfunctions.googleapis.com/function/execution_count(project: %s, region: %s, function: %s)", project, region, function)
+
+	return monitoringGetMonthlyValue(ctx, timeSeriesRequest{
+		project: project,
+		metric:  "cloudfunctions.googleapis.com/function/execution_count",
+		labels:  cloudFunctionLabels(region, function),
+		aligner: alignSum,
+		reducer: reduceSum,
+	})
+}
+
+// CloudFunctionsGetExecutionTimeAvg returns the average execution time in
+// milliseconds.
+func CloudFunctionsGetExecutionTimeAvg(ctx context.Context, project string, region string, function string) (float64, error) {
+	log.Debugf("Querying Google Cloud Monitoring: cloudfunctions.googleapis.com/function/execution_times(project: %s, region: %s, function: %s)", project, region, function)
+
+	ns, err := monitoringGetMonthlyValue(ctx, timeSeriesRequest{
+		project: project,
+		metric:  "cloudfunctions.googleapis.com/function/execution_times",
+		labels:  cloudFunctionLabels(region, function),
+		aligner: alignMean,
+		reducer: reduceMean,
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return ns / 1000 / 1000, nil
+}
+
+func CloudFunctionsGetNetworkEgressBytes(ctx context.Context, project string, region string, function string) (float64, error) {
+	log.Debugf("Querying Google Cloud Monitoring: cloudfunctions.googleapis.com/function/network_egress(project: %s, region: %s, function: %s)", project, region, function)
+
+	return monitoringGetMonthlyValue(ctx, timeSeriesRequest{
+		project: project,
+		metric:  "cloudfunctions.googleapis.com/function/network_egress",
+		labels:  cloudFunctionLabels(region, function),
+		aligner: alignSum,
+		reducer: reduceSum,
+	})
+}
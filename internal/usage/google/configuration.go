@@ -0,0 +1,83 @@
+package google
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"strings"
+	"sync"
+
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+)
+
+const defaultMonitoringEndpoint = "https://monitoring.googleapis.com"
+
+// projectEnvVars are the environment variables that the Terraform Google
+// provider reads the project from.
+var projectEnvVars = []string{
+	"GOOGLE_PROJECT",
+	"GOOGLE_CLOUD_PROJECT",
+	"GCLOUD_PROJECT",
+	"CLOUDSDK_CORE_PROJECT",
+}
+
+var (
+	gcloudToken     string
+	gcloudTokenErr  error
+	gcloudTokenOnce sync.Once
+)
+
+type ctxConfigKeyType struct{}
+
+var ctxConfigKey = &ctxConfigKeyType{}
+
+type config struct {
+	endpoint string
+	token    string
+}
+
+// getConfig returns the endpoint and access token for the Cloud Monitoring API.
+// The token is read from GOOGLE_OAUTH_ACCESS_TOKEN, as it is by the Terraform
+// Google provider, or from the gcloud CLI.
+func getConfig(ctx context.Context) (config, error) {
+	if cfg, ok := ctx.Value(ctxConfigKey).(config); ok {
+		return cfg, nil
+	}
+
+	cfg := config{endpoint: defaultMonitoringEndpoint}
+
+	if token := os.Getenv("GOOGLE_OAUTH_ACCESS_TOKEN"); token != "" {
+		cfg.token = token
+		return cfg, nil
+	}
+
+	gcloudTokenOnce.Do(func() {
+		log.Debugf("Getting Google Cloud access token from gcloud")
+		out, err := exec.Command("gcloud", "auth", "print-access-token").Output()
+		if err != nil {
+			gcloudTokenErr = errors.Wrap(err, "Could not get a Google Cloud access token, set GOOGLE_OAUTH_ACCESS_TOKEN or log in with gcloud")
+			return
+		}
+		gcloudToken = strings.TrimSpace(string(out))
+	})
+
+	cfg.token = gcloudToken
+	return cfg, gcloudTokenErr
+}
+
+// projectID returns the project of the resource, or the project from the
+// environment if the resource doesn't set it.
+func projectID(project string) (string, error) {
+	if project != "" {
+		return project, nil
+	}
+
+	for _, k := range projectEnvVars {
+		if v := os.Getenv(k); v != "" {
+			return v, nil
+		}
+	}
+
+	return "", errors.New("Could not find the Google Cloud project, set the project of the resource or GOOGLE_PROJECT")
+}
@@ -0,0 +1,202 @@
+package google
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	alignSum   = "ALIGN_SUM"
+	alignMean  = "ALIGN_MEAN"
+	reduceSum  = "REDUCE_SUM"
+	reduceMean = "REDUCE_MEAN"
+	// alignPeriod is timeMonth, so the interval is a single alignment period
+	alignPeriod = "2592000s"
+)
+
+type timeSeriesRequest struct {
+	project string
+	metric  string
+	// labels filters the time series by their resource or metric labels, e.g.
+	// resource.labels.bucket_name
+	labels  map[string]string
+	aligner string
+	reducer string
+	groupBy []string
+}
+
+type timeSeries struct {
+	Metric struct {
+		Labels map[string]string `json:"labels"`
+	} `json:"metric"`
+	Points []point `json:"points"`
+}
+
+type point struct {
+	Value struct {
+		Int64Value        *string  `json:"int64Value"`
+		DoubleValue       *float64 `json:"doubleValue"`
+		DistributionValue *struct {
+			Mean float64 `json:"mean"`
+		} `json:"distributionValue"`
+	} `json:"value"`
+}
+
+func (p point) value() float64 {
+	switch {
+	case p.Value.Int64Value != nil:
+		v, _ := strconv.ParseFloat(*p.Value.Int64Value, 64)
+		return v
+	case p.Value.DoubleValue != nil:
+		return *p.Value.DoubleValue
+	case p.Value.DistributionValue != nil:
+		return p.Value.DistributionValue.Mean
+	}
+
+	return 0
+}
+
+// value returns the value of the time series over the month. The interval can
+// still span two alignment periods, so the points are added up for ALIGN_SUM,
+// but only the latest point is used for ALIGN_MEAN since adding up averages of a
+// gauge would double it.
+func (s timeSeries) value(aligner string) float64 {
+	if len(s.Points) == 0 {
+		return 0
+	}
+
+	// The points are returned in reverse time order
+	if aligner == alignMean {
+		return s.Points[0].value()
+	}
+
+	total := 0.0
+	for _, p := range s.Points {
+		total += p.value()
+	}
+
+	return total
+}
+
+// monitoringListTimeSeries returns the time series of the metric over the last
+// month, aligned to a single point.
+func monitoringListTimeSeries(ctx context.Context, req timeSeriesRequest) ([]timeSeries, error) {
+	cfg, err := getConfig(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	project, err := projectID(req.project)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now().UTC()
+
+	params := url.Values{}
+	params.Set("filter", monitoringFilter(req))
+	params.Set("interval.startTime", now.Add(-timeMonth).Format(time.RFC3339))
+	params.Set("interval.endTime", now.Format(time.RFC3339))
+	params.Set("aggregation.alignmentPeriod", alignPeriod)
+	params.Set("aggregation.perSeriesAligner", req.aligner)
+	params.Set("aggregation.crossSeriesReducer", req.reducer)
+	for _, f := range req.groupBy {
+		params.Add("aggregation.groupByFields", f)
+	}
+
+	var series []timeSeries
+
+	for {
+		u := fmt.Sprintf("%s/v3/projects/%s/timeSeries?%s", strings.TrimSuffix(cfg.endpoint, "/"), url.PathEscape(project), params.Encode())
+
+		var resp struct {
+			TimeSeries    []timeSeries `json:"timeSeries"`
+			NextPageToken string       `json:"nextPageToken"`
+		}
+		if err := monitoringGet(ctx, u, cfg.token, &resp); err != nil {
+			return nil, err
+		}
+
+		series = append(series, resp.TimeSeries...)
+
+		if resp.NextPageToken == "" {
+			return series, nil
+		}
+		params.Set("pageToken", resp.NextPageToken)
+	}
+}
+
+// monitoringGetMonthlyValue returns the value of the metric over the last month,
+// reduced to a single time series.
+func monitoringGetMonthlyValue(ctx context.Context, req timeSeriesRequest) (float64, error) {
+	series, err := monitoringListTimeSeries(ctx, req)
+	if err != nil {
+		return 0, err
+	}
+
+	total := 0.0
+	for _, s := range series {
+		total += s.value(req.aligner)
+	}
+
+	return total, nil
+}
+
+func monitoringFilter(req timeSeriesRequest) string {
+	filters := []string{fmt.Sprintf("metric.type = %q", req.metric)}
+
+	keys := make([]string, 0, len(req.labels))
+	for k := range req.labels {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	for _, k := range keys {
+		filters = append(filters, fmt.Sprintf("%s = %q", k, req.labels[k]))
+	}
+
+	return strings.Join(filters, " AND ")
+}
+
+func monitoringGet(ctx context.Context, u string, token string, v interface{}) error {
+	req, err := http.NewRequestWithContext(ctx, "GET", u, nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Add("Authorization", fmt.Sprintf("Bearer %s", token))
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		var apiErr struct {
+			Error struct {
+				Message string `json:"message"`
+			} `json:"error"`
+		}
+		if json.Unmarshal(body, &apiErr) == nil && apiErr.Error.Message != "" {
+			return errors.Errorf("Cloud Monitoring API error: %s", apiErr.Error.Message)
+		}
+		return errors.Errorf("Invalid response from Cloud Monitoring API: %s", resp.Status)
+	}
+
+	return json.Unmarshal(body, v)
+}
@@ -0,0 +1,162 @@
+package google
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type stubbedResponse struct {
+	metric    string
+	pageToken string
+	status    int
+	body      string
+}
+
+type stubbedMonitoring struct {
+	t         *testing.T
+	server    *httptest.Server
+	ctx       context.Context
+	responses []stubbedResponse
+	queries   []url.Values
+}
+
+func (sm *stubbedMonitoring) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Header.Get("Authorization") != "Bearer test-token" {
+		sm.t.Errorf("Expected stubbed Cloud Monitoring call to have the test token, got %q", r.Header.Get("Authorization"))
+	}
+
+	if r.URL.Path != "/v3/projects/my-project/timeSeries" {
+		sm.t.Fatalf("Received unexpected stubbed Cloud Monitoring call: %s %s", r.Method, r.URL)
+	}
+
+	q := r.URL.Query()
+	sm.queries = append(sm.queries, q)
+
+	for _, resp := range sm.responses {
+		if strings.Contains(q.Get("filter"), resp.metric) && resp.pageToken == q.Get("pageToken") {
+			w.WriteHeader(resp.status)
+			_, err := w.Write([]byte(resp.body))
+			if err != nil {
+				sm.t.Fatalf("Cannot write stubbed HTTP response: %s", err)
+			}
+			return
+		}
+	}
+	sm.t.Fatalf("Received unexpected stubbed Cloud Monitoring call: %s %s", r.Method, r.URL)
+}
+
+// When stubs the response for the page of the time series of the metric.
+func (sm *stubbedMonitoring) When(metric string, pageToken string, status int, body string) {
+	sm.responses = append(sm.responses, stubbedResponse{
+		metric:    metric,
+		pageToken: pageToken,
+		status:    status,
+		body:      body,
+	})
+}
+
+func stubMonitoring(t *testing.T) *stubbedMonitoring {
+	stub := &stubbedMonitoring{t: t}
+	stub.server = httptest.NewServer(stub)
+	stub.ctx = WithTestEndpoint(context.TODO(), stub.server.URL)
+	t.Cleanup(stub.server.Close)
+	return stub
+}
+
+func TestMonitoringGetMonthlyValuePagination(t *testing.T) {
+	stub := stubMonitoring(t)
+
+	stub.When("cloudfunctions.googleapis.com/function/execution_count", "", 200, `{
+		"timeSeries": [{"points": [{"value": {"int64Value": "1000000"}}]}],
+		"nextPageToken": "page-2"
+	}`)
+	stub.When("cloudfunctions.googleapis.com/function/execution_count", "page-2", 200, `{
+		"timeSeries": [{"points": [{"value": {"int64Value": "234"}}]}]
+	}`)
+
+	v, err := CloudFunctionsGetExecutions(stub.ctx, "my-project", "us-central1", "my-function")
+	require.NoError(t, err)
+	assert.Equal(t, 1000234.0, v)
+
+	require.Len(t, stub.queries, 2)
+	assert.Equal(t, `metric.type = "cloudfunctions.googleapis.com/function/execution_count" AND resource.labels.function_name = "my-function" AND resource.labels.region = "us-central1"`, stub.queries[0].Get("filter"))
+	assert.Equal(t, alignPeriod, stub.queries[0].Get("aggregation.alignmentPeriod"))
+	assert.Equal(t, alignSum, stub.queries[0].Get("aggregation.perSeriesAligner"))
+	assert.Equal(t, reduceSum, stub.queries[0].Get("aggregation.crossSeriesReducer"))
+	assert.Equal(t, "page-2", stub.queries[1].Get("pageToken"))
+}
+
+func TestMonitoringGetMonthlyValueAggregation(t *testing.T) {
+	stub := stubMonitoring(t)
+
+	// The interval spans two alignment periods, with the latest point first
+	stub.When("pubsub.googleapis.com/topic/byte_cost", "", 200, `{
+		"timeSeries": [{"points": [{"value": {"int64Value": "300"}}, {"value": {"int64Value": "700"}}]}]
+	}`)
+	stub.When("storage.googleapis.com/storage/total_bytes", "", 200, `{
+		"timeSeries": [{"points": [{"value": {"doubleValue": 2048}}, {"value": {"doubleValue": 1024}}]}]
+	}`)
+	stub.When("cloudfunctions.googleapis.com/function/execution_times", "", 200, `{
+		"timeSeries": [{"points": [{"value": {"distributionValue": {"count": "10", "mean": 312600000}}}, {"value": {"distributionValue": {"count": "5", "mean": 100000000}}}]}]
+	}`)
+
+	bytes, err := PubSubGetTopicBytes(stub.ctx, "my-project", "my-topic")
+	require.NoError(t, err)
+	assert.Equal(t, 1000.0, bytes)
+
+	size, err := StorageGetBucketSizeBytes(stub.ctx, "my-project", "my-bucket")
+	require.NoError(t, err)
+	assert.Equal(t, 2048.0, size)
+
+	ms, err := CloudFunctionsGetExecutionTimeAvg(stub.ctx, "my-project", "", "my-function")
+	require.NoError(t, err)
+	assert.Equal(t, 312.6, ms)
+
+	require.Len(t, stub.queries, 3)
+	assert.Equal(t, alignMean, stub.queries[1].Get("aggregation.perSeriesAligner"))
+	assert.Equal(t, `metric.type = "cloudfunctions.googleapis.com/function/execution_times" AND resource.labels.function_name = "my-function"`, stub.queries[2].Get("filter"))
+	assert.Equal(t, reduceMean, stub.queries[2].Get("aggregation.crossSeriesReducer"))
+}
+
+func TestMonitoringGetMonthlyValueError(t *testing.T) {
+	stub := stubMonitoring(t)
+
+	stub.When("storage.googleapis.com/storage/total_bytes", "", 403, `{
+		"error": {"code": 403, "message": "Permission monitoring.timeSeries.list denied", "status": "PERMISSION_DENIED"}
+	}`)
+	stub.When("pubsub.googleapis.com/topic/byte_cost", "", 500, `Internal error`)
+
+	_, err := StorageGetBucketSizeBytes(stub.ctx, "my-project", "my-bucket")
+	assert.EqualError(t, err, "Cloud Monitoring API error: Permission monitoring.timeSeries.list denied")
+
+	_, err = PubSubGetTopicBytes(stub.ctx, "my-project", "my-topic")
+	assert.EqualError(t, err, "Invalid response from Cloud Monitoring API: 500 Internal Server Error")
+}
+
+func TestStorageGetBucketOperations(t *testing.T) {
+	stub := stubMonitoring(t)
+
+	stub.When("storage.googleapis.com/api/request_count", "", 200, `{
+		"timeSeries": [
+			{"metric": {"labels": {"method": "WriteObject"}}, "points": [{"value": {"int64Value": "30000"}}]},
+			{"metric": {"labels": {"method": "ListObjects"}}, "points": [{"value": {"int64Value": "10000"}}]},
+			{"metric": {"labels": {"method": "ReadObject"}}, "points": [{"value": {"int64Value": "15000"}}]},
+			{"metric": {"labels": {"method": "GetObjectMetadata"}}, "points": [{"value": {"int64Value": "5000"}}]},
+			{"metric": {"labels": {"method": "DeleteObject"}}, "points": [{"value": {"int64Value": "700"}}]}
+		]
+	}`)
+
+	ops, err := StorageGetBucketOperations(stub.ctx, "my-project", "my-bucket")
+	require.NoError(t, err)
+	assert.Equal(t, StorageOperations{ClassA: 40000, ClassB: 20000}, ops)
+
+	require.Len(t, stub.queries, 1)
+	assert.Equal(t, []string{"metric.label.method"}, stub.queries[0]["aggregation.groupByFields"])
+}
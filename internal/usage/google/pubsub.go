@@ -0,0 +1,22 @@
+package google
+
+import (
+	"context"
+
+	log "github.com/sirupsen/logrus"
+)
+
+// PubSubGetTopicBytes returns the billable bytes published to the topic.
+func PubSubGetTopicBytes(ctx context.Context, project string, topic string) (float64, error) {
+	log.Debugf("Querying Google Cloud Monitoring: pubsub.googleapis.com/topic/byte_cost(project: %s, topic: %s)", project, topic)
+
+	return monitoringGetMonthlyValue(ctx, timeSeriesRequest{
+		project: project,
+		metric:  "pubsub.googleapis.com/topic/byte_cost",
+		labels: map[string]string{
+			"resource.labels.topic_id": topic,
+		},
+		aligner: alignSum,
+		reducer: reduceSum,
+	})
+}
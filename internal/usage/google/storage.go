@@ -0,0 +1,75 @@
+package google
+
+import (
+	"context"
+	"strings"
+
+	log "github.com/sirupsen/logrus"
+)
+
+// StorageOperations is the number of requests to a bucket by operation class.
+type StorageOperations struct {
+	ClassA float64
+	ClassB float64
+}
+
+func StorageGetBucketSizeBytes(ctx context.Context, project string, bucket string) (float64, error) {
+	log.Debugf("Querying Google Cloud Monitoring: storage.googleapis.com/storage/total_bytes(project: %s, bucket: %s)", project, bucket)
+
+	return monitoringGetMonthlyValue(ctx, timeSeriesRequest{
+		project: project,
+		metric:  "storage.googleapis.com/storage/total_bytes",
+		labels: map[string]string{
+			"resource.labels.bucket_name": bucket,
+		},
+		aligner: alignMean,
+		reducer: reduceSum,
+	})
+}
+
+func StorageGetBucketOperations(ctx context.Context, project string, bucket string) (StorageOperations, error) {
+	log.Debugf("Querying Google Cloud Monitoring: storage.googleapis.com/api/request_count(project: %s, bucket: %s)", project, bucket)
+
+	var ops StorageOperations
+
+	series, err := monitoringListTimeSeries(ctx, timeSeriesRequest{
+		project: project,
+		metric:  "storage.googleapis.com/api/request_count",
+		labels: map[string]string{
+			"resource.labels.bucket_name": bucket,
+		},
+		aligner: alignSum,
+		reducer: reduceSum,
+		groupBy: []string{"metric.label.method"},
+	})
+	if err != nil {
+		return ops, err
+	}
+
+	for _, s := range series {
+		switch storageOperationClass(s.Metric.Labels["method"]) {
+		case "A":
+			ops.ClassA += s.value(alignSum)
+		case "B":
+			ops.ClassB += s.value(alignSum)
+		}
+	}
+
+	return ops, nil
+}
+
+// storageOperationClass returns the pricing class of the JSON API method, e.g.
+// WriteObject. Reads and gets are class B, deletes are free and the other
+// methods, e.g. inserts, lists and updates, are class A.
+func storageOperationClass(method string) string {
+	switch {
+	case method == "":
+		return ""
+	case strings.HasPrefix(method, "Delete"):
+		return ""
+	case strings.HasPrefix(method, "Get"), strings.HasPrefix(method, "Read"):
+		return "B"
+	default:
+		return "A"
+	}
+}
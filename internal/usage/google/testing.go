@@ -0,0 +1,14 @@
+package google
+
+import (
+	"context"
+)
+
+// WithTestEndpoint returns a context that sends Cloud Monitoring requests to the
+// URL with a test access token.
+func WithTestEndpoint(ctx context.Context, url string) context.Context {
+	return context.WithValue(ctx, ctxConfigKey, config{
+		endpoint: url,
+		token:    "test-token",
+	})
+}
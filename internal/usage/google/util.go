@@ -0,0 +1,7 @@
+package google
+
+import (
+	"time"
+)
+
+const timeMonth = time.Hour * 24 * 30
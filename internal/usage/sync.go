@@ -3,18 +3,27 @@ package usage
 import (
 	"context"
 	"fmt"
+	"math"
 	"sort"
 	"strings"
 
 	"github.com/infracost/infracost/internal/schema"
+	"github.com/infracost/infracost/internal/usage/billing"
 	log "github.com/sirupsen/logrus"
 	"github.com/tidwall/gjson"
 )
 
 type SyncResult struct {
-	ResourceCount    int
-	EstimationCount  int
-	EstimationErrors map[string]error
+	ResourceCount      int
+	EstimationCount    int
+	EstimationErrors   map[string]error
+	BillingExportCount int
+}
+
+type SyncUsageDataOpts struct {
+	// BillingExport is used to populate the usage of the resources that have
+	// line items in it, overriding the estimated usage
+	BillingExport *billing.Export
 }
 
 type ReplaceResourceUsagesOpts struct {
@@ -27,6 +36,7 @@ func (s *SyncResult) ProjectContext() map[string]interface{} {
 	r["usageSyncs"] = s.ResourceCount
 	r["usageEstimates"] = s.EstimationCount
 	r["usageEstimateErrors"] = len(s.EstimationErrors)
+	r["usageBillingExportMatches"] = s.BillingExportCount
 
 	var remediable, remAttempts, remErrors int
 	for _, err := range s.EstimationErrors {
@@ -42,7 +52,7 @@ func (s *SyncResult) ProjectContext() map[string]interface{} {
 	return r
 }
 
-func SyncUsageData(usageFile *UsageFile, projects []*schema.Project) (*SyncResult, error) {
+func SyncUsageData(usageFile *UsageFile, projects []*schema.Project, opts SyncUsageDataOpts) (*SyncResult, error) {
 	referenceFile, err := LoadReferenceFile()
 	if err != nil {
 		return nil, err
@@ -55,12 +65,17 @@ func SyncUsageData(usageFile *UsageFile, projects []*schema.Project) (*SyncResul
 		resources = append(resources, project.Resources...)
 	}
 
-	syncResult := syncResourceUsages(usageFile, resources, referenceFile)
+	var billingUsages map[string]map[string]float64
+	if opts.BillingExport != nil {
+		billingUsages = opts.BillingExport.ResourceUsages(resources)
+	}
+
+	syncResult := syncResourceUsages(usageFile, resources, referenceFile, billingUsages)
 
 	return syncResult, nil
 }
 
-func syncResourceUsages(usageFile *UsageFile, resources []*schema.Resource, referenceFile *ReferenceFile) *SyncResult {
+func syncResourceUsages(usageFile *UsageFile, resources []*schema.Resource, referenceFile *ReferenceFile, billingUsages map[string]map[string]float64) *SyncResult {
 	syncResult := &SyncResult{
 		EstimationErrors: make(map[string]error),
 	}
@@ -150,6 +165,14 @@ func syncResourceUsages(usageFile *UsageFile, resources []*schema.Resource, refe
 			mergeResourceUsageWithUsageData(resourceUsage, estimatedUsageData)
 		}
 
+		// Merge in the usage from the billing export, since it's the actual usage
+		if values, ok := billingUsages[resource.Name]; ok {
+			syncResult.BillingExportCount++
+
+			billingUsageData := schema.NewUsageData(resource.Name, schema.ParseAttributes(billingUsageMap(resourceUsage.Items, values, "")))
+			mergeResourceUsageWithUsageData(resourceUsage, billingUsageData)
+		}
+
 		resourceUsages = append(resourceUsages, resourceUsage)
 	}
 
@@ -160,6 +183,45 @@ func syncResourceUsages(usageFile *UsageFile, resources []*schema.Resource, refe
 	return syncResult
 }
 
+// billingUsageMap returns the usage values from the billing export for the usage
+// items, in the same format as ResourceUsage.Map. The values of sub-resource usage
+// items are keyed by the sub-resource key and the item key, e.g. standard.storage_gb.
+func billingUsageMap(items []*schema.UsageItem, values map[string]float64, prefix string) map[string]interface{} {
+	m := make(map[string]interface{})
+
+	for _, item := range items {
+		key := prefix + item.Key
+
+		if item.ValueType == schema.SubResourceUsage {
+			subResourceUsage, _ := item.Value.(*ResourceUsage)
+			if subResourceUsage == nil {
+				subResourceUsage, _ = item.DefaultValue.(*ResourceUsage)
+			}
+
+			if subResourceUsage != nil {
+				if subMap := billingUsageMap(subResourceUsage.Items, values, key+"."); len(subMap) > 0 {
+					m[item.Key] = subMap
+				}
+			}
+			continue
+		}
+
+		v, ok := values[key]
+		if !ok {
+			continue
+		}
+
+		// Round the values of integer usage, since converting them would truncate them
+		if item.ValueType == schema.Int64 {
+			m[item.Key] = int64(math.Round(v))
+		} else {
+			m[item.Key] = v
+		}
+	}
+
+	return m
+}
+
 // replaceResourceUsages override usageItems from dest with usageItems from src
 func replaceResourceUsages(dest *ResourceUsage, src *ResourceUsage, opts ReplaceResourceUsagesOpts) {
 	if dest == nil || src == nil {
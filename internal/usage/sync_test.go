@@ -428,3 +428,61 @@ func TestReplaceResourceUsageWithUsageDataDeep(t *testing.T) {
 	assert.Len(t, subResource2.Items, 1)
 	assert.Equal(t, int64(10), subResource2.Items[0].Value.(int64))
 }
+
+func TestBillingUsageMap(t *testing.T) {
+	items := []*schema.UsageItem{
+		{
+			Key:       "monthly_requests",
+			ValueType: schema.Int64,
+		},
+		{
+			Key:       "storage_gb",
+			ValueType: schema.Float64,
+		},
+		{
+			Key:       "not_in_export",
+			ValueType: schema.Int64,
+		},
+		{
+			Key:       "standard",
+			ValueType: schema.SubResourceUsage,
+			DefaultValue: &ResourceUsage{
+				Name: "standard",
+				Items: []*schema.UsageItem{
+					{
+						Key:       "storage_gb",
+						ValueType: schema.Float64,
+					},
+				},
+			},
+		},
+		{
+			Key:       "glacier",
+			ValueType: schema.SubResourceUsage,
+			DefaultValue: &ResourceUsage{
+				Name: "glacier",
+				Items: []*schema.UsageItem{
+					{
+						Key:       "storage_gb",
+						ValueType: schema.Int64,
+					},
+				},
+			},
+		},
+	}
+
+	values := map[string]float64{
+		"monthly_requests":    1500.6,
+		"storage_gb":          10.25,
+		"standard.storage_gb": 20.5,
+		"not_in_schema":       1,
+	}
+
+	assert.Equal(t, map[string]interface{}{
+		"monthly_requests": int64(1501),
+		"storage_gb":       10.25,
+		"standard": map[string]interface{}{
+			"storage_gb": 20.5,
+		},
+	}, billingUsageMap(items, values, ""))
+}
@@ -23,6 +23,17 @@ type UsageFile struct { // nolint:revive
 	RawResourceUsage yamlv3.Node `yaml:"resource_usage"`
 	// The raw usage is then parsed into this struct
 	ResourceUsages []*ResourceUsage `yaml:"-"`
+	// Profiles override the resource usage, e.g. for low and peak traffic. They're
+	// kept as a YAML node so they're written back as they are when the file is synced
+	RawProfiles yamlv3.Node     `yaml:"profiles,omitempty"`
+	Profiles    []*UsageProfile `yaml:"-"`
+}
+
+// UsageProfile is a named set of resource usages that override the resource usage
+// of the usage file.
+type UsageProfile struct {
+	Name           string
+	ResourceUsages []*ResourceUsage
 }
 
 // CreateUsageFile creates a blank usage file if it does not exists
@@ -91,6 +102,11 @@ func LoadUsageFileFromString(s string) (*UsageFile, error) {
 		return usageFile, errors.Wrap(err, "Error loading YAML file")
 	}
 
+	err = usageFile.parseProfiles()
+	if err != nil {
+		return usageFile, errors.Wrap(err, "Error loading YAML file")
+	}
+
 	return usageFile, nil
 }
 
@@ -126,6 +142,16 @@ See https://infracost.io/usage-file/ for docs`,
 		&u.RawResourceUsage,
 	)
 
+	if len(u.Profiles) > 0 {
+		root.Content = append(root.Content,
+			&yamlv3.Node{
+				Kind:  yamlv3.ScalarNode,
+				Value: "profiles",
+			},
+			&u.RawProfiles,
+		)
+	}
+
 	// Add a comment to the first commented-out resource
 	for _, node := range u.RawResourceUsage.Content {
 		if isNodeMarkedAsCommented(node) {
@@ -161,6 +187,53 @@ func (u *UsageFile) ToUsageDataMap() map[string]*schema.UsageData {
 	return m
 }
 
+// ProfileNames returns the names of the usage profiles in the order they're
+// defined in the file.
+func (u *UsageFile) ProfileNames() []string {
+	names := make([]string, 0, len(u.Profiles))
+	for _, profile := range u.Profiles {
+		names = append(names, profile.Name)
+	}
+
+	return names
+}
+
+// ApplyProfile overrides the resource usages with the values of the named profile.
+// Values that aren't set in the profile are kept, so a profile only needs to
+// contain the values that are different.
+func (u *UsageFile) ApplyProfile(name string) error {
+	var profile *UsageProfile
+	for _, p := range u.Profiles {
+		if p.Name == name {
+			profile = p
+			break
+		}
+	}
+
+	if profile == nil {
+		if len(u.Profiles) == 0 {
+			return fmt.Errorf("Usage profile %s not found, the usage file has no profiles", name)
+		}
+		return fmt.Errorf("Usage profile %s not found, the usage file has the profiles: %s", name, strings.Join(u.ProfileNames(), ", "))
+	}
+
+	existing := resourceUsagesMap(u.ResourceUsages)
+
+	for _, resourceUsage := range profile.ResourceUsages {
+		dest, ok := existing[resourceUsage.Name]
+		if !ok {
+			u.ResourceUsages = append(u.ResourceUsages, resourceUsage)
+			continue
+		}
+
+		// Merging the existing values into the profile keeps the profile values
+		resourceUsage.MergeResourceUsage(dest)
+		dest.Items = resourceUsage.Items
+	}
+
+	return nil
+}
+
 func (u *UsageFile) checkVersion() bool {
 	v := u.Version
 	if !strings.HasPrefix(u.Version, "v") {
@@ -180,7 +253,12 @@ func (u *UsageFile) InvalidKeys() ([]string, error) {
 		return invalidKeys, err
 	}
 
-	for _, resourceUsage := range u.ResourceUsages {
+	resourceUsages := u.ResourceUsages
+	for _, profile := range u.Profiles {
+		resourceUsages = append(resourceUsages, profile.ResourceUsages...)
+	}
+
+	for _, resourceUsage := range resourceUsages {
 		refResourceUsage := refFile.FindMatchingResourceUsage(resourceUsage.Name)
 		if refResourceUsage == nil {
 			continue
@@ -241,6 +319,33 @@ func (u *UsageFile) parseResourceUsages() error {
 	return nil
 }
 
+func (u *UsageFile) parseProfiles() error {
+	if u.RawProfiles.Kind == 0 {
+		return nil
+	}
+
+	if u.RawProfiles.Kind != yamlv3.MappingNode {
+		return errors.New("Expected profiles to be a map of profile names to resource usages")
+	}
+
+	for i := 0; i < len(u.RawProfiles.Content); i += 2 {
+		keyNode := u.RawProfiles.Content[i]
+		valNode := u.RawProfiles.Content[i+1]
+
+		resourceUsages, err := ResourceUsagesFromYAML(*valNode)
+		if err != nil {
+			return errors.Wrapf(err, "Error parsing usage profile %s", keyNode.Value)
+		}
+
+		u.Profiles = append(u.Profiles, &UsageProfile{
+			Name:           keyNode.Value,
+			ResourceUsages: resourceUsages,
+		})
+	}
+
+	return nil
+}
+
 func (u *UsageFile) dumpResourceUsages() bool {
 	var allCommented bool
 	u.RawResourceUsage, allCommented = ResourceUsagesToYAML(u.ResourceUsages)
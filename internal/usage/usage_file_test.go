@@ -3,6 +3,7 @@ package usage_test
 import (
 	"github.com/infracost/infracost/internal/usage"
 	"github.com/stretchr/testify/assert"
+	"path/filepath"
 	"testing"
 
 	"github.com/infracost/infracost/internal/providers/terraform/tftest"
@@ -105,3 +106,82 @@ resource_usage:
 	}
 
 }
+
+func TestUsageFileProfiles(t *testing.T) {
+	contents := `
+version: 0.1
+resource_usage:
+  aws_lambda_function.hello_world:
+    monthly_requests: 100000
+    request_duration_ms: 500
+  aws_s3_bucket.bucket:
+    standard:
+      storage_gb: 100
+      monthly_tier_1_requests: 1000
+profiles:
+  low:
+    aws_lambda_function.hello_world:
+      monthly_requests: 10000
+  peak:
+    aws_lambda_function.hello_world:
+      monthly_requests: 10000000
+    aws_s3_bucket.bucket:
+      standard:
+        storage_gb: 1000
+    aws_dynamodb_table.table:
+      monthly_read_request_units: 5000000
+`
+
+	usageFile, err := usage.LoadUsageFileFromString(contents)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"low", "peak"}, usageFile.ProfileNames())
+
+	err = usageFile.ApplyProfile("peak")
+	assert.NoError(t, err)
+
+	m := usageFile.ToUsageDataMap()
+
+	lambda := m["aws_lambda_function.hello_world"]
+	assert.Equal(t, int64(10000000), lambda.Get("monthly_requests").Int())
+	assert.Equal(t, int64(500), lambda.Get("request_duration_ms").Int())
+
+	bucket := m["aws_s3_bucket.bucket"]
+	assert.Equal(t, int64(1000), bucket.Get("standard").Get("storage_gb").Int())
+	assert.Equal(t, int64(1000), bucket.Get("standard").Get("monthly_tier_1_requests").Int())
+
+	table := m["aws_dynamodb_table.table"]
+	assert.NotNil(t, table)
+	assert.Equal(t, int64(5000000), table.Get("monthly_read_request_units").Int())
+
+	usageFile, err = usage.LoadUsageFileFromString(contents)
+	assert.NoError(t, err)
+
+	err = usageFile.ApplyProfile("expected")
+	assert.EqualError(t, err, "Usage profile expected not found, the usage file has the profiles: low, peak")
+}
+
+func TestUsageFileProfilesWritten(t *testing.T) {
+	usageFile, err := usage.LoadUsageFileFromString(`
+version: 0.1
+resource_usage:
+  aws_lambda_function.hello_world:
+    monthly_requests: 100000
+profiles:
+  peak:
+    aws_lambda_function.hello_world:
+      monthly_requests: 10000000
+`)
+	assert.NoError(t, err)
+
+	path := filepath.Join(t.TempDir(), "infracost-usage.yml")
+	err = usageFile.WriteToPath(path)
+	assert.NoError(t, err)
+
+	written, err := usage.LoadUsageFile(path)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"peak"}, written.ProfileNames())
+
+	err = written.ApplyProfile("peak")
+	assert.NoError(t, err)
+	assert.Equal(t, int64(10000000), written.ToUsageDataMap()["aws_lambda_function.hello_world"].Get("monthly_requests").Int())
+}
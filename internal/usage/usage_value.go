@@ -0,0 +1,88 @@
+package usage
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/infracost/infracost/internal/schema"
+)
+
+// ParseUsageValue parses a usage value that's entered as a string, e.g. in a prompt,
+// into the value type of the usage item. String array values are comma separated.
+func ParseUsageValue(valueType schema.UsageVariableType, s string) (interface{}, error) {
+	s = strings.TrimSpace(s)
+
+	switch valueType {
+	case schema.Int64:
+		i, err := strconv.ParseInt(s, 10, 64)
+		if err != nil {
+			return nil, errors.New("Value must be a whole number")
+		}
+		return i, nil
+	case schema.Float64:
+		f, err := strconv.ParseFloat(s, 64)
+		if err != nil {
+			return nil, errors.New("Value must be a number")
+		}
+		return f, nil
+	case schema.StringArray:
+		l := make([]string, 0)
+		for _, v := range strings.Split(s, ",") {
+			if v = strings.TrimSpace(v); v != "" {
+				l = append(l, v)
+			}
+		}
+		return l, nil
+	case schema.SubResourceUsage:
+		return nil, errors.New("Sub-resource usage values can't be parsed")
+	}
+
+	return s, nil
+}
+
+// FormatUsageValue formats a usage value so it can be parsed by ParseUsageValue. It
+// returns an empty string for nil values.
+func FormatUsageValue(v interface{}) string {
+	switch val := v.(type) {
+	case nil:
+		return ""
+	case float64:
+		return strconv.FormatFloat(val, 'f', -1, 64)
+	case []string:
+		return strings.Join(val, ", ")
+	}
+
+	return fmt.Sprintf("%v", v)
+}
+
+// SubResourceUsageValue returns the value of a sub-resource usage item so the values
+// of its sub-items can be set. If the item has no value, or its value is shared with
+// the default value, it's set to a copy of the sub-items so the defaults don't change.
+func SubResourceUsageValue(item *schema.UsageItem) *ResourceUsage {
+	value, _ := item.Value.(*ResourceUsage)
+	defaultValue, _ := item.DefaultValue.(*ResourceUsage)
+
+	if value != nil && value != defaultValue {
+		return value
+	}
+
+	src := value
+	if src == nil {
+		src = defaultValue
+	}
+
+	subResourceUsage := &ResourceUsage{Name: item.Key}
+	if src != nil {
+		for _, subItem := range src.Items {
+			c := *subItem
+			subResourceUsage.Items = append(subResourceUsage.Items, &c)
+		}
+	}
+
+	item.Value = subResourceUsage
+
+	return subResourceUsage
+}
@@ -0,0 +1,86 @@
+package usage
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/infracost/infracost/internal/schema"
+)
+
+func TestParseUsageValue(t *testing.T) {
+	tests := []struct {
+		valueType schema.UsageVariableType
+		input     string
+		want      interface{}
+		wantErr   string
+	}{
+		{schema.Int64, " 100 ", int64(100), ""},
+		{schema.Int64, "1.5", nil, "Value must be a whole number"},
+		{schema.Float64, "1.5", 1.5, ""},
+		{schema.Float64, "abc", nil, "Value must be a number"},
+		{schema.String, " us-east-1 ", "us-east-1", ""},
+		{schema.StringArray, "a, b,,c", []string{"a", "b", "c"}, ""},
+		{schema.StringArray, "", []string{}, ""},
+		{schema.SubResourceUsage, "1", nil, "Sub-resource usage values can't be parsed"},
+	}
+
+	for _, tt := range tests {
+		got, err := ParseUsageValue(tt.valueType, tt.input)
+		if tt.wantErr != "" {
+			assert.EqualError(t, err, tt.wantErr, tt.input)
+			continue
+		}
+
+		assert.NoError(t, err, tt.input)
+		assert.Equal(t, tt.want, got, tt.input)
+	}
+}
+
+func TestFormatUsageValue(t *testing.T) {
+	assert.Equal(t, "", FormatUsageValue(nil))
+	assert.Equal(t, "100", FormatUsageValue(int64(100)))
+	assert.Equal(t, "0.25", FormatUsageValue(0.25))
+	assert.Equal(t, "10", FormatUsageValue(10.0))
+	assert.Equal(t, "a, b", FormatUsageValue([]string{"a", "b"}))
+	assert.Equal(t, "us-east-1", FormatUsageValue("us-east-1"))
+}
+
+func TestSubResourceUsageValue(t *testing.T) {
+	defaultValue := &ResourceUsage{
+		Name: "standard",
+		Items: []*schema.UsageItem{
+			{Key: "storage_gb", ValueType: schema.Float64, DefaultValue: 0.0},
+		},
+	}
+
+	item := &schema.UsageItem{
+		Key:          "standard",
+		ValueType:    schema.SubResourceUsage,
+		DefaultValue: defaultValue,
+	}
+
+	value := SubResourceUsageValue(item)
+	value.Items[0].Value = 10.0
+
+	assert.Same(t, value, item.Value)
+	assert.Nil(t, defaultValue.Items[0].Value)
+	// The value is only copied once
+	assert.Same(t, value, SubResourceUsageValue(item))
+
+	// The value is copied when it's shared with the default value
+	defaultValue.Items[0].Value = 5.0
+	shared := &schema.UsageItem{
+		Key:          "standard",
+		ValueType:    schema.SubResourceUsage,
+		Value:        defaultValue,
+		DefaultValue: defaultValue,
+	}
+
+	value = SubResourceUsageValue(shared)
+	value.Items[0].Value = 20.0
+
+	assert.NotSame(t, defaultValue, value)
+	assert.Equal(t, 5.0, defaultValue.Items[0].Value)
+	assert.Equal(t, map[string]interface{}{"storage_gb": 20.0}, value.Map())
+}